@@ -64,9 +64,65 @@ func main() {
 		logger.Field{Key: "version", Value: version},
 	)
 
+	revisionStore := services.NewRevisionStoreService(cfg, log)
+	revisionRetention := services.NewRevisionRetentionService(cfg, log, internalDB, revisionStore)
+	if err := revisionRetention.Start(); err != nil {
+		log.Error("Could not start revision retention service", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	defer func() {
+		_ = revisionRetention.Stop()
+	}()
+
+	revisionIntegrity := services.NewRevisionIntegrityService(cfg, log, internalDB, revisionStore)
+	if err := revisionIntegrity.Start(); err != nil {
+		log.Error("Could not start revision integrity service", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	defer func() {
+		_ = revisionIntegrity.Stop()
+	}()
+
 	fileEditor := services.NewFileEditorService(log)
+	pathResolver, err := services.NewPathResolverService(cfg, log)
+	if err != nil {
+		log.Error("Could not load allowed roots", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	fileWatcher := services.NewFileWatcherService(cfg, log, fileEditor, pathResolver, revisionStore)
+	if err := fileWatcher.Start(); err != nil {
+		log.Error("Could not start file watcher service", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	defer func() {
+		_ = fileWatcher.Stop()
+	}()
+
 	processService := services.NewProcessService(log)
-	serverManagerService := services.NewServerManagerService(internalDB, processService, log)
+	processLogs := services.NewProcessLogService(cfg, log)
+	if err := processLogs.Start(); err != nil {
+		log.Error("Could not start process log service", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	defer func() {
+		_ = processLogs.Stop()
+	}()
+
+	serverManagerService := services.NewServerManagerService(cfg, internalDB, processService, processLogs, log)
+	if err := serverManagerService.Start(); err != nil {
+		log.Error("Could not start process supervisor", logger.Field{Key: "error", Value: err})
+		os.Exit(1)
+	}
+
+	defer func() {
+		_ = serverManagerService.Stop()
+	}()
+
 	server := server.NewServer(
 		cfg, log,
 		frontendFiles,
@@ -74,8 +130,13 @@ func main() {
 		version,
 		internalDB,
 		fileEditor,
+		pathResolver,
+		revisionStore,
+		revisionIntegrity,
+		fileWatcher,
 		processService,
 		serverManagerService,
+		processLogs,
 	)
 	if err := server.ListenAndServe(); err != nil {
 		log.Error("Could not start Omnihance A3 Agent server", logger.Field{Key: "error", Value: err})
@@ -278,6 +278,7 @@ export function FileEdit({ filePath }: FileEditProps) {
                 <TextFileEdit
                   filePath={filePath}
                   defaultContent={textFileData.content}
+                  etag={textFileData.etag}
                 />
               )}
             </>
@@ -215,7 +215,9 @@ export function FileTree({ initialPath }: FileTreeProps) {
     if (item.kind === 'directory') {
       let newPath: string;
       if (!currentPath || currentPath === '') {
-        if (isWindows && /^[A-Za-z]:$/.test(item.name)) {
+        if (item.path) {
+          newPath = item.path;
+        } else if (isWindows && /^[A-Za-z]:$/.test(item.name)) {
           newPath = `${item.name}\\`;
         } else {
           newPath = '/' + item.name;
@@ -254,9 +256,10 @@ export function FileTree({ initialPath }: FileTreeProps) {
   const getFullPath = (item: FileNode): string => {
     if (!currentPath || currentPath === '') {
       const path =
-        isWindows && /^[A-Za-z]:$/.test(item.name)
+        item.path ??
+        (isWindows && /^[A-Za-z]:$/.test(item.name)
           ? `${item.name}\\`
-          : '/' + item.name;
+          : '/' + item.name);
       return normalizePath(path, isWindows);
     }
 
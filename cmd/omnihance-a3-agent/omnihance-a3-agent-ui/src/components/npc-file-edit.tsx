@@ -20,7 +20,12 @@ import {
 } from '@/components/ui/table';
 import { Link } from '@tanstack/react-router';
 import { toast } from 'sonner';
-import { APIError, updateNPCFile, type NPCFileAPIData } from '@/lib/api';
+import {
+  APIError,
+  updateNPCFile,
+  type NPCFileAPIData,
+  type WithETag,
+} from '@/lib/api';
 import { queryKeys } from '@/constants';
 
 const attackSchema = z.object({
@@ -56,7 +61,7 @@ type NPCFileFormData = z.infer<typeof npcFileSchema>;
 
 interface NPCFileEditProps {
   filePath: string;
-  defaultData: NPCFileAPIData;
+  defaultData: WithETag<NPCFileAPIData>;
 }
 
 export function NPCFileEdit({ filePath, defaultData }: NPCFileEditProps) {
@@ -69,10 +74,12 @@ export function NPCFileEdit({ filePath, defaultData }: NPCFileEditProps) {
   });
 
   const hasInitialized = useRef(false);
+  const etagRef = useRef(defaultData.etag);
 
   useEffect(() => {
     if (!hasInitialized.current && defaultData) {
       form.reset(defaultData);
+      etagRef.current = defaultData.etag;
       hasInitialized.current = true;
     }
   }, [defaultData, form]);
@@ -86,7 +93,7 @@ export function NPCFileEdit({ filePath, defaultData }: NPCFileEditProps) {
 
   const mutation = useMutation({
     mutationFn: (values: NPCFileFormData) =>
-      updateNPCFile({ path: filePath }, values),
+      updateNPCFile({ path: filePath }, values, etagRef.current),
     onSuccess: () => {
       queryClient.invalidateQueries({
         queryKey: queryKeys.npcFile(filePath),
@@ -23,6 +23,7 @@ import {
   APIError,
   updateSpawnFile,
   type SpawnFileAPIData,
+  type WithETag,
   getMonsters,
 } from '@/lib/api';
 import { queryKeys } from '@/constants';
@@ -44,7 +45,7 @@ type SpawnFileFormData = z.infer<typeof spawnFileSchema>;
 
 interface SpawnFileEditProps {
   filePath: string;
-  defaultData: SpawnFileAPIData;
+  defaultData: WithETag<SpawnFileAPIData>;
 }
 
 export function SpawnFileEdit({ filePath, defaultData }: SpawnFileEditProps) {
@@ -57,10 +58,12 @@ export function SpawnFileEdit({ filePath, defaultData }: SpawnFileEditProps) {
   });
 
   const hasInitialized = useRef(false);
+  const etagRef = useRef(defaultData.etag);
 
   useEffect(() => {
     if (!hasInitialized.current && defaultData) {
       form.reset(defaultData);
+      etagRef.current = defaultData.etag;
       hasInitialized.current = true;
     }
   }, [defaultData, form]);
@@ -97,7 +100,7 @@ export function SpawnFileEdit({ filePath, defaultData }: SpawnFileEditProps) {
 
   const mutation = useMutation({
     mutationFn: (values: SpawnFileFormData) =>
-      updateSpawnFile({ path: filePath }, values),
+      updateSpawnFile({ path: filePath }, values, etagRef.current),
     onSuccess: () => {
       queryClient.invalidateQueries({
         queryKey: queryKeys.spawnFile(filePath),
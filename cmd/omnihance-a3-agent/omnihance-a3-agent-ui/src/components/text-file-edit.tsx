@@ -24,12 +24,18 @@ type TextFileFormData = z.infer<typeof textFileSchema>;
 interface TextFileEditProps {
   filePath: string;
   defaultContent: string;
+  etag?: string;
 }
 
-export function TextFileEdit({ filePath, defaultContent }: TextFileEditProps) {
+export function TextFileEdit({
+  filePath,
+  defaultContent,
+  etag,
+}: TextFileEditProps) {
   const router = useRouter();
   const queryClient = useQueryClient();
   const previousFilePathRef = useRef<string>(filePath);
+  const etagRef = useRef(etag);
 
   const form = useForm<TextFileFormData>({
     resolver: zodResolver(textFileSchema),
@@ -41,15 +47,20 @@ export function TextFileEdit({ filePath, defaultContent }: TextFileEditProps) {
   useEffect(() => {
     if (previousFilePathRef.current !== filePath) {
       previousFilePathRef.current = filePath;
+      etagRef.current = etag;
       form.reset({
         content: defaultContent,
       });
     }
-  }, [filePath, defaultContent, form]);
+  }, [filePath, defaultContent, etag, form]);
 
   const mutation = useMutation({
     mutationFn: (data: TextFileFormData) =>
-      updateTextFile({ path: filePath }, { content: data.content }),
+      updateTextFile(
+        { path: filePath },
+        { content: data.content },
+        etagRef.current,
+      ),
     onSuccess: () => {
       queryClient.invalidateQueries({
         queryKey: queryKeys.textFile(filePath),
@@ -208,6 +208,7 @@ export type NPCFileAPIData = z.infer<typeof NPCFileAPIDataSchema>;
 type FileNode = {
   id: string;
   name: string;
+  path?: string;
   kind: 'directory' | 'file';
   depth: number;
   last_modified?: string;
@@ -232,6 +233,7 @@ const FileNodeSchema: z.ZodType<FileNode> = z.lazy(() =>
   z.object({
     id: z.string(),
     name: z.string(),
+    path: z.string().optional(),
     kind: z.enum(['directory', 'file']),
     depth: z.number().int(),
     last_modified: z.string().optional(),
@@ -289,6 +291,19 @@ const UpdateFileResponseSchema = z.object({
 
 export type UpdateFileResponse = z.infer<typeof UpdateFileResponseSchema>;
 
+// File data together with the ETag it was loaded with. Updates send the ETag
+// back as If-Match so that changes saved by someone else are not overwritten.
+export type WithETag<T> = T & { etag?: string };
+
+function getETag(headers: { [key: string]: unknown }): string | undefined {
+  const etag = headers['etag'];
+  return typeof etag === 'string' ? etag : undefined;
+}
+
+function ifMatchHeaders(etag?: string) {
+  return etag ? { 'If-Match': etag } : undefined;
+}
+
 const RevisionSummaryResponseSchema = z.object({
   count: z.number().int().nonnegative(),
   last_revision_at: z.number().int().nullable().optional(),
@@ -592,26 +607,29 @@ export async function getFileTree(
 
 export async function getNPCFile(
   params: GetNPCFileParams,
-): Promise<NPCFileAPIData> {
+): Promise<WithETag<NPCFileAPIData>> {
   const response = await axiosInstance.get<unknown>(API_ROUTES.NPC_FILE, {
     params,
   });
-  return validateResponse(
+  const data = validateResponse(
     NPCFileAPIDataSchema,
     response.data,
     API_ROUTES.NPC_FILE,
   );
+  return { ...data, etag: getETag(response.headers) };
 }
 
 export async function updateNPCFile(
   params: GetNPCFileParams,
   data: NPCFileAPIData,
+  etag?: string,
 ): Promise<UpdateFileResponse> {
   const response = await axiosInstance.put<unknown>(
     API_ROUTES.NPC_FILE,
     NPCFileAPIDataSchema.parse(data),
     {
       params,
+      headers: ifMatchHeaders(etag),
     },
   );
   return validateResponse(
@@ -623,26 +641,29 @@ export async function updateNPCFile(
 
 export async function getTextFile(
   params: GetTextFileParams,
-): Promise<TextFileAPIData> {
+): Promise<WithETag<TextFileAPIData>> {
   const response = await axiosInstance.get<unknown>(API_ROUTES.TEXT_FILE, {
     params,
   });
-  return validateResponse(
+  const data = validateResponse(
     TextFileAPIDataSchema,
     response.data,
     API_ROUTES.TEXT_FILE,
   );
+  return { ...data, etag: getETag(response.headers) };
 }
 
 export async function updateTextFile(
   params: GetTextFileParams,
   data: TextFileAPIData,
+  etag?: string,
 ): Promise<UpdateFileResponse> {
   const response = await axiosInstance.put<unknown>(
     API_ROUTES.TEXT_FILE,
     TextFileAPIDataSchema.parse(data),
     {
       params,
+      headers: ifMatchHeaders(etag),
     },
   );
   return validateResponse(
@@ -654,26 +675,29 @@ export async function updateTextFile(
 
 export async function getSpawnFile(
   params: GetSpawnFileParams,
-): Promise<SpawnFileAPIData> {
+): Promise<WithETag<SpawnFileAPIData>> {
   const response = await axiosInstance.get<unknown>(API_ROUTES.SPAWN_FILE, {
     params,
   });
-  return validateResponse(
+  const data = validateResponse(
     SpawnFileAPIDataSchema,
     response.data,
     API_ROUTES.SPAWN_FILE,
   );
+  return { ...data, etag: getETag(response.headers) };
 }
 
 export async function updateSpawnFile(
   params: GetSpawnFileParams,
   data: SpawnFileAPIData,
+  etag?: string,
 ): Promise<UpdateFileResponse> {
   const response = await axiosInstance.put<unknown>(
     API_ROUTES.SPAWN_FILE,
     SpawnFileAPIDataSchema.parse(data),
     {
       params,
+      headers: ifMatchHeaders(etag),
     },
   );
   return validateResponse(
@@ -26,6 +26,26 @@ type EnvVars struct {
 	SessionTimeoutSeconds            int
 	CookieSecret                     string
 	MaxFileUploadSizeMb              int
+	MaxArchiveSizeMb                 int
+	FileLockTTLSeconds               int
+	RevisionCompression              string
+	RevisionRetentionCount           int
+	RevisionRetentionDays            int
+	RevisionRetentionSchedule        string
+	RevisionIntegritySchedule        string
+	AllowedRoots                     []string
+	FileWatcherEnabled               bool
+	FileWatcherDebounceMs            int
+	SupervisorEnabled                bool
+	SupervisorCheckIntervalSeconds   int
+	SupervisorRestartBackoffSeconds  int
+	SupervisorMaxBackoffSeconds      int
+	SupervisorMaxRestartAttempts     int
+	SupervisorResetAfterSeconds      int
+	ProcessLogDirectory              string
+	ProcessLogMaxSizeMb              int
+	ProcessLogMaxFiles               int
+	ProcessLogBufferLines            int
 }
 
 var defaultEnvVars = map[string]string{
@@ -40,6 +60,26 @@ var defaultEnvVars = map[string]string{
 	"METRICS_ENABLED":                     "true",
 	"SESSION_TIMEOUT_SECONDS":             fmt.Sprintf("%d", 60*60*24*30),
 	"COOKIE_SECRET":                       utils.GenerateRandomToken(32),
+	"FILE_LOCK_TTL_SECONDS":               "300",
+	"MAX_ARCHIVE_SIZE_MB":                 "256",
+	"REVISION_COMPRESSION":                "zstd",
+	"REVISION_RETENTION_COUNT":            "0",
+	"REVISION_RETENTION_DAYS":             "0",
+	"REVISION_RETENTION_SCHEDULE":         "@daily",
+	"REVISION_INTEGRITY_SCHEDULE":         "0 30 3 * * *",
+	"ALLOWED_ROOTS":                       "",
+	"FILE_WATCHER_ENABLED":                "true",
+	"FILE_WATCHER_DEBOUNCE_MS":            "500",
+	"SUPERVISOR_ENABLED":                  "true",
+	"SUPERVISOR_CHECK_INTERVAL_SECONDS":   "10",
+	"SUPERVISOR_RESTART_BACKOFF_SECONDS":  "5",
+	"SUPERVISOR_MAX_BACKOFF_SECONDS":      "300",
+	"SUPERVISOR_MAX_RESTART_ATTEMPTS":     "5",
+	"SUPERVISOR_RESET_AFTER_SECONDS":      "600",
+	"PROCESS_LOG_DIRECTORY":               filepath.Join("logs", "processes"),
+	"PROCESS_LOG_MAX_SIZE_MB":             "10",
+	"PROCESS_LOG_MAX_FILES":               "5",
+	"PROCESS_LOG_BUFFER_LINES":            "2000",
 }
 
 func New() *EnvVars {
@@ -101,6 +141,115 @@ func New() *EnvVars {
 		maxFileUploadSizeMb = 2
 	}
 
+	maxArchiveSizeMb, err := strconv.Atoi(os.Getenv("MAX_ARCHIVE_SIZE_MB"))
+	if err != nil {
+		slog.Warn("Could not get max archive size: " + err.Error())
+		maxArchiveSizeMb = 256
+	}
+
+	fileLockTTLSeconds, err := strconv.Atoi(os.Getenv("FILE_LOCK_TTL_SECONDS"))
+	if err != nil {
+		slog.Warn("Could not get file lock TTL seconds: " + err.Error())
+		fileLockTTLSeconds = 300
+	}
+
+	revisionCompression := strings.ToLower(os.Getenv("REVISION_COMPRESSION"))
+	switch revisionCompression {
+	case "none", "gzip", "zstd":
+	default:
+		slog.Warn("Unknown revision compression " + revisionCompression + ", using zstd")
+		revisionCompression = "zstd"
+	}
+
+	revisionRetentionCount, err := strconv.Atoi(os.Getenv("REVISION_RETENTION_COUNT"))
+	if err != nil {
+		slog.Warn("Could not get revision retention count: " + err.Error())
+		revisionRetentionCount = 0
+	}
+
+	revisionRetentionDays, err := strconv.Atoi(os.Getenv("REVISION_RETENTION_DAYS"))
+	if err != nil {
+		slog.Warn("Could not get revision retention days: " + err.Error())
+		revisionRetentionDays = 0
+	}
+
+	var allowedRoots []string
+	for _, root := range filepath.SplitList(os.Getenv("ALLOWED_ROOTS")) {
+		if root = strings.TrimSpace(root); root != "" {
+			allowedRoots = append(allowedRoots, root)
+		}
+	}
+
+	if len(allowedRoots) == 0 {
+		slog.Warn("ALLOWED_ROOTS is not set, file access through the API is disabled")
+	}
+
+	fileWatcherEnabled, err := strconv.ParseBool(os.Getenv("FILE_WATCHER_ENABLED"))
+	if err != nil {
+		slog.Warn("Could not get file watcher enabled: " + err.Error())
+		fileWatcherEnabled = true
+	}
+
+	fileWatcherDebounceMs, err := strconv.Atoi(os.Getenv("FILE_WATCHER_DEBOUNCE_MS"))
+	if err != nil || fileWatcherDebounceMs < 0 {
+		slog.Warn("Could not get file watcher debounce milliseconds, using 500")
+		fileWatcherDebounceMs = 500
+	}
+
+	supervisorEnabled, err := strconv.ParseBool(os.Getenv("SUPERVISOR_ENABLED"))
+	if err != nil {
+		slog.Warn("Could not get supervisor enabled: " + err.Error())
+		supervisorEnabled = true
+	}
+
+	supervisorCheckIntervalSeconds, err := strconv.Atoi(os.Getenv("SUPERVISOR_CHECK_INTERVAL_SECONDS"))
+	if err != nil || supervisorCheckIntervalSeconds < 1 {
+		slog.Warn("Could not get supervisor check interval seconds, using 10")
+		supervisorCheckIntervalSeconds = 10
+	}
+
+	supervisorRestartBackoffSeconds, err := strconv.Atoi(os.Getenv("SUPERVISOR_RESTART_BACKOFF_SECONDS"))
+	if err != nil || supervisorRestartBackoffSeconds < 0 {
+		slog.Warn("Could not get supervisor restart backoff seconds, using 5")
+		supervisorRestartBackoffSeconds = 5
+	}
+
+	supervisorMaxBackoffSeconds, err := strconv.Atoi(os.Getenv("SUPERVISOR_MAX_BACKOFF_SECONDS"))
+	if err != nil || supervisorMaxBackoffSeconds < supervisorRestartBackoffSeconds {
+		slog.Warn("Could not get supervisor max backoff seconds, using 300")
+		supervisorMaxBackoffSeconds = max(300, supervisorRestartBackoffSeconds)
+	}
+
+	supervisorMaxRestartAttempts, err := strconv.Atoi(os.Getenv("SUPERVISOR_MAX_RESTART_ATTEMPTS"))
+	if err != nil || supervisorMaxRestartAttempts < 0 {
+		slog.Warn("Could not get supervisor max restart attempts, using 5")
+		supervisorMaxRestartAttempts = 5
+	}
+
+	supervisorResetAfterSeconds, err := strconv.Atoi(os.Getenv("SUPERVISOR_RESET_AFTER_SECONDS"))
+	if err != nil || supervisorResetAfterSeconds < 0 {
+		slog.Warn("Could not get supervisor reset after seconds, using 600")
+		supervisorResetAfterSeconds = 600
+	}
+
+	processLogMaxSizeMb, err := strconv.Atoi(os.Getenv("PROCESS_LOG_MAX_SIZE_MB"))
+	if err != nil || processLogMaxSizeMb < 1 {
+		slog.Warn("Could not get process log max size MB, using 10")
+		processLogMaxSizeMb = 10
+	}
+
+	processLogMaxFiles, err := strconv.Atoi(os.Getenv("PROCESS_LOG_MAX_FILES"))
+	if err != nil || processLogMaxFiles < 1 {
+		slog.Warn("Could not get process log max files, using 5")
+		processLogMaxFiles = 5
+	}
+
+	processLogBufferLines, err := strconv.Atoi(os.Getenv("PROCESS_LOG_BUFFER_LINES"))
+	if err != nil || processLogBufferLines < 1 {
+		slog.Warn("Could not get process log buffer lines, using 2000")
+		processLogBufferLines = 2000
+	}
+
 	return &EnvVars{
 		Port:                             os.Getenv("PORT"),
 		LogLevel:                         os.Getenv("LOG_LEVEL"),
@@ -114,6 +263,26 @@ func New() *EnvVars {
 		SessionTimeoutSeconds:            sessionTimeoutSeconds,
 		CookieSecret:                     cookieSecret,
 		MaxFileUploadSizeMb:              maxFileUploadSizeMb,
+		MaxArchiveSizeMb:                 maxArchiveSizeMb,
+		FileLockTTLSeconds:               fileLockTTLSeconds,
+		RevisionCompression:              revisionCompression,
+		RevisionRetentionCount:           revisionRetentionCount,
+		RevisionRetentionDays:            revisionRetentionDays,
+		RevisionRetentionSchedule:        os.Getenv("REVISION_RETENTION_SCHEDULE"),
+		RevisionIntegritySchedule:        os.Getenv("REVISION_INTEGRITY_SCHEDULE"),
+		AllowedRoots:                     allowedRoots,
+		FileWatcherEnabled:               fileWatcherEnabled,
+		FileWatcherDebounceMs:            fileWatcherDebounceMs,
+		SupervisorEnabled:                supervisorEnabled,
+		SupervisorCheckIntervalSeconds:   supervisorCheckIntervalSeconds,
+		SupervisorRestartBackoffSeconds:  supervisorRestartBackoffSeconds,
+		SupervisorMaxBackoffSeconds:      supervisorMaxBackoffSeconds,
+		SupervisorMaxRestartAttempts:     supervisorMaxRestartAttempts,
+		SupervisorResetAfterSeconds:      supervisorResetAfterSeconds,
+		ProcessLogDirectory:              os.Getenv("PROCESS_LOG_DIRECTORY"),
+		ProcessLogMaxSizeMb:              processLogMaxSizeMb,
+		ProcessLogMaxFiles:               processLogMaxFiles,
+		ProcessLogBufferLines:            processLogBufferLines,
 	}
 }
 
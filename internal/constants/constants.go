@@ -9,6 +9,8 @@ const (
 	ErrorCodePathIsDirectory     = "PATH_IS_DIRECTORY"
 	ErrorCodeFileNotViewable     = "FILE_NOT_VIEWABLE"
 	ErrorCodeFileReadError       = "FILE_READ_ERROR"
+	ErrorCodeFileModified        = "FILE_MODIFIED"
+	ErrorCodePathNotAllowed      = "PATH_NOT_ALLOWED"
 )
 
 const (
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+type ChangeSet struct {
+	ID          string     `db:"id" json:"id"`
+	Description string     `db:"description" json:"description"`
+	Status      string     `db:"status" json:"status"`
+	CreatedBy   int64      `db:"created_by" json:"created_by"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedBy   *int64     `db:"updated_by" json:"updated_by"`
+	UpdatedAt   *time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type ChangeSetListItem struct {
+	ID             string     `db:"id" json:"id"`
+	Description    string     `db:"description" json:"description"`
+	Status         string     `db:"status" json:"status"`
+	CreatedBy      int64      `db:"created_by" json:"created_by"`
+	CreatedByEmail *string    `db:"created_by_email" json:"created_by_email"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at" json:"updated_at"`
+	RevisionCount  int64      `db:"revision_count" json:"revision_count"`
+}
+
+func (s *sqliteInternalDB) CreateChangeSet(id, description, status string, createdBy int64) (*ChangeSet, error) {
+	_, err := s.goqu.Insert("change_sets").
+		Prepared(true).
+		Rows(goqu.Record{
+			"id":          id,
+			"description": description,
+			"status":      status,
+			"created_by":  createdBy,
+			"created_at":  goqu.L("CURRENT_TIMESTAMP"),
+		}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to create change set",
+			logger.Field{Key: "change_set_id", Value: id},
+			logger.Field{Key: "created_by", Value: createdBy},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to create change set: %w", err)
+	}
+
+	changeSet, err := s.GetChangeSet(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeSet == nil {
+		return nil, fmt.Errorf("change set %s not found after creation", id)
+	}
+
+	return changeSet, nil
+}
+
+func (s *sqliteInternalDB) GetChangeSet(id string) (*ChangeSet, error) {
+	var changeSet ChangeSet
+	found, err := s.goqu.From("change_sets").
+		Prepared(true).
+		Where(goqu.Ex{"id": id}).
+		ScanStruct(&changeSet)
+	if err != nil {
+		s.logger.Error(
+			"failed to get change set",
+			logger.Field{Key: "change_set_id", Value: id},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get change set: %w", err)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return &changeSet, nil
+}
+
+func (s *sqliteInternalDB) UpdateChangeSetStatus(id, status string, updatedBy int64) error {
+	updateRecord := goqu.Record{
+		"status":     status,
+		"updated_at": goqu.L("CURRENT_TIMESTAMP"),
+		"updated_by": updatedBy,
+	}
+
+	_, err := s.goqu.Update("change_sets").
+		Prepared(true).
+		Set(updateRecord).
+		Where(goqu.Ex{"id": id}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to update change set status",
+			logger.Field{Key: "change_set_id", Value: id},
+			logger.Field{Key: "status", Value: status},
+			logger.Field{Key: "updated_by", Value: updatedBy},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to update change set status: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEmptyChangeSet deletes the change set unless revisions were recorded
+// in it.
+func (s *sqliteInternalDB) DeleteEmptyChangeSet(id string) error {
+	_, err := s.goqu.Delete("change_sets").
+		Prepared(true).
+		Where(
+			goqu.Ex{"id": id},
+			goqu.L("NOT EXISTS (SELECT 1 FROM file_revisions WHERE change_set_id = ?)", id),
+		).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to delete empty change set",
+			logger.Field{Key: "change_set_id", Value: id},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to delete empty change set: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) GetChangeSetsPaginated(page, pageSize int, status string) ([]ChangeSetListItem, int64, error) {
+	countQuery := s.goqu.From("change_sets").
+		Prepared(true).
+		Select(goqu.COUNT("*"))
+
+	if status != "" {
+		countQuery = countQuery.Where(goqu.Ex{"status": status})
+	}
+
+	var totalCount int64
+	_, err := countQuery.ScanVal(&totalCount)
+	if err != nil {
+		s.logger.Error(
+			"failed to get change sets count",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get change sets count: %w", err)
+	}
+
+	query := s.goqu.From(goqu.T("change_sets").As("cs")).
+		Prepared(true).
+		LeftJoin(goqu.T("users").As("u"), goqu.On(goqu.I("u.id").Eq(goqu.I("cs.created_by")))).
+		LeftJoin(goqu.T("file_revisions").As("fr"), goqu.On(goqu.I("fr.change_set_id").Eq(goqu.I("cs.id")))).
+		Select(
+			goqu.I("cs.id").As("id"),
+			goqu.I("cs.description").As("description"),
+			goqu.I("cs.status").As("status"),
+			goqu.I("cs.created_by").As("created_by"),
+			goqu.I("u.email").As("created_by_email"),
+			goqu.I("cs.created_at").As("created_at"),
+			goqu.I("cs.updated_at").As("updated_at"),
+			goqu.COUNT(goqu.I("fr.id")).As("revision_count"),
+		).
+		GroupBy(goqu.I("cs.id"))
+
+	if status != "" {
+		query = query.Where(goqu.I("cs.status").Eq(status))
+	}
+
+	offset := (page - 1) * pageSize
+	var changeSets []ChangeSetListItem
+	err = query.
+		Order(goqu.I("cs.created_at").Desc(), goqu.I("cs.id").Desc()).
+		Limit(uint(pageSize)).
+		Offset(uint(offset)).
+		ScanStructs(&changeSets)
+	if err != nil {
+		s.logger.Error(
+			"failed to get paginated change sets",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get paginated change sets: %w", err)
+	}
+
+	return changeSets, totalCount, nil
+}
+
+func (s *sqliteInternalDB) GetChangeSetRevisions(changeSetID string) ([]FileRevision, error) {
+	revisions := make([]FileRevision, 0)
+	err := s.goqu.From("file_revisions").
+		Prepared(true).
+		Where(goqu.Ex{"change_set_id": changeSetID}).
+		Order(goqu.I("id").Asc()).
+		ScanStructs(&revisions)
+	if err != nil {
+		s.logger.Error(
+			"failed to get change set revisions",
+			logger.Field{Key: "change_set_id", Value: changeSetID},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get change set revisions: %w", err)
+	}
+
+	return revisions, nil
+}
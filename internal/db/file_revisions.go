@@ -15,14 +15,17 @@ type FileRevision struct {
 	RevisionPath string     `db:"revision_path" json:"revision_path"`
 	PreviousHash string     `db:"previous_hash" json:"previous_hash"`
 	CurrentHash  string     `db:"current_hash" json:"current_hash"`
-	CreatedBy    int64      `db:"created_by" json:"created_by"`
+	CreatedBy    *int64     `db:"created_by" json:"created_by"`
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 	UpdatedBy    *int64     `db:"updated_by" json:"updated_by"`
 	UpdatedAt    *time.Time `db:"updated_at" json:"updated_at"`
 	Status       string     `db:"status" json:"status"`
+	ChangeSetID  *string    `db:"change_set_id" json:"change_set_id"`
+	Operation    string     `db:"operation" json:"operation"`
+	Source       string     `db:"source" json:"source"`
 }
 
-func (s *sqliteInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID, originalPath, revisionPath string, previousHash, currentHash string, createdBy int64) (int64, error) {
+func (s *sqliteInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID, originalPath, revisionPath string, previousHash, currentHash string, createdBy *int64) (int64, error) {
 	result, err := tx.Insert("file_revisions").
 		Prepared(true).
 		Rows(goqu.Record{
@@ -59,7 +62,7 @@ func (s *sqliteInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID, origi
 	return revisionID, nil
 }
 
-func (s *sqliteInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy int64) error {
+func (s *sqliteInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy *int64) error {
 	updateRecord := goqu.Record{
 		"status":     status,
 		"updated_at": goqu.L("CURRENT_TIMESTAMP"),
@@ -86,7 +89,7 @@ func (s *sqliteInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisio
 	return nil
 }
 
-func (s *sqliteInternalDB) UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy int64) error {
+func (s *sqliteInternalDB) UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy *int64) error {
 	updateRecord := goqu.Record{
 		"revision_path": revisionPath,
 		"updated_at":    goqu.L("CURRENT_TIMESTAMP"),
@@ -112,6 +115,87 @@ func (s *sqliteInternalDB) UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionI
 	return nil
 }
 
+func (s *sqliteInternalDB) UpdateFileRevisionChangeSet(tx *goqu.TxDatabase, revisionID int64, changeSetID string, updatedBy *int64) error {
+	updateRecord := goqu.Record{
+		"change_set_id": changeSetID,
+		"updated_at":    goqu.L("CURRENT_TIMESTAMP"),
+		"updated_by":    updatedBy,
+	}
+
+	_, err := tx.Update("file_revisions").
+		Prepared(true).
+		Set(updateRecord).
+		Where(goqu.Ex{"id": revisionID}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to update file revision change set",
+			logger.Field{Key: "revision_id", Value: revisionID},
+			logger.Field{Key: "change_set_id", Value: changeSetID},
+			logger.Field{Key: "updated_by", Value: updatedBy},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to update file revision change set: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) UpdateFileRevisionOperation(tx *goqu.TxDatabase, revisionID int64, operation string, updatedBy *int64) error {
+	updateRecord := goqu.Record{
+		"operation":  operation,
+		"updated_at": goqu.L("CURRENT_TIMESTAMP"),
+		"updated_by": updatedBy,
+	}
+
+	_, err := tx.Update("file_revisions").
+		Prepared(true).
+		Set(updateRecord).
+		Where(goqu.Ex{"id": revisionID}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to update file revision operation",
+			logger.Field{Key: "revision_id", Value: revisionID},
+			logger.Field{Key: "operation", Value: operation},
+			logger.Field{Key: "updated_by", Value: updatedBy},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to update file revision operation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) UpdateFileRevisionSource(tx *goqu.TxDatabase, revisionID int64, source string, updatedBy *int64) error {
+	updateRecord := goqu.Record{
+		"source":     source,
+		"updated_at": goqu.L("CURRENT_TIMESTAMP"),
+		"updated_by": updatedBy,
+	}
+
+	_, err := tx.Update("file_revisions").
+		Prepared(true).
+		Set(updateRecord).
+		Where(goqu.Ex{"id": revisionID}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to update file revision source",
+			logger.Field{Key: "revision_id", Value: revisionID},
+			logger.Field{Key: "source", Value: source},
+			logger.Field{Key: "updated_by", Value: updatedBy},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to update file revision source: %w", err)
+	}
+
+	return nil
+}
+
 func (s *sqliteInternalDB) GetFileRevision(revisionID int64) (*FileRevision, error) {
 	var revision FileRevision
 	found, err := s.goqu.From("file_revisions").
@@ -139,7 +223,7 @@ func (s *sqliteInternalDB) GetLastCompletedFileRevision(fileID string) (*FileRev
 	found, err := s.goqu.From("file_revisions").
 		Prepared(true).
 		Where(goqu.Ex{"file_id": fileID, "status": "completed"}).
-		Order(goqu.I("created_at").Desc()).
+		Order(goqu.I("created_at").Desc(), goqu.I("id").Desc()).
 		Limit(1).
 		ScanStruct(&revision)
 	if err != nil {
@@ -158,6 +242,75 @@ func (s *sqliteInternalDB) GetLastCompletedFileRevision(fileID string) (*FileRev
 	return &revision, nil
 }
 
+type FileRevisionListItem struct {
+	ID             int64      `db:"id" json:"id"`
+	FileID         string     `db:"file_id" json:"file_id"`
+	OriginalPath   string     `db:"original_path" json:"original_path"`
+	PreviousHash   string     `db:"previous_hash" json:"previous_hash"`
+	CurrentHash    string     `db:"current_hash" json:"current_hash"`
+	CreatedBy      *int64     `db:"created_by" json:"created_by"`
+	CreatedByEmail *string    `db:"created_by_email" json:"created_by_email"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      *time.Time `db:"updated_at" json:"updated_at"`
+	Status         string     `db:"status" json:"status"`
+	ChangeSetID    *string    `db:"change_set_id" json:"change_set_id"`
+	Operation      string     `db:"operation" json:"operation"`
+	Source         string     `db:"source" json:"source"`
+}
+
+func (s *sqliteInternalDB) GetFileRevisionsPaginated(fileID string, page, pageSize int) ([]FileRevisionListItem, int64, error) {
+	var totalCount int64
+	_, err := s.goqu.From("file_revisions").
+		Prepared(true).
+		Select(goqu.COUNT("*")).
+		Where(goqu.Ex{"file_id": fileID}).
+		ScanVal(&totalCount)
+	if err != nil {
+		s.logger.Error(
+			"failed to get file revisions count",
+			logger.Field{Key: "file_id", Value: fileID},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get file revisions count: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	var revisions []FileRevisionListItem
+	err = s.goqu.From(goqu.T("file_revisions").As("fr")).
+		Prepared(true).
+		LeftJoin(goqu.T("users").As("u"), goqu.On(goqu.I("u.id").Eq(goqu.I("fr.created_by")))).
+		Select(
+			goqu.I("fr.id").As("id"),
+			goqu.I("fr.file_id").As("file_id"),
+			goqu.I("fr.original_path").As("original_path"),
+			goqu.I("fr.previous_hash").As("previous_hash"),
+			goqu.I("fr.current_hash").As("current_hash"),
+			goqu.I("fr.created_by").As("created_by"),
+			goqu.I("u.email").As("created_by_email"),
+			goqu.I("fr.created_at").As("created_at"),
+			goqu.I("fr.updated_at").As("updated_at"),
+			goqu.I("fr.status").As("status"),
+			goqu.I("fr.change_set_id").As("change_set_id"),
+			goqu.I("fr.operation").As("operation"),
+			goqu.I("fr.source").As("source"),
+		).
+		Where(goqu.I("fr.file_id").Eq(fileID)).
+		Order(goqu.I("fr.created_at").Desc(), goqu.I("fr.id").Desc()).
+		Limit(uint(pageSize)).
+		Offset(uint(offset)).
+		ScanStructs(&revisions)
+	if err != nil {
+		s.logger.Error(
+			"failed to get paginated file revisions",
+			logger.Field{Key: "file_id", Value: fileID},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get paginated file revisions: %w", err)
+	}
+
+	return revisions, totalCount, nil
+}
+
 type RevisionSummary struct {
 	Count          int64  `json:"count"`
 	LastRevisionAt *int64 `json:"last_revision_at,omitempty"`
@@ -227,3 +380,142 @@ func (s *sqliteInternalDB) GetRevisionSummary(fileID string) (*RevisionSummary,
 
 	return &summary, nil
 }
+
+// GetStoredFileRevisions returns every revision that has a stored copy, oldest
+// first.
+func (s *sqliteInternalDB) GetStoredFileRevisions() ([]FileRevision, error) {
+	revisions := make([]FileRevision, 0)
+	err := s.goqu.From("file_revisions").
+		Prepared(true).
+		Where(goqu.C("revision_path").Neq("")).
+		Order(goqu.I("id").Asc()).
+		ScanStructs(&revisions)
+	if err != nil {
+		s.logger.Error(
+			"failed to get stored file revisions",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get stored file revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetFileRevisionsForRetention returns the finished revisions that the
+// retention policy may prune, grouped by file and newest first. Revisions in
+// open change sets are left out because the change set may still be reverted.
+func (s *sqliteInternalDB) GetFileRevisionsForRetention() ([]FileRevision, error) {
+	revisions := make([]FileRevision, 0)
+	err := s.goqu.From("file_revisions").
+		Prepared(true).
+		Where(
+			goqu.C("status").In("completed", "reverted", "corrupted"),
+			goqu.C("revision_path").Neq(""),
+			goqu.L("NOT EXISTS (SELECT 1 FROM change_sets cs WHERE cs.id = file_revisions.change_set_id AND cs.status = 'open')"),
+		).
+		Order(goqu.I("file_id").Asc(), goqu.I("id").Desc()).
+		ScanStructs(&revisions)
+	if err != nil {
+		s.logger.Error(
+			"failed to get file revisions for retention",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get file revisions for retention: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// SetFileRevisionPath moves a revision to a new stored copy without recording
+// a user update, for maintenance that does not change the revision content.
+func (s *sqliteInternalDB) SetFileRevisionPath(revisionID int64, revisionPath string) error {
+	_, err := s.goqu.Update("file_revisions").
+		Prepared(true).
+		Set(goqu.Record{"revision_path": revisionPath}).
+		Where(goqu.Ex{"id": revisionID}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to set file revision path",
+			logger.Field{Key: "revision_id", Value: revisionID},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to set file revision path: %w", err)
+	}
+
+	return nil
+}
+
+// PruneFileRevisions marks revisions as pruned and detaches their stored
+// copies. The revision rows are kept so that history stays complete.
+func (s *sqliteInternalDB) PruneFileRevisions(revisionIDs []int64) error {
+	if len(revisionIDs) == 0 {
+		return nil
+	}
+
+	_, err := s.goqu.Update("file_revisions").
+		Prepared(true).
+		Set(goqu.Record{
+			"status":        "pruned",
+			"revision_path": "",
+			"updated_at":    goqu.L("CURRENT_TIMESTAMP"),
+		}).
+		Where(goqu.C("id").In(revisionIDs)).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to prune file revisions",
+			logger.Field{Key: "count", Value: len(revisionIDs)},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to prune file revisions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) GetFileRevisionsByStatus(statuses []string) ([]FileRevision, error) {
+	revisions := make([]FileRevision, 0)
+	err := s.goqu.From("file_revisions").
+		Prepared(true).
+		Where(goqu.C("status").In(statuses)).
+		Order(goqu.I("id").Asc()).
+		ScanStructs(&revisions)
+	if err != nil {
+		s.logger.Error(
+			"failed to get file revisions by status",
+			logger.Field{Key: "statuses", Value: statuses},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get file revisions by status: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// SetFileRevisionStatus changes a revision status from a maintenance job,
+// without recording a user update.
+func (s *sqliteInternalDB) SetFileRevisionStatus(revisionID int64, status string) error {
+	_, err := s.goqu.Update("file_revisions").
+		Prepared(true).
+		Set(goqu.Record{
+			"status":     status,
+			"updated_at": goqu.L("CURRENT_TIMESTAMP"),
+		}).
+		Where(goqu.Ex{"id": revisionID}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to set file revision status",
+			logger.Field{Key: "revision_id", Value: revisionID},
+			logger.Field{Key: "status", Value: status},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to set file revision status: %w", err)
+	}
+
+	return nil
+}
@@ -28,13 +28,29 @@ type InternalDB interface {
 	GetMetricSamplesByTimeRange(metricName string, startTime, endTime int64) ([]MetricSampleWithLabels, error)
 	DeleteOldMetrics(retentionDays int) error
 	BeginTx() (*goqu.TxDatabase, error)
-	CreateFileRevision(tx *goqu.TxDatabase, fileID, originalPath, revisionPath string, previousHash, currentHash string, createdBy int64) (int64, error)
-	UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy int64) error
-	UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy int64) error
+	CreateFileRevision(tx *goqu.TxDatabase, fileID, originalPath, revisionPath string, previousHash, currentHash string, createdBy *int64) (int64, error)
+	UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy *int64) error
+	UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy *int64) error
+	UpdateFileRevisionChangeSet(tx *goqu.TxDatabase, revisionID int64, changeSetID string, updatedBy *int64) error
+	UpdateFileRevisionOperation(tx *goqu.TxDatabase, revisionID int64, operation string, updatedBy *int64) error
+	UpdateFileRevisionSource(tx *goqu.TxDatabase, revisionID int64, source string, updatedBy *int64) error
 	GetFileRevision(revisionID int64) (*FileRevision, error)
 	GetLastCompletedFileRevision(fileID string) (*FileRevision, error)
+	GetFileRevisionsPaginated(fileID string, page, pageSize int) ([]FileRevisionListItem, int64, error)
+	GetStoredFileRevisions() ([]FileRevision, error)
+	GetFileRevisionsForRetention() ([]FileRevision, error)
+	SetFileRevisionPath(revisionID int64, revisionPath string) error
+	PruneFileRevisions(revisionIDs []int64) error
+	GetFileRevisionsByStatus(statuses []string) ([]FileRevision, error)
+	SetFileRevisionStatus(revisionID int64, status string) error
 	GetCompletedRevisionCount(fileID string) (int64, error)
 	GetRevisionSummary(fileID string) (*RevisionSummary, error)
+	CreateChangeSet(id, description, status string, createdBy int64) (*ChangeSet, error)
+	GetChangeSet(id string) (*ChangeSet, error)
+	UpdateChangeSetStatus(id, status string, updatedBy int64) error
+	DeleteEmptyChangeSet(id string) error
+	GetChangeSetsPaginated(page, pageSize int, status string) ([]ChangeSetListItem, int64, error)
+	GetChangeSetRevisions(changeSetID string) ([]FileRevision, error)
 	CreateSession(userID int64, expiresAt time.Time, userAgent, ipAddress *string) (*Session, error)
 	GetSession(sessionID string) (*Session, error)
 	UpdateSessionLastAccessed(sessionID string) error
@@ -59,20 +75,26 @@ type InternalDB interface {
 	SetDefaultSettings() error
 	BulkReplaceMonsterClientData(data []MonsterClientData) error
 	GetAllMonsterClientData(search string) ([]MonsterClientData, error)
+	GetMonsterClientDataByIDs(ids []int64) ([]MonsterClientData, error)
 	BulkReplaceMapClientData(data []MapClientData) error
 	GetAllMapClientData(search string) ([]MapClientData, error)
+	GetMapClientDataByIDs(ids []int64) ([]MapClientData, error)
 	BulkReplaceItemClientData(data []ItemClientData) error
 	GetAllItemClientData(search string) ([]ItemClientData, error)
+	GetItemClientDataByIDs(ids []int64) ([]ItemClientData, error)
 	GetServerProcesses() ([]ServerProcess, error)
 	GetServerProcess(id int64) (*ServerProcess, error)
-	GetServerProcessByPath(path string) (*ServerProcess, error)
-	CreateServerProcess(name, path string, port *int, sequenceOrder int) (*ServerProcess, error)
-	UpdateServerProcess(id int64, name, path string, port *int) error
+	GetServerProcessesByPath(path string) ([]ServerProcess, error)
+	CreateServerProcess(name, path string, port *int, launch ServerProcessLaunch, dependsOn []int64, sequenceOrder int) (*ServerProcess, error)
+	UpdateServerProcess(id int64, name, path string, port *int, launch ServerProcessLaunch, dependsOn []int64) error
 	DeleteServerProcess(id int64) error
 	ReorderServerProcesses(updates []ReorderUpdate) error
 	GetMaxSequenceOrder() (int, error)
 	UpdateProcessStartTime(id int64, startTime time.Time) error
 	UpdateProcessEndTime(id int64, endTime time.Time) error
+	UpdateProcessPID(id int64, pid *int, pidCreateTime *int64) error
+	CreateServerProcessEvent(processID int64, eventType string, attempt int, message string) error
+	GetServerProcessEventsPaginated(processID *int64, page, pageSize int) ([]ServerProcessEvent, int64, error)
 }
 
 type sqliteInternalDB struct {
@@ -201,10 +223,82 @@ func (s *sqliteInternalDB) MigrateUp() error {
 		return err
 	}
 
+	if err := s.migrate010FileRevisionsChangeSetID(); err != nil {
+		return err
+	}
+
+	if err := s.migrate011ChangeSetsTable(); err != nil {
+		return err
+	}
+
+	if err := s.migrate012FileRevisionsOperation(); err != nil {
+		return err
+	}
+
+	if err := s.migrate013FileRevisionsSource(); err != nil {
+		return err
+	}
+
+	if err := s.migrate014ServerProcessEventsTable(); err != nil {
+		return err
+	}
+
+	if err := s.migrate015ServerProcessesLaunchConfig(); err != nil {
+		return err
+	}
+
+	if err := s.migrate016ServerProcessesPID(); err != nil {
+		return err
+	}
+
+	if err := s.migrate017ServerProcessDependenciesTable(); err != nil {
+		return err
+	}
+
+	if err := s.migrate018FileRevisionsNullableCreatedBy(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (s *sqliteInternalDB) MigrateDown() error {
+	if err := s.rollback018FileRevisionsNullableCreatedBy(); err != nil {
+		return err
+	}
+
+	if err := s.rollback017ServerProcessDependenciesTable(); err != nil {
+		return err
+	}
+
+	if err := s.rollback016ServerProcessesPID(); err != nil {
+		return err
+	}
+
+	if err := s.rollback015ServerProcessesLaunchConfig(); err != nil {
+		return err
+	}
+
+	if err := s.rollback014ServerProcessEventsTable(); err != nil {
+		return err
+	}
+
+	if err := s.rollback013FileRevisionsSource(); err != nil {
+		return err
+	}
+
+	if err := s.rollback012FileRevisionsOperation(); err != nil {
+		return err
+	}
+
+	if err := s.rollback011ChangeSetsTable(); err != nil {
+		return err
+	}
+
+	if err := s.rollback010FileRevisionsChangeSetID(); err != nil {
+		return err
+	}
+
 	if err := s.rollback009ServerProcessesTable(); err != nil {
 		return err
 	}
@@ -1176,3 +1270,844 @@ func (s *sqliteInternalDB) rollback009ServerProcessesTable() error {
 
 	return nil
 }
+
+func (s *sqliteInternalDB) migrate010FileRevisionsChangeSetID() error {
+	const migName = "010_file_revisions_change_set_id"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE file_revisions ADD COLUMN change_set_id TEXT;
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_change_set_id ON file_revisions (change_set_id);
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to add change_set_id to file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback010FileRevisionsChangeSetID() error {
+	const migName = "010_file_revisions_change_set_id"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	DROP INDEX IF EXISTS idx_file_revisions_change_set_id;
+
+	ALTER TABLE file_revisions DROP COLUMN change_set_id;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to rollback change_set_id on file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate011ChangeSetsTable() error {
+	const migName = "011_change_sets_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	CREATE TABLE IF NOT EXISTS change_sets (
+		id TEXT PRIMARY KEY,
+		description TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'open',
+		created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		updated_at TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_change_sets_status ON change_sets (status);
+
+	CREATE INDEX IF NOT EXISTS idx_change_sets_created_at ON change_sets (created_at);
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create change_sets table: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback011ChangeSetsTable() error {
+	const migName = "011_change_sets_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	DROP TABLE IF EXISTS change_sets;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to rollback change_sets table: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate012FileRevisionsOperation() error {
+	const migName = "012_file_revisions_operation"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE file_revisions ADD COLUMN operation TEXT NOT NULL DEFAULT 'edit';
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to add operation column to file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback012FileRevisionsOperation() error {
+	const migName = "012_file_revisions_operation"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE file_revisions DROP COLUMN operation;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to drop operation column from file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate013FileRevisionsSource() error {
+	const migName = "013_file_revisions_source"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE file_revisions ADD COLUMN source TEXT NOT NULL DEFAULT 'agent';
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to add source column to file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback013FileRevisionsSource() error {
+	const migName = "013_file_revisions_source"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE file_revisions DROP COLUMN source;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to drop source column from file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate014ServerProcessEventsTable() error {
+	const migName = "014_server_process_events_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	CREATE TABLE IF NOT EXISTS server_process_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		process_id INTEGER NOT NULL REFERENCES server_processes(id) ON DELETE CASCADE,
+		event_type TEXT NOT NULL,
+		attempt INTEGER NOT NULL DEFAULT 0,
+		message TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_server_process_events_process_id ON server_process_events (process_id);
+
+	CREATE INDEX IF NOT EXISTS idx_server_process_events_created_at ON server_process_events (created_at);
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create server_process_events table: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback014ServerProcessEventsTable() error {
+	const migName = "014_server_process_events_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	DROP TABLE IF EXISTS server_process_events;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to rollback server_process_events table: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate015ServerProcessesLaunchConfig() error {
+	const migName = "015_server_processes_launch_config"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE server_processes ADD COLUMN args TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE server_processes ADD COLUMN env TEXT NOT NULL DEFAULT '{}';
+	ALTER TABLE server_processes ADD COLUMN working_dir TEXT;
+	ALTER TABLE server_processes ADD COLUMN start_timeout_seconds INTEGER;
+	ALTER TABLE server_processes ADD COLUMN check_interval_seconds INTEGER;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to add launch config columns to server_processes: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback015ServerProcessesLaunchConfig() error {
+	const migName = "015_server_processes_launch_config"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE server_processes DROP COLUMN check_interval_seconds;
+	ALTER TABLE server_processes DROP COLUMN start_timeout_seconds;
+	ALTER TABLE server_processes DROP COLUMN working_dir;
+	ALTER TABLE server_processes DROP COLUMN env;
+	ALTER TABLE server_processes DROP COLUMN args;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to drop launch config columns from server_processes: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) migrate016ServerProcessesPID() error {
+	const migName = "016_server_processes_pid"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE server_processes ADD COLUMN pid INTEGER;
+	ALTER TABLE server_processes ADD COLUMN pid_create_time INTEGER;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to add pid columns to server_processes: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback016ServerProcessesPID() error {
+	const migName = "016_server_processes_pid"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	ALTER TABLE server_processes DROP COLUMN pid_create_time;
+	ALTER TABLE server_processes DROP COLUMN pid;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to drop pid columns from server_processes: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+// migrate017ServerProcessDependenciesTable makes every existing process depend
+// on the one before it in sequence order, so that they keep starting one
+// after another.
+func (s *sqliteInternalDB) migrate017ServerProcessDependenciesTable() error {
+	const migName = "017_server_process_dependencies_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	CREATE TABLE IF NOT EXISTS server_process_dependencies (
+		process_id INTEGER NOT NULL REFERENCES server_processes(id) ON DELETE CASCADE,
+		depends_on_id INTEGER NOT NULL REFERENCES server_processes(id) ON DELETE RESTRICT,
+		PRIMARY KEY (process_id, depends_on_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_server_process_dependencies_depends_on_id ON server_process_dependencies (depends_on_id);
+
+	INSERT INTO server_process_dependencies (process_id, depends_on_id)
+	SELECT process_id, depends_on_id FROM (
+		SELECT p.id AS process_id, (
+			SELECT q.id FROM server_processes q
+			WHERE q.sequence_order < p.sequence_order OR (q.sequence_order = p.sequence_order AND q.id < p.id)
+			ORDER BY q.sequence_order DESC, q.id DESC
+			LIMIT 1
+		) AS depends_on_id
+		FROM server_processes p
+	)
+	WHERE depends_on_id IS NOT NULL;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create server_process_dependencies table: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sqliteInternalDB) rollback017ServerProcessDependenciesTable() error {
+	const migName = "017_server_process_dependencies_table"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	DROP TABLE IF EXISTS server_process_dependencies;
+	`
+	_, err = s.db.Exec(migrationSQL)
+	if err != nil {
+		return fmt.Errorf("failed to rollback server_process_dependencies table: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+// migrate018FileRevisionsNullableCreatedBy lets created_by be NULL for
+// revisions of changes made outside the agent, which have no user. SQLite
+// cannot change a column constraint, so the table is rebuilt.
+func (s *sqliteInternalDB) migrate018FileRevisionsNullableCreatedBy() error {
+	const migName = "018_file_revisions_nullable_created_by"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to check migration status for %s: %w", migName, err)
+	}
+
+	if applied {
+		return nil
+	}
+
+	s.logger.Info("Applying migration", logger.Field{Key: "migration", Value: migName})
+
+	migrationSQL := `
+	CREATE TABLE file_revisions_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		file_id TEXT NOT NULL,
+		original_path TEXT NOT NULL,
+		revision_path TEXT NOT NULL,
+		previous_hash TEXT NOT NULL,
+		current_hash TEXT NOT NULL,
+		created_by INTEGER REFERENCES users(id) ON DELETE RESTRICT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		updated_at TIMESTAMP,
+		status TEXT NOT NULL DEFAULT 'draft',
+		change_set_id TEXT,
+		operation TEXT NOT NULL DEFAULT 'edit',
+		source TEXT NOT NULL DEFAULT 'agent'
+	);
+
+	INSERT INTO file_revisions_new (id, file_id, original_path, revision_path, previous_hash, current_hash, created_by, created_at, updated_by, updated_at, status, change_set_id, operation, source)
+	SELECT id, file_id, original_path, revision_path, previous_hash, current_hash, created_by, created_at, updated_by, updated_at, status, change_set_id, operation, source
+	FROM file_revisions;
+
+	DROP TABLE file_revisions;
+
+	ALTER TABLE file_revisions_new RENAME TO file_revisions;
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_file_id ON file_revisions (file_id);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_status ON file_revisions (status);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_created_by ON file_revisions (created_by);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_created_at ON file_revisions (created_at);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_change_set_id ON file_revisions (change_set_id);
+	`
+	if err := s.execInTransaction(migrationSQL); err != nil {
+		return fmt.Errorf("failed to make created_by of file_revisions nullable: %w", err)
+	}
+
+	if err := s.markMigrationApplied(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as applied",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as applied: %w", err)
+	}
+
+	return nil
+}
+
+// rollback018FileRevisionsNullableCreatedBy attributes revisions without a
+// user to the first user again, as created_by cannot be NULL before 018.
+func (s *sqliteInternalDB) rollback018FileRevisionsNullableCreatedBy() error {
+	const migName = "018_file_revisions_nullable_created_by"
+
+	applied, err := s.isMigrationApplied(migName)
+	if err != nil {
+		s.logger.Error(
+			"failed to check migration status",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+	}
+
+	if !applied {
+		return nil
+	}
+
+	s.logger.Info("Rolling back migration", logger.Field{Key: "migration", Value: migName})
+
+	// Revisions without a user are given the first user; they are dropped
+	// when there are no users to give them.
+	migrationSQL := `
+	CREATE TABLE file_revisions_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		file_id TEXT NOT NULL,
+		original_path TEXT NOT NULL,
+		revision_path TEXT NOT NULL,
+		previous_hash TEXT NOT NULL,
+		current_hash TEXT NOT NULL,
+		created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		updated_at TIMESTAMP,
+		status TEXT NOT NULL DEFAULT 'draft',
+		change_set_id TEXT,
+		operation TEXT NOT NULL DEFAULT 'edit',
+		source TEXT NOT NULL DEFAULT 'agent'
+	);
+
+	INSERT INTO file_revisions_new (id, file_id, original_path, revision_path, previous_hash, current_hash, created_by, created_at, updated_by, updated_at, status, change_set_id, operation, source)
+	SELECT id, file_id, original_path, revision_path, previous_hash, current_hash, COALESCE(created_by, (SELECT MIN(id) FROM users)), created_at, updated_by, updated_at, status, change_set_id, operation, source
+	FROM file_revisions
+	WHERE created_by IS NOT NULL OR EXISTS (SELECT 1 FROM users);
+
+	DROP TABLE file_revisions;
+
+	ALTER TABLE file_revisions_new RENAME TO file_revisions;
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_file_id ON file_revisions (file_id);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_status ON file_revisions (status);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_created_by ON file_revisions (created_by);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_created_at ON file_revisions (created_at);
+
+	CREATE INDEX IF NOT EXISTS idx_file_revisions_change_set_id ON file_revisions (change_set_id);
+	`
+	if err := s.execInTransaction(migrationSQL); err != nil {
+		return fmt.Errorf("failed to rollback nullable created_by of file_revisions: %w", err)
+	}
+
+	if err := s.markMigrationRolledBack(migName); err != nil {
+		s.logger.Error(
+			"failed to mark migration as rolled back",
+			logger.Field{Key: "migration", Value: migName},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to mark migration as rolled back: %w", err)
+	}
+
+	return nil
+}
+
+// execInTransaction runs statements that must not be left half applied, such
+// as the steps of a table rebuild.
+func (s *sqliteInternalDB) execInTransaction(statements string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(statements); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("failed to rollback transaction", logger.Field{Key: "error", Value: rollbackErr})
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -18,6 +18,11 @@ type ItemClientData struct {
 	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// ClientDataName returns the item ID and its name.
+func (d ItemClientData) ClientDataName() (int64, string) {
+	return d.ID, d.Name
+}
+
 func (s *sqliteInternalDB) BulkReplaceItemClientData(data []ItemClientData) error {
 	_, err := s.goqu.Delete("item_client_data").
 		Prepared(true).
@@ -96,3 +101,25 @@ func (s *sqliteInternalDB) GetAllItemClientData(search string) ([]ItemClientData
 
 	return data, nil
 }
+
+func (s *sqliteInternalDB) GetItemClientDataByIDs(ids []int64) ([]ItemClientData, error) {
+	var data []ItemClientData
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	err := s.goqu.From("item_client_data").
+		Prepared(true).
+		Where(goqu.C("id").In(ids)).
+		ScanStructs(&data)
+	if err != nil {
+		s.logger.Error(
+			"failed to get item client data by ids",
+			logger.Field{Key: "count", Value: len(ids)},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get item client data by ids: %w", err)
+	}
+
+	return data, nil
+}
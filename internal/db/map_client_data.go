@@ -18,6 +18,11 @@ type MapClientData struct {
 	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// ClientDataName returns the map ID and its name.
+func (d MapClientData) ClientDataName() (int64, string) {
+	return d.ID, d.Name
+}
+
 func (s *sqliteInternalDB) BulkReplaceMapClientData(data []MapClientData) error {
 	_, err := s.goqu.Delete("map_client_data").
 		Prepared(true).
@@ -96,3 +101,25 @@ func (s *sqliteInternalDB) GetAllMapClientData(search string) ([]MapClientData,
 
 	return data, nil
 }
+
+func (s *sqliteInternalDB) GetMapClientDataByIDs(ids []int64) ([]MapClientData, error) {
+	var data []MapClientData
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	err := s.goqu.From("map_client_data").
+		Prepared(true).
+		Where(goqu.C("id").In(ids)).
+		ScanStructs(&data)
+	if err != nil {
+		s.logger.Error(
+			"failed to get map client data by ids",
+			logger.Field{Key: "count", Value: len(ids)},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get map client data by ids: %w", err)
+	}
+
+	return data, nil
+}
@@ -334,8 +334,88 @@ func (_c *MockInternalDB_Connect_Call) RunAndReturn(run func() error) *MockInter
 	return _c
 }
 
+// CreateChangeSet provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) CreateChangeSet(id string, description string, status string, createdBy int64) (*ChangeSet, error) {
+	ret := _mock.Called(id, description, status, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChangeSet")
+	}
+
+	var r0 *ChangeSet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, int64) (*ChangeSet, error)); ok {
+		return returnFunc(id, description, status, createdBy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, int64) *ChangeSet); ok {
+		r0 = returnFunc(id, description, status, createdBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ChangeSet)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, int64) error); ok {
+		r1 = returnFunc(id, description, status, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_CreateChangeSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChangeSet'
+type MockInternalDB_CreateChangeSet_Call struct {
+	*mock.Call
+}
+
+// CreateChangeSet is a helper method to define mock.On call
+//   - id string
+//   - description string
+//   - status string
+//   - createdBy int64
+func (_e *MockInternalDB_Expecter) CreateChangeSet(id interface{}, description interface{}, status interface{}, createdBy interface{}) *MockInternalDB_CreateChangeSet_Call {
+	return &MockInternalDB_CreateChangeSet_Call{Call: _e.mock.On("CreateChangeSet", id, description, status, createdBy)}
+}
+
+func (_c *MockInternalDB_CreateChangeSet_Call) Run(run func(id string, description string, status string, createdBy int64)) *MockInternalDB_CreateChangeSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_CreateChangeSet_Call) Return(changeSet *ChangeSet, err error) *MockInternalDB_CreateChangeSet_Call {
+	_c.Call.Return(changeSet, err)
+	return _c
+}
+
+func (_c *MockInternalDB_CreateChangeSet_Call) RunAndReturn(run func(id string, description string, status string, createdBy int64) (*ChangeSet, error)) *MockInternalDB_CreateChangeSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateFileRevision provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy int64) (int64, error) {
+func (_mock *MockInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy *int64) (int64, error) {
 	ret := _mock.Called(tx, fileID, originalPath, revisionPath, previousHash, currentHash, createdBy)
 
 	if len(ret) == 0 {
@@ -344,15 +424,15 @@ func (_mock *MockInternalDB) CreateFileRevision(tx *goqu.TxDatabase, fileID stri
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, string, string, string, string, string, int64) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, string, string, string, string, string, *int64) (int64, error)); ok {
 		return returnFunc(tx, fileID, originalPath, revisionPath, previousHash, currentHash, createdBy)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, string, string, string, string, string, int64) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, string, string, string, string, string, *int64) int64); ok {
 		r0 = returnFunc(tx, fileID, originalPath, revisionPath, previousHash, currentHash, createdBy)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*goqu.TxDatabase, string, string, string, string, string, int64) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(*goqu.TxDatabase, string, string, string, string, string, *int64) error); ok {
 		r1 = returnFunc(tx, fileID, originalPath, revisionPath, previousHash, currentHash, createdBy)
 	} else {
 		r1 = ret.Error(1)
@@ -372,12 +452,12 @@ type MockInternalDB_CreateFileRevision_Call struct {
 //   - revisionPath string
 //   - previousHash string
 //   - currentHash string
-//   - createdBy int64
+//   - createdBy *int64
 func (_e *MockInternalDB_Expecter) CreateFileRevision(tx interface{}, fileID interface{}, originalPath interface{}, revisionPath interface{}, previousHash interface{}, currentHash interface{}, createdBy interface{}) *MockInternalDB_CreateFileRevision_Call {
 	return &MockInternalDB_CreateFileRevision_Call{Call: _e.mock.On("CreateFileRevision", tx, fileID, originalPath, revisionPath, previousHash, currentHash, createdBy)}
 }
 
-func (_c *MockInternalDB_CreateFileRevision_Call) Run(run func(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy int64)) *MockInternalDB_CreateFileRevision_Call {
+func (_c *MockInternalDB_CreateFileRevision_Call) Run(run func(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy *int64)) *MockInternalDB_CreateFileRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *goqu.TxDatabase
 		if args[0] != nil {
@@ -403,9 +483,9 @@ func (_c *MockInternalDB_CreateFileRevision_Call) Run(run func(tx *goqu.TxDataba
 		if args[5] != nil {
 			arg5 = args[5].(string)
 		}
-		var arg6 int64
+		var arg6 *int64
 		if args[6] != nil {
-			arg6 = args[6].(int64)
+			arg6 = args[6].(*int64)
 		}
 		run(
 			arg0,
@@ -425,14 +505,14 @@ func (_c *MockInternalDB_CreateFileRevision_Call) Return(n int64, err error) *Mo
 	return _c
 }
 
-func (_c *MockInternalDB_CreateFileRevision_Call) RunAndReturn(run func(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy int64) (int64, error)) *MockInternalDB_CreateFileRevision_Call {
+func (_c *MockInternalDB_CreateFileRevision_Call) RunAndReturn(run func(tx *goqu.TxDatabase, fileID string, originalPath string, revisionPath string, previousHash string, currentHash string, createdBy *int64) (int64, error)) *MockInternalDB_CreateFileRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateServerProcess provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) CreateServerProcess(name string, path string, port *int, sequenceOrder int) (*ServerProcess, error) {
-	ret := _mock.Called(name, path, port, sequenceOrder)
+func (_mock *MockInternalDB) CreateServerProcess(name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64, sequenceOrder int) (*ServerProcess, error) {
+	ret := _mock.Called(name, path, port, launch, dependsOn, sequenceOrder)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateServerProcess")
@@ -440,18 +520,18 @@ func (_mock *MockInternalDB) CreateServerProcess(name string, path string, port
 
 	var r0 *ServerProcess
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, *int, int) (*ServerProcess, error)); ok {
-		return returnFunc(name, path, port, sequenceOrder)
+	if returnFunc, ok := ret.Get(0).(func(string, string, *int, ServerProcessLaunch, []int64, int) (*ServerProcess, error)); ok {
+		return returnFunc(name, path, port, launch, dependsOn, sequenceOrder)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, *int, int) *ServerProcess); ok {
-		r0 = returnFunc(name, path, port, sequenceOrder)
+	if returnFunc, ok := ret.Get(0).(func(string, string, *int, ServerProcessLaunch, []int64, int) *ServerProcess); ok {
+		r0 = returnFunc(name, path, port, launch, dependsOn, sequenceOrder)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*ServerProcess)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, *int, int) error); ok {
-		r1 = returnFunc(name, path, port, sequenceOrder)
+	if returnFunc, ok := ret.Get(1).(func(string, string, *int, ServerProcessLaunch, []int64, int) error); ok {
+		r1 = returnFunc(name, path, port, launch, dependsOn, sequenceOrder)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -467,12 +547,14 @@ type MockInternalDB_CreateServerProcess_Call struct {
 //   - name string
 //   - path string
 //   - port *int
+//   - launch ServerProcessLaunch
+//   - dependsOn []int64
 //   - sequenceOrder int
-func (_e *MockInternalDB_Expecter) CreateServerProcess(name interface{}, path interface{}, port interface{}, sequenceOrder interface{}) *MockInternalDB_CreateServerProcess_Call {
-	return &MockInternalDB_CreateServerProcess_Call{Call: _e.mock.On("CreateServerProcess", name, path, port, sequenceOrder)}
+func (_e *MockInternalDB_Expecter) CreateServerProcess(name interface{}, path interface{}, port interface{}, launch interface{}, dependsOn interface{}, sequenceOrder interface{}) *MockInternalDB_CreateServerProcess_Call {
+	return &MockInternalDB_CreateServerProcess_Call{Call: _e.mock.On("CreateServerProcess", name, path, port, launch, dependsOn, sequenceOrder)}
 }
 
-func (_c *MockInternalDB_CreateServerProcess_Call) Run(run func(name string, path string, port *int, sequenceOrder int)) *MockInternalDB_CreateServerProcess_Call {
+func (_c *MockInternalDB_CreateServerProcess_Call) Run(run func(name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64, sequenceOrder int)) *MockInternalDB_CreateServerProcess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -486,15 +568,25 @@ func (_c *MockInternalDB_CreateServerProcess_Call) Run(run func(name string, pat
 		if args[2] != nil {
 			arg2 = args[2].(*int)
 		}
-		var arg3 int
+		var arg3 ServerProcessLaunch
 		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg3 = args[3].(ServerProcessLaunch)
+		}
+		var arg4 []int64
+		if args[4] != nil {
+			arg4 = args[4].([]int64)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -505,7 +597,76 @@ func (_c *MockInternalDB_CreateServerProcess_Call) Return(serverProcess *ServerP
 	return _c
 }
 
-func (_c *MockInternalDB_CreateServerProcess_Call) RunAndReturn(run func(name string, path string, port *int, sequenceOrder int) (*ServerProcess, error)) *MockInternalDB_CreateServerProcess_Call {
+func (_c *MockInternalDB_CreateServerProcess_Call) RunAndReturn(run func(name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64, sequenceOrder int) (*ServerProcess, error)) *MockInternalDB_CreateServerProcess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServerProcessEvent provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) CreateServerProcessEvent(processID int64, eventType string, attempt int, message string) error {
+	ret := _mock.Called(processID, eventType, attempt, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServerProcessEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int64, string, int, string) error); ok {
+		r0 = returnFunc(processID, eventType, attempt, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_CreateServerProcessEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServerProcessEvent'
+type MockInternalDB_CreateServerProcessEvent_Call struct {
+	*mock.Call
+}
+
+// CreateServerProcessEvent is a helper method to define mock.On call
+//   - processID int64
+//   - eventType string
+//   - attempt int
+//   - message string
+func (_e *MockInternalDB_Expecter) CreateServerProcessEvent(processID interface{}, eventType interface{}, attempt interface{}, message interface{}) *MockInternalDB_CreateServerProcessEvent_Call {
+	return &MockInternalDB_CreateServerProcessEvent_Call{Call: _e.mock.On("CreateServerProcessEvent", processID, eventType, attempt, message)}
+}
+
+func (_c *MockInternalDB_CreateServerProcessEvent_Call) Run(run func(processID int64, eventType string, attempt int, message string)) *MockInternalDB_CreateServerProcessEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_CreateServerProcessEvent_Call) Return(err error) *MockInternalDB_CreateServerProcessEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_CreateServerProcessEvent_Call) RunAndReturn(run func(processID int64, eventType string, attempt int, message string) error) *MockInternalDB_CreateServerProcessEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -756,6 +917,57 @@ func (_c *MockInternalDB_CreateUserWithStatus_Call) RunAndReturn(run func(email
 	return _c
 }
 
+// DeleteEmptyChangeSet provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) DeleteEmptyChangeSet(id string) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmptyChangeSet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_DeleteEmptyChangeSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmptyChangeSet'
+type MockInternalDB_DeleteEmptyChangeSet_Call struct {
+	*mock.Call
+}
+
+// DeleteEmptyChangeSet is a helper method to define mock.On call
+//   - id string
+func (_e *MockInternalDB_Expecter) DeleteEmptyChangeSet(id interface{}) *MockInternalDB_DeleteEmptyChangeSet_Call {
+	return &MockInternalDB_DeleteEmptyChangeSet_Call{Call: _e.mock.On("DeleteEmptyChangeSet", id)}
+}
+
+func (_c *MockInternalDB_DeleteEmptyChangeSet_Call) Run(run func(id string)) *MockInternalDB_DeleteEmptyChangeSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_DeleteEmptyChangeSet_Call) Return(_a0 error) *MockInternalDB_DeleteEmptyChangeSet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInternalDB_DeleteEmptyChangeSet_Call) RunAndReturn(run func(id string) error) *MockInternalDB_DeleteEmptyChangeSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteExpiredSessions provides a mock function for the type MockInternalDB
 func (_mock *MockInternalDB) DeleteExpiredSessions() error {
 	ret := _mock.Called()
@@ -1470,44 +1682,46 @@ func (_c *MockInternalDB_GetAllMonsterClientData_Call) RunAndReturn(run func(sea
 	return _c
 }
 
-// GetCompletedRevisionCount provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetCompletedRevisionCount(fileID string) (int64, error) {
-	ret := _mock.Called(fileID)
+// GetChangeSet provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetChangeSet(id string) (*ChangeSet, error) {
+	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCompletedRevisionCount")
+		panic("no return value specified for GetChangeSet")
 	}
 
-	var r0 int64
+	var r0 *ChangeSet
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return returnFunc(fileID)
+	if returnFunc, ok := ret.Get(0).(func(string) (*ChangeSet, error)); ok {
+		return returnFunc(id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = returnFunc(fileID)
+	if returnFunc, ok := ret.Get(0).(func(string) *ChangeSet); ok {
+		r0 = returnFunc(id)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ChangeSet)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(fileID)
+		r1 = returnFunc(id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetCompletedRevisionCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCompletedRevisionCount'
-type MockInternalDB_GetCompletedRevisionCount_Call struct {
+// MockInternalDB_GetChangeSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChangeSet'
+type MockInternalDB_GetChangeSet_Call struct {
 	*mock.Call
 }
 
-// GetCompletedRevisionCount is a helper method to define mock.On call
-//   - fileID string
-func (_e *MockInternalDB_Expecter) GetCompletedRevisionCount(fileID interface{}) *MockInternalDB_GetCompletedRevisionCount_Call {
-	return &MockInternalDB_GetCompletedRevisionCount_Call{Call: _e.mock.On("GetCompletedRevisionCount", fileID)}
+// GetChangeSet is a helper method to define mock.On call
+//   - id string
+func (_e *MockInternalDB_Expecter) GetChangeSet(id interface{}) *MockInternalDB_GetChangeSet_Call {
+	return &MockInternalDB_GetChangeSet_Call{Call: _e.mock.On("GetChangeSet", id)}
 }
 
-func (_c *MockInternalDB_GetCompletedRevisionCount_Call) Run(run func(fileID string)) *MockInternalDB_GetCompletedRevisionCount_Call {
+func (_c *MockInternalDB_GetChangeSet_Call) Run(run func(id string)) *MockInternalDB_GetChangeSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -1520,60 +1734,60 @@ func (_c *MockInternalDB_GetCompletedRevisionCount_Call) Run(run func(fileID str
 	return _c
 }
 
-func (_c *MockInternalDB_GetCompletedRevisionCount_Call) Return(n int64, err error) *MockInternalDB_GetCompletedRevisionCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockInternalDB_GetChangeSet_Call) Return(changeSet *ChangeSet, err error) *MockInternalDB_GetChangeSet_Call {
+	_c.Call.Return(changeSet, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetCompletedRevisionCount_Call) RunAndReturn(run func(fileID string) (int64, error)) *MockInternalDB_GetCompletedRevisionCount_Call {
+func (_c *MockInternalDB_GetChangeSet_Call) RunAndReturn(run func(id string) (*ChangeSet, error)) *MockInternalDB_GetChangeSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileRevision provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetFileRevision(revisionID int64) (*FileRevision, error) {
-	ret := _mock.Called(revisionID)
+// GetChangeSetRevisions provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetChangeSetRevisions(changeSetID string) ([]FileRevision, error) {
+	ret := _mock.Called(changeSetID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileRevision")
+		panic("no return value specified for GetChangeSetRevisions")
 	}
 
-	var r0 *FileRevision
+	var r0 []FileRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int64) (*FileRevision, error)); ok {
-		return returnFunc(revisionID)
+	if returnFunc, ok := ret.Get(0).(func(string) ([]FileRevision, error)); ok {
+		return returnFunc(changeSetID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int64) *FileRevision); ok {
-		r0 = returnFunc(revisionID)
+	if returnFunc, ok := ret.Get(0).(func(string) []FileRevision); ok {
+		r0 = returnFunc(changeSetID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*FileRevision)
+			r0 = ret.Get(0).([]FileRevision)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = returnFunc(revisionID)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(changeSetID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetFileRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileRevision'
-type MockInternalDB_GetFileRevision_Call struct {
+// MockInternalDB_GetChangeSetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChangeSetRevisions'
+type MockInternalDB_GetChangeSetRevisions_Call struct {
 	*mock.Call
 }
 
-// GetFileRevision is a helper method to define mock.On call
-//   - revisionID int64
-func (_e *MockInternalDB_Expecter) GetFileRevision(revisionID interface{}) *MockInternalDB_GetFileRevision_Call {
-	return &MockInternalDB_GetFileRevision_Call{Call: _e.mock.On("GetFileRevision", revisionID)}
+// GetChangeSetRevisions is a helper method to define mock.On call
+//   - changeSetID string
+func (_e *MockInternalDB_Expecter) GetChangeSetRevisions(changeSetID interface{}) *MockInternalDB_GetChangeSetRevisions_Call {
+	return &MockInternalDB_GetChangeSetRevisions_Call{Call: _e.mock.On("GetChangeSetRevisions", changeSetID)}
 }
 
-func (_c *MockInternalDB_GetFileRevision_Call) Run(run func(revisionID int64)) *MockInternalDB_GetFileRevision_Call {
+func (_c *MockInternalDB_GetChangeSetRevisions_Call) Run(run func(changeSetID string)) *MockInternalDB_GetChangeSetRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int64
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(int64)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
@@ -1582,421 +1796,459 @@ func (_c *MockInternalDB_GetFileRevision_Call) Run(run func(revisionID int64)) *
 	return _c
 }
 
-func (_c *MockInternalDB_GetFileRevision_Call) Return(fileRevision *FileRevision, err error) *MockInternalDB_GetFileRevision_Call {
-	_c.Call.Return(fileRevision, err)
+func (_c *MockInternalDB_GetChangeSetRevisions_Call) Return(fileRevisions []FileRevision, err error) *MockInternalDB_GetChangeSetRevisions_Call {
+	_c.Call.Return(fileRevisions, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetFileRevision_Call) RunAndReturn(run func(revisionID int64) (*FileRevision, error)) *MockInternalDB_GetFileRevision_Call {
+func (_c *MockInternalDB_GetChangeSetRevisions_Call) RunAndReturn(run func(changeSetID string) ([]FileRevision, error)) *MockInternalDB_GetChangeSetRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastCompletedFileRevision provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetLastCompletedFileRevision(fileID string) (*FileRevision, error) {
-	ret := _mock.Called(fileID)
+// GetChangeSetsPaginated provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetChangeSetsPaginated(page int, pageSize int, status string) ([]ChangeSetListItem, int64, error) {
+	ret := _mock.Called(page, pageSize, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastCompletedFileRevision")
+		panic("no return value specified for GetChangeSetsPaginated")
 	}
 
-	var r0 *FileRevision
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*FileRevision, error)); ok {
-		return returnFunc(fileID)
+	var r0 []ChangeSetListItem
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, string) ([]ChangeSetListItem, int64, error)); ok {
+		return returnFunc(page, pageSize, status)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *FileRevision); ok {
-		r0 = returnFunc(fileID)
+	if returnFunc, ok := ret.Get(0).(func(int, int, string) []ChangeSetListItem); ok {
+		r0 = returnFunc(page, pageSize, status)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*FileRevision)
+			r0 = ret.Get(0).([]ChangeSetListItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(fileID)
+	if returnFunc, ok := ret.Get(1).(func(int, int, string) int64); ok {
+		r1 = returnFunc(page, pageSize, status)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(int, int, string) error); ok {
+		r2 = returnFunc(page, pageSize, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockInternalDB_GetLastCompletedFileRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastCompletedFileRevision'
-type MockInternalDB_GetLastCompletedFileRevision_Call struct {
+// MockInternalDB_GetChangeSetsPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChangeSetsPaginated'
+type MockInternalDB_GetChangeSetsPaginated_Call struct {
 	*mock.Call
 }
 
-// GetLastCompletedFileRevision is a helper method to define mock.On call
-//   - fileID string
-func (_e *MockInternalDB_Expecter) GetLastCompletedFileRevision(fileID interface{}) *MockInternalDB_GetLastCompletedFileRevision_Call {
-	return &MockInternalDB_GetLastCompletedFileRevision_Call{Call: _e.mock.On("GetLastCompletedFileRevision", fileID)}
+// GetChangeSetsPaginated is a helper method to define mock.On call
+//   - page int
+//   - pageSize int
+//   - status string
+func (_e *MockInternalDB_Expecter) GetChangeSetsPaginated(page interface{}, pageSize interface{}, status interface{}) *MockInternalDB_GetChangeSetsPaginated_Call {
+	return &MockInternalDB_GetChangeSetsPaginated_Call{Call: _e.mock.On("GetChangeSetsPaginated", page, pageSize, status)}
 }
 
-func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) Run(run func(fileID string)) *MockInternalDB_GetLastCompletedFileRevision_Call {
+func (_c *MockInternalDB_GetChangeSetsPaginated_Call) Run(run func(page int, pageSize int, status string)) *MockInternalDB_GetChangeSetsPaginated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) Return(fileRevision *FileRevision, err error) *MockInternalDB_GetLastCompletedFileRevision_Call {
-	_c.Call.Return(fileRevision, err)
+func (_c *MockInternalDB_GetChangeSetsPaginated_Call) Return(changeSetListItems []ChangeSetListItem, n int64, err error) *MockInternalDB_GetChangeSetsPaginated_Call {
+	_c.Call.Return(changeSetListItems, n, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) RunAndReturn(run func(fileID string) (*FileRevision, error)) *MockInternalDB_GetLastCompletedFileRevision_Call {
+func (_c *MockInternalDB_GetChangeSetsPaginated_Call) RunAndReturn(run func(page int, pageSize int, status string) ([]ChangeSetListItem, int64, error)) *MockInternalDB_GetChangeSetsPaginated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestSamples provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetLatestSamples() ([]LatestSample, error) {
-	ret := _mock.Called()
+// GetCompletedRevisionCount provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetCompletedRevisionCount(fileID string) (int64, error) {
+	ret := _mock.Called(fileID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLatestSamples")
+		panic("no return value specified for GetCompletedRevisionCount")
 	}
 
-	var r0 []LatestSample
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]LatestSample, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return returnFunc(fileID)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []LatestSample); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = returnFunc(fileID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]LatestSample)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(fileID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetLatestSamples_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestSamples'
-type MockInternalDB_GetLatestSamples_Call struct {
+// MockInternalDB_GetCompletedRevisionCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCompletedRevisionCount'
+type MockInternalDB_GetCompletedRevisionCount_Call struct {
 	*mock.Call
 }
 
-// GetLatestSamples is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetLatestSamples() *MockInternalDB_GetLatestSamples_Call {
-	return &MockInternalDB_GetLatestSamples_Call{Call: _e.mock.On("GetLatestSamples")}
+// GetCompletedRevisionCount is a helper method to define mock.On call
+//   - fileID string
+func (_e *MockInternalDB_Expecter) GetCompletedRevisionCount(fileID interface{}) *MockInternalDB_GetCompletedRevisionCount_Call {
+	return &MockInternalDB_GetCompletedRevisionCount_Call{Call: _e.mock.On("GetCompletedRevisionCount", fileID)}
 }
 
-func (_c *MockInternalDB_GetLatestSamples_Call) Run(run func()) *MockInternalDB_GetLatestSamples_Call {
+func (_c *MockInternalDB_GetCompletedRevisionCount_Call) Run(run func(fileID string)) *MockInternalDB_GetCompletedRevisionCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetLatestSamples_Call) Return(latestSamples []LatestSample, err error) *MockInternalDB_GetLatestSamples_Call {
-	_c.Call.Return(latestSamples, err)
+func (_c *MockInternalDB_GetCompletedRevisionCount_Call) Return(n int64, err error) *MockInternalDB_GetCompletedRevisionCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetLatestSamples_Call) RunAndReturn(run func() ([]LatestSample, error)) *MockInternalDB_GetLatestSamples_Call {
+func (_c *MockInternalDB_GetCompletedRevisionCount_Call) RunAndReturn(run func(fileID string) (int64, error)) *MockInternalDB_GetCompletedRevisionCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMaxSequenceOrder provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetMaxSequenceOrder() (int, error) {
-	ret := _mock.Called()
+// GetFileRevision provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetFileRevision(revisionID int64) (*FileRevision, error) {
+	ret := _mock.Called(revisionID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMaxSequenceOrder")
+		panic("no return value specified for GetFileRevision")
 	}
 
-	var r0 int
+	var r0 *FileRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() (int, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int64) (*FileRevision, error)); ok {
+		return returnFunc(revisionID)
 	}
-	if returnFunc, ok := ret.Get(0).(func() int); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int64) *FileRevision); ok {
+		r0 = returnFunc(revisionID)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*FileRevision)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = returnFunc(revisionID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetMaxSequenceOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaxSequenceOrder'
-type MockInternalDB_GetMaxSequenceOrder_Call struct {
+// MockInternalDB_GetFileRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileRevision'
+type MockInternalDB_GetFileRevision_Call struct {
 	*mock.Call
 }
 
-// GetMaxSequenceOrder is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetMaxSequenceOrder() *MockInternalDB_GetMaxSequenceOrder_Call {
-	return &MockInternalDB_GetMaxSequenceOrder_Call{Call: _e.mock.On("GetMaxSequenceOrder")}
+// GetFileRevision is a helper method to define mock.On call
+//   - revisionID int64
+func (_e *MockInternalDB_Expecter) GetFileRevision(revisionID interface{}) *MockInternalDB_GetFileRevision_Call {
+	return &MockInternalDB_GetFileRevision_Call{Call: _e.mock.On("GetFileRevision", revisionID)}
 }
 
-func (_c *MockInternalDB_GetMaxSequenceOrder_Call) Run(run func()) *MockInternalDB_GetMaxSequenceOrder_Call {
+func (_c *MockInternalDB_GetFileRevision_Call) Run(run func(revisionID int64)) *MockInternalDB_GetFileRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetMaxSequenceOrder_Call) Return(n int, err error) *MockInternalDB_GetMaxSequenceOrder_Call {
-	_c.Call.Return(n, err)
+func (_c *MockInternalDB_GetFileRevision_Call) Return(fileRevision *FileRevision, err error) *MockInternalDB_GetFileRevision_Call {
+	_c.Call.Return(fileRevision, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetMaxSequenceOrder_Call) RunAndReturn(run func() (int, error)) *MockInternalDB_GetMaxSequenceOrder_Call {
+func (_c *MockInternalDB_GetFileRevision_Call) RunAndReturn(run func(revisionID int64) (*FileRevision, error)) *MockInternalDB_GetFileRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMetricSamplesByTimeRange provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetMetricSamplesByTimeRange(metricName string, startTime int64, endTime int64) ([]MetricSampleWithLabels, error) {
-	ret := _mock.Called(metricName, startTime, endTime)
+// GetFileRevisionsByStatus provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetFileRevisionsByStatus(statuses []string) ([]FileRevision, error) {
+	ret := _mock.Called(statuses)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMetricSamplesByTimeRange")
+		panic("no return value specified for GetFileRevisionsByStatus")
 	}
 
-	var r0 []MetricSampleWithLabels
+	var r0 []FileRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, int64, int64) ([]MetricSampleWithLabels, error)); ok {
-		return returnFunc(metricName, startTime, endTime)
+	if returnFunc, ok := ret.Get(0).(func([]string) ([]FileRevision, error)); ok {
+		return returnFunc(statuses)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, int64, int64) []MetricSampleWithLabels); ok {
-		r0 = returnFunc(metricName, startTime, endTime)
+	if returnFunc, ok := ret.Get(0).(func([]string) []FileRevision); ok {
+		r0 = returnFunc(statuses)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]MetricSampleWithLabels)
+			r0 = ret.Get(0).([]FileRevision)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, int64, int64) error); ok {
-		r1 = returnFunc(metricName, startTime, endTime)
+	if returnFunc, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = returnFunc(statuses)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetMetricSamplesByTimeRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetricSamplesByTimeRange'
-type MockInternalDB_GetMetricSamplesByTimeRange_Call struct {
+// MockInternalDB_GetFileRevisionsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileRevisionsByStatus'
+type MockInternalDB_GetFileRevisionsByStatus_Call struct {
 	*mock.Call
 }
 
-// GetMetricSamplesByTimeRange is a helper method to define mock.On call
-//   - metricName string
-//   - startTime int64
-//   - endTime int64
-func (_e *MockInternalDB_Expecter) GetMetricSamplesByTimeRange(metricName interface{}, startTime interface{}, endTime interface{}) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
-	return &MockInternalDB_GetMetricSamplesByTimeRange_Call{Call: _e.mock.On("GetMetricSamplesByTimeRange", metricName, startTime, endTime)}
+// GetFileRevisionsByStatus is a helper method to define mock.On call
+//   - statuses []string
+func (_e *MockInternalDB_Expecter) GetFileRevisionsByStatus(statuses interface{}) *MockInternalDB_GetFileRevisionsByStatus_Call {
+	return &MockInternalDB_GetFileRevisionsByStatus_Call{Call: _e.mock.On("GetFileRevisionsByStatus", statuses)}
 }
 
-func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) Run(run func(metricName string, startTime int64, endTime int64)) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
+func (_c *MockInternalDB_GetFileRevisionsByStatus_Call) Run(run func(statuses []string)) *MockInternalDB_GetFileRevisionsByStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 []string
 		if args[0] != nil {
-			arg0 = args[0].(string)
-		}
-		var arg1 int64
-		if args[1] != nil {
-			arg1 = args[1].(int64)
-		}
-		var arg2 int64
-		if args[2] != nil {
-			arg2 = args[2].(int64)
+			arg0 = args[0].([]string)
 		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) Return(metricSampleWithLabelss []MetricSampleWithLabels, err error) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
-	_c.Call.Return(metricSampleWithLabelss, err)
+func (_c *MockInternalDB_GetFileRevisionsByStatus_Call) Return(fileRevisions []FileRevision, err error) *MockInternalDB_GetFileRevisionsByStatus_Call {
+	_c.Call.Return(fileRevisions, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) RunAndReturn(run func(metricName string, startTime int64, endTime int64) ([]MetricSampleWithLabels, error)) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
+func (_c *MockInternalDB_GetFileRevisionsByStatus_Call) RunAndReturn(run func(statuses []string) ([]FileRevision, error)) *MockInternalDB_GetFileRevisionsByStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRevisionSummary provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetRevisionSummary(fileID string) (*RevisionSummary, error) {
-	ret := _mock.Called(fileID)
+// GetFileRevisionsForRetention provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetFileRevisionsForRetention() ([]FileRevision, error) {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRevisionSummary")
+		panic("no return value specified for GetFileRevisionsForRetention")
 	}
 
-	var r0 *RevisionSummary
+	var r0 []FileRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*RevisionSummary, error)); ok {
-		return returnFunc(fileID)
+	if returnFunc, ok := ret.Get(0).(func() ([]FileRevision, error)); ok {
+		return returnFunc()
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *RevisionSummary); ok {
-		r0 = returnFunc(fileID)
+	if returnFunc, ok := ret.Get(0).(func() []FileRevision); ok {
+		r0 = returnFunc()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*RevisionSummary)
+			r0 = ret.Get(0).([]FileRevision)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(fileID)
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetRevisionSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisionSummary'
-type MockInternalDB_GetRevisionSummary_Call struct {
+// MockInternalDB_GetFileRevisionsForRetention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileRevisionsForRetention'
+type MockInternalDB_GetFileRevisionsForRetention_Call struct {
 	*mock.Call
 }
 
-// GetRevisionSummary is a helper method to define mock.On call
-//   - fileID string
-func (_e *MockInternalDB_Expecter) GetRevisionSummary(fileID interface{}) *MockInternalDB_GetRevisionSummary_Call {
-	return &MockInternalDB_GetRevisionSummary_Call{Call: _e.mock.On("GetRevisionSummary", fileID)}
+// GetFileRevisionsForRetention is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetFileRevisionsForRetention() *MockInternalDB_GetFileRevisionsForRetention_Call {
+	return &MockInternalDB_GetFileRevisionsForRetention_Call{Call: _e.mock.On("GetFileRevisionsForRetention")}
 }
 
-func (_c *MockInternalDB_GetRevisionSummary_Call) Run(run func(fileID string)) *MockInternalDB_GetRevisionSummary_Call {
+func (_c *MockInternalDB_GetFileRevisionsForRetention_Call) Run(run func()) *MockInternalDB_GetFileRevisionsForRetention_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
-		if args[0] != nil {
-			arg0 = args[0].(string)
-		}
-		run(
-			arg0,
-		)
+		run()
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetRevisionSummary_Call) Return(revisionSummary *RevisionSummary, err error) *MockInternalDB_GetRevisionSummary_Call {
-	_c.Call.Return(revisionSummary, err)
+func (_c *MockInternalDB_GetFileRevisionsForRetention_Call) Return(fileRevisions []FileRevision, err error) *MockInternalDB_GetFileRevisionsForRetention_Call {
+	_c.Call.Return(fileRevisions, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetRevisionSummary_Call) RunAndReturn(run func(fileID string) (*RevisionSummary, error)) *MockInternalDB_GetRevisionSummary_Call {
+func (_c *MockInternalDB_GetFileRevisionsForRetention_Call) RunAndReturn(run func() ([]FileRevision, error)) *MockInternalDB_GetFileRevisionsForRetention_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSeriesWithLabels provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetSeriesWithLabels() ([]SeriesWithLabels, error) {
-	ret := _mock.Called()
+// GetFileRevisionsPaginated provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetFileRevisionsPaginated(fileID string, page int, pageSize int) ([]FileRevisionListItem, int64, error) {
+	ret := _mock.Called(fileID, page, pageSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSeriesWithLabels")
+		panic("no return value specified for GetFileRevisionsPaginated")
 	}
 
-	var r0 []SeriesWithLabels
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]SeriesWithLabels, error)); ok {
-		return returnFunc()
+	var r0 []FileRevisionListItem
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) ([]FileRevisionListItem, int64, error)); ok {
+		return returnFunc(fileID, page, pageSize)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []SeriesWithLabels); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) []FileRevisionListItem); ok {
+		r0 = returnFunc(fileID, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]SeriesWithLabels)
+			r0 = ret.Get(0).([]FileRevisionListItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) int64); ok {
+		r1 = returnFunc(fileID, page, pageSize)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(string, int, int) error); ok {
+		r2 = returnFunc(fileID, page, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockInternalDB_GetSeriesWithLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSeriesWithLabels'
-type MockInternalDB_GetSeriesWithLabels_Call struct {
+// MockInternalDB_GetFileRevisionsPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileRevisionsPaginated'
+type MockInternalDB_GetFileRevisionsPaginated_Call struct {
 	*mock.Call
 }
 
-// GetSeriesWithLabels is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetSeriesWithLabels() *MockInternalDB_GetSeriesWithLabels_Call {
-	return &MockInternalDB_GetSeriesWithLabels_Call{Call: _e.mock.On("GetSeriesWithLabels")}
+// GetFileRevisionsPaginated is a helper method to define mock.On call
+//   - fileID string
+//   - page int
+//   - pageSize int
+func (_e *MockInternalDB_Expecter) GetFileRevisionsPaginated(fileID interface{}, page interface{}, pageSize interface{}) *MockInternalDB_GetFileRevisionsPaginated_Call {
+	return &MockInternalDB_GetFileRevisionsPaginated_Call{Call: _e.mock.On("GetFileRevisionsPaginated", fileID, page, pageSize)}
 }
 
-func (_c *MockInternalDB_GetSeriesWithLabels_Call) Run(run func()) *MockInternalDB_GetSeriesWithLabels_Call {
+func (_c *MockInternalDB_GetFileRevisionsPaginated_Call) Run(run func(fileID string, page int, pageSize int)) *MockInternalDB_GetFileRevisionsPaginated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetSeriesWithLabels_Call) Return(seriesWithLabelss []SeriesWithLabels, err error) *MockInternalDB_GetSeriesWithLabels_Call {
-	_c.Call.Return(seriesWithLabelss, err)
+func (_c *MockInternalDB_GetFileRevisionsPaginated_Call) Return(fileRevisionListItems []FileRevisionListItem, n int64, err error) *MockInternalDB_GetFileRevisionsPaginated_Call {
+	_c.Call.Return(fileRevisionListItems, n, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetSeriesWithLabels_Call) RunAndReturn(run func() ([]SeriesWithLabels, error)) *MockInternalDB_GetSeriesWithLabels_Call {
+func (_c *MockInternalDB_GetFileRevisionsPaginated_Call) RunAndReturn(run func(fileID string, page int, pageSize int) ([]FileRevisionListItem, int64, error)) *MockInternalDB_GetFileRevisionsPaginated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServerProcess provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetServerProcess(id int64) (*ServerProcess, error) {
-	ret := _mock.Called(id)
+// GetItemClientDataByIDs provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetItemClientDataByIDs(ids []int64) ([]ItemClientData, error) {
+	ret := _mock.Called(ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetServerProcess")
+		panic("no return value specified for GetItemClientDataByIDs")
 	}
 
-	var r0 *ServerProcess
+	var r0 []ItemClientData
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int64) (*ServerProcess, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func([]int64) ([]ItemClientData, error)); ok {
+		return returnFunc(ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int64) *ServerProcess); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func([]int64) []ItemClientData); ok {
+		r0 = returnFunc(ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ServerProcess)
+			r0 = ret.Get(0).([]ItemClientData)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = returnFunc(ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetServerProcess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcess'
-type MockInternalDB_GetServerProcess_Call struct {
+// MockInternalDB_GetItemClientDataByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemClientDataByIDs'
+type MockInternalDB_GetItemClientDataByIDs_Call struct {
 	*mock.Call
 }
 
-// GetServerProcess is a helper method to define mock.On call
-//   - id int64
-func (_e *MockInternalDB_Expecter) GetServerProcess(id interface{}) *MockInternalDB_GetServerProcess_Call {
-	return &MockInternalDB_GetServerProcess_Call{Call: _e.mock.On("GetServerProcess", id)}
+// GetItemClientDataByIDs is a helper method to define mock.On call
+//   - ids []int64
+func (_e *MockInternalDB_Expecter) GetItemClientDataByIDs(ids interface{}) *MockInternalDB_GetItemClientDataByIDs_Call {
+	return &MockInternalDB_GetItemClientDataByIDs_Call{Call: _e.mock.On("GetItemClientDataByIDs", ids)}
 }
 
-func (_c *MockInternalDB_GetServerProcess_Call) Run(run func(id int64)) *MockInternalDB_GetServerProcess_Call {
+func (_c *MockInternalDB_GetItemClientDataByIDs_Call) Run(run func(ids []int64)) *MockInternalDB_GetItemClientDataByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int64
+		var arg0 []int64
 		if args[0] != nil {
-			arg0 = args[0].(int64)
+			arg0 = args[0].([]int64)
 		}
 		run(
 			arg0,
@@ -2005,177 +2257,177 @@ func (_c *MockInternalDB_GetServerProcess_Call) Run(run func(id int64)) *MockInt
 	return _c
 }
 
-func (_c *MockInternalDB_GetServerProcess_Call) Return(serverProcess *ServerProcess, err error) *MockInternalDB_GetServerProcess_Call {
-	_c.Call.Return(serverProcess, err)
+func (_c *MockInternalDB_GetItemClientDataByIDs_Call) Return(itemClientDatas []ItemClientData, err error) *MockInternalDB_GetItemClientDataByIDs_Call {
+	_c.Call.Return(itemClientDatas, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetServerProcess_Call) RunAndReturn(run func(id int64) (*ServerProcess, error)) *MockInternalDB_GetServerProcess_Call {
+func (_c *MockInternalDB_GetItemClientDataByIDs_Call) RunAndReturn(run func(ids []int64) ([]ItemClientData, error)) *MockInternalDB_GetItemClientDataByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServerProcesses provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetServerProcesses() ([]ServerProcess, error) {
-	ret := _mock.Called()
+// GetLastCompletedFileRevision provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetLastCompletedFileRevision(fileID string) (*FileRevision, error) {
+	ret := _mock.Called(fileID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetServerProcesses")
+		panic("no return value specified for GetLastCompletedFileRevision")
 	}
 
-	var r0 []ServerProcess
+	var r0 *FileRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]ServerProcess, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string) (*FileRevision, error)); ok {
+		return returnFunc(fileID)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []ServerProcess); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string) *FileRevision); ok {
+		r0 = returnFunc(fileID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ServerProcess)
+			r0 = ret.Get(0).(*FileRevision)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(fileID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetServerProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcesses'
-type MockInternalDB_GetServerProcesses_Call struct {
+// MockInternalDB_GetLastCompletedFileRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastCompletedFileRevision'
+type MockInternalDB_GetLastCompletedFileRevision_Call struct {
 	*mock.Call
 }
 
-// GetServerProcesses is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetServerProcesses() *MockInternalDB_GetServerProcesses_Call {
-	return &MockInternalDB_GetServerProcesses_Call{Call: _e.mock.On("GetServerProcesses")}
+// GetLastCompletedFileRevision is a helper method to define mock.On call
+//   - fileID string
+func (_e *MockInternalDB_Expecter) GetLastCompletedFileRevision(fileID interface{}) *MockInternalDB_GetLastCompletedFileRevision_Call {
+	return &MockInternalDB_GetLastCompletedFileRevision_Call{Call: _e.mock.On("GetLastCompletedFileRevision", fileID)}
 }
 
-func (_c *MockInternalDB_GetServerProcesses_Call) Run(run func()) *MockInternalDB_GetServerProcesses_Call {
+func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) Run(run func(fileID string)) *MockInternalDB_GetLastCompletedFileRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetServerProcesses_Call) Return(serverProcesss []ServerProcess, err error) *MockInternalDB_GetServerProcesses_Call {
-	_c.Call.Return(serverProcesss, err)
+func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) Return(fileRevision *FileRevision, err error) *MockInternalDB_GetLastCompletedFileRevision_Call {
+	_c.Call.Return(fileRevision, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetServerProcesses_Call) RunAndReturn(run func() ([]ServerProcess, error)) *MockInternalDB_GetServerProcesses_Call {
+func (_c *MockInternalDB_GetLastCompletedFileRevision_Call) RunAndReturn(run func(fileID string) (*FileRevision, error)) *MockInternalDB_GetLastCompletedFileRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSession provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetSession(sessionID string) (*Session, error) {
-	ret := _mock.Called(sessionID)
+// GetLatestSamples provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetLatestSamples() ([]LatestSample, error) {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSession")
+		panic("no return value specified for GetLatestSamples")
 	}
 
-	var r0 *Session
+	var r0 []LatestSample
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*Session, error)); ok {
-		return returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(0).(func() ([]LatestSample, error)); ok {
+		return returnFunc()
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *Session); ok {
-		r0 = returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(0).(func() []LatestSample); ok {
+		r0 = returnFunc()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Session)
+			r0 = ret.Get(0).([]LatestSample)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
-type MockInternalDB_GetSession_Call struct {
+// MockInternalDB_GetLatestSamples_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestSamples'
+type MockInternalDB_GetLatestSamples_Call struct {
 	*mock.Call
 }
 
-// GetSession is a helper method to define mock.On call
-//   - sessionID string
-func (_e *MockInternalDB_Expecter) GetSession(sessionID interface{}) *MockInternalDB_GetSession_Call {
-	return &MockInternalDB_GetSession_Call{Call: _e.mock.On("GetSession", sessionID)}
+// GetLatestSamples is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetLatestSamples() *MockInternalDB_GetLatestSamples_Call {
+	return &MockInternalDB_GetLatestSamples_Call{Call: _e.mock.On("GetLatestSamples")}
 }
 
-func (_c *MockInternalDB_GetSession_Call) Run(run func(sessionID string)) *MockInternalDB_GetSession_Call {
+func (_c *MockInternalDB_GetLatestSamples_Call) Run(run func()) *MockInternalDB_GetLatestSamples_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
-		if args[0] != nil {
-			arg0 = args[0].(string)
-		}
-		run(
-			arg0,
-		)
+		run()
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetSession_Call) Return(session *Session, err error) *MockInternalDB_GetSession_Call {
-	_c.Call.Return(session, err)
+func (_c *MockInternalDB_GetLatestSamples_Call) Return(latestSamples []LatestSample, err error) *MockInternalDB_GetLatestSamples_Call {
+	_c.Call.Return(latestSamples, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetSession_Call) RunAndReturn(run func(sessionID string) (*Session, error)) *MockInternalDB_GetSession_Call {
+func (_c *MockInternalDB_GetLatestSamples_Call) RunAndReturn(run func() ([]LatestSample, error)) *MockInternalDB_GetLatestSamples_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSetting provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetSetting(key string) (*Settings, error) {
-	ret := _mock.Called(key)
+// GetMapClientDataByIDs provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetMapClientDataByIDs(ids []int64) ([]MapClientData, error) {
+	ret := _mock.Called(ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSetting")
+		panic("no return value specified for GetMapClientDataByIDs")
 	}
 
-	var r0 *Settings
+	var r0 []MapClientData
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*Settings, error)); ok {
-		return returnFunc(key)
+	if returnFunc, ok := ret.Get(0).(func([]int64) ([]MapClientData, error)); ok {
+		return returnFunc(ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *Settings); ok {
-		r0 = returnFunc(key)
+	if returnFunc, ok := ret.Get(0).(func([]int64) []MapClientData); ok {
+		r0 = returnFunc(ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Settings)
+			r0 = ret.Get(0).([]MapClientData)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(key)
+	if returnFunc, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = returnFunc(ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSetting'
-type MockInternalDB_GetSetting_Call struct {
+// MockInternalDB_GetMapClientDataByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMapClientDataByIDs'
+type MockInternalDB_GetMapClientDataByIDs_Call struct {
 	*mock.Call
 }
 
-// GetSetting is a helper method to define mock.On call
-//   - key string
-func (_e *MockInternalDB_Expecter) GetSetting(key interface{}) *MockInternalDB_GetSetting_Call {
-	return &MockInternalDB_GetSetting_Call{Call: _e.mock.On("GetSetting", key)}
+// GetMapClientDataByIDs is a helper method to define mock.On call
+//   - ids []int64
+func (_e *MockInternalDB_Expecter) GetMapClientDataByIDs(ids interface{}) *MockInternalDB_GetMapClientDataByIDs_Call {
+	return &MockInternalDB_GetMapClientDataByIDs_Call{Call: _e.mock.On("GetMapClientDataByIDs", ids)}
 }
 
-func (_c *MockInternalDB_GetSetting_Call) Run(run func(key string)) *MockInternalDB_GetSetting_Call {
+func (_c *MockInternalDB_GetMapClientDataByIDs_Call) Run(run func(ids []int64)) *MockInternalDB_GetMapClientDataByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 []int64
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].([]int64)
 		}
 		run(
 			arg0,
@@ -2184,35 +2436,33 @@ func (_c *MockInternalDB_GetSetting_Call) Run(run func(key string)) *MockInterna
 	return _c
 }
 
-func (_c *MockInternalDB_GetSetting_Call) Return(settings *Settings, err error) *MockInternalDB_GetSetting_Call {
-	_c.Call.Return(settings, err)
+func (_c *MockInternalDB_GetMapClientDataByIDs_Call) Return(mapClientDatas []MapClientData, err error) *MockInternalDB_GetMapClientDataByIDs_Call {
+	_c.Call.Return(mapClientDatas, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetSetting_Call) RunAndReturn(run func(key string) (*Settings, error)) *MockInternalDB_GetSetting_Call {
+func (_c *MockInternalDB_GetMapClientDataByIDs_Call) RunAndReturn(run func(ids []int64) ([]MapClientData, error)) *MockInternalDB_GetMapClientDataByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSettings provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetSettings() ([]Settings, error) {
+// GetMaxSequenceOrder provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetMaxSequenceOrder() (int, error) {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSettings")
+		panic("no return value specified for GetMaxSequenceOrder")
 	}
 
-	var r0 []Settings
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]Settings, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func() (int, error)); ok {
 		return returnFunc()
 	}
-	if returnFunc, ok := ret.Get(0).(func() []Settings); ok {
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
 		r0 = returnFunc()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Settings)
-		}
+		r0 = ret.Get(0).(int)
 	}
 	if returnFunc, ok := ret.Get(1).(func() error); ok {
 		r1 = returnFunc()
@@ -2222,139 +2472,151 @@ func (_mock *MockInternalDB) GetSettings() ([]Settings, error) {
 	return r0, r1
 }
 
-// MockInternalDB_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
-type MockInternalDB_GetSettings_Call struct {
+// MockInternalDB_GetMaxSequenceOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaxSequenceOrder'
+type MockInternalDB_GetMaxSequenceOrder_Call struct {
 	*mock.Call
 }
 
-// GetSettings is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetSettings() *MockInternalDB_GetSettings_Call {
-	return &MockInternalDB_GetSettings_Call{Call: _e.mock.On("GetSettings")}
+// GetMaxSequenceOrder is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetMaxSequenceOrder() *MockInternalDB_GetMaxSequenceOrder_Call {
+	return &MockInternalDB_GetMaxSequenceOrder_Call{Call: _e.mock.On("GetMaxSequenceOrder")}
 }
 
-func (_c *MockInternalDB_GetSettings_Call) Run(run func()) *MockInternalDB_GetSettings_Call {
+func (_c *MockInternalDB_GetMaxSequenceOrder_Call) Run(run func()) *MockInternalDB_GetMaxSequenceOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetSettings_Call) Return(settingss []Settings, err error) *MockInternalDB_GetSettings_Call {
-	_c.Call.Return(settingss, err)
+func (_c *MockInternalDB_GetMaxSequenceOrder_Call) Return(n int, err error) *MockInternalDB_GetMaxSequenceOrder_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetSettings_Call) RunAndReturn(run func() ([]Settings, error)) *MockInternalDB_GetSettings_Call {
+func (_c *MockInternalDB_GetMaxSequenceOrder_Call) RunAndReturn(run func() (int, error)) *MockInternalDB_GetMaxSequenceOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByEmail provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUserByEmail(email string) (*User, error) {
-	ret := _mock.Called(email)
+// GetMetricSamplesByTimeRange provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetMetricSamplesByTimeRange(metricName string, startTime int64, endTime int64) ([]MetricSampleWithLabels, error) {
+	ret := _mock.Called(metricName, startTime, endTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmail")
+		panic("no return value specified for GetMetricSamplesByTimeRange")
 	}
 
-	var r0 *User
+	var r0 []MetricSampleWithLabels
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*User, error)); ok {
-		return returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func(string, int64, int64) ([]MetricSampleWithLabels, error)); ok {
+		return returnFunc(metricName, startTime, endTime)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *User); ok {
-		r0 = returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func(string, int64, int64) []MetricSampleWithLabels); ok {
+		r0 = returnFunc(metricName, startTime, endTime)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]MetricSampleWithLabels)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(email)
+	if returnFunc, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = returnFunc(metricName, startTime, endTime)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
-type MockInternalDB_GetUserByEmail_Call struct {
+// MockInternalDB_GetMetricSamplesByTimeRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetricSamplesByTimeRange'
+type MockInternalDB_GetMetricSamplesByTimeRange_Call struct {
 	*mock.Call
 }
 
-// GetUserByEmail is a helper method to define mock.On call
-//   - email string
-func (_e *MockInternalDB_Expecter) GetUserByEmail(email interface{}) *MockInternalDB_GetUserByEmail_Call {
-	return &MockInternalDB_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", email)}
+// GetMetricSamplesByTimeRange is a helper method to define mock.On call
+//   - metricName string
+//   - startTime int64
+//   - endTime int64
+func (_e *MockInternalDB_Expecter) GetMetricSamplesByTimeRange(metricName interface{}, startTime interface{}, endTime interface{}) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
+	return &MockInternalDB_GetMetricSamplesByTimeRange_Call{Call: _e.mock.On("GetMetricSamplesByTimeRange", metricName, startTime, endTime)}
 }
 
-func (_c *MockInternalDB_GetUserByEmail_Call) Run(run func(email string)) *MockInternalDB_GetUserByEmail_Call {
+func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) Run(run func(metricName string, startTime int64, endTime int64)) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByEmail_Call) Return(user *User, err error) *MockInternalDB_GetUserByEmail_Call {
-	_c.Call.Return(user, err)
+func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) Return(metricSampleWithLabelss []MetricSampleWithLabels, err error) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
+	_c.Call.Return(metricSampleWithLabelss, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByEmail_Call) RunAndReturn(run func(email string) (*User, error)) *MockInternalDB_GetUserByEmail_Call {
+func (_c *MockInternalDB_GetMetricSamplesByTimeRange_Call) RunAndReturn(run func(metricName string, startTime int64, endTime int64) ([]MetricSampleWithLabels, error)) *MockInternalDB_GetMetricSamplesByTimeRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByEmailIncludeDeleted provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUserByEmailIncludeDeleted(email string) (*User, error) {
-	ret := _mock.Called(email)
+// GetMonsterClientDataByIDs provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetMonsterClientDataByIDs(ids []int64) ([]MonsterClientData, error) {
+	ret := _mock.Called(ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmailIncludeDeleted")
+		panic("no return value specified for GetMonsterClientDataByIDs")
 	}
 
-	var r0 *User
+	var r0 []MonsterClientData
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*User, error)); ok {
-		return returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func([]int64) ([]MonsterClientData, error)); ok {
+		return returnFunc(ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *User); ok {
-		r0 = returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func([]int64) []MonsterClientData); ok {
+		r0 = returnFunc(ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]MonsterClientData)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(email)
+	if returnFunc, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = returnFunc(ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetUserByEmailIncludeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmailIncludeDeleted'
-type MockInternalDB_GetUserByEmailIncludeDeleted_Call struct {
+// MockInternalDB_GetMonsterClientDataByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMonsterClientDataByIDs'
+type MockInternalDB_GetMonsterClientDataByIDs_Call struct {
 	*mock.Call
 }
 
-// GetUserByEmailIncludeDeleted is a helper method to define mock.On call
-//   - email string
-func (_e *MockInternalDB_Expecter) GetUserByEmailIncludeDeleted(email interface{}) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
-	return &MockInternalDB_GetUserByEmailIncludeDeleted_Call{Call: _e.mock.On("GetUserByEmailIncludeDeleted", email)}
+// GetMonsterClientDataByIDs is a helper method to define mock.On call
+//   - ids []int64
+func (_e *MockInternalDB_Expecter) GetMonsterClientDataByIDs(ids interface{}) *MockInternalDB_GetMonsterClientDataByIDs_Call {
+	return &MockInternalDB_GetMonsterClientDataByIDs_Call{Call: _e.mock.On("GetMonsterClientDataByIDs", ids)}
 }
 
-func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) Run(run func(email string)) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+func (_c *MockInternalDB_GetMonsterClientDataByIDs_Call) Run(run func(ids []int64)) *MockInternalDB_GetMonsterClientDataByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 []int64
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].([]int64)
 		}
 		run(
 			arg0,
@@ -2363,60 +2625,60 @@ func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) Run(run func(email s
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) Return(user *User, err error) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
-	_c.Call.Return(user, err)
+func (_c *MockInternalDB_GetMonsterClientDataByIDs_Call) Return(monsterClientDatas []MonsterClientData, err error) *MockInternalDB_GetMonsterClientDataByIDs_Call {
+	_c.Call.Return(monsterClientDatas, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) RunAndReturn(run func(email string) (*User, error)) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+func (_c *MockInternalDB_GetMonsterClientDataByIDs_Call) RunAndReturn(run func(ids []int64) ([]MonsterClientData, error)) *MockInternalDB_GetMonsterClientDataByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByID provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUserByID(userID int64) (*User, error) {
-	ret := _mock.Called(userID)
+// GetRevisionSummary provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetRevisionSummary(fileID string) (*RevisionSummary, error) {
+	ret := _mock.Called(fileID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByID")
+		panic("no return value specified for GetRevisionSummary")
 	}
 
-	var r0 *User
+	var r0 *RevisionSummary
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int64) (*User, error)); ok {
-		return returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(string) (*RevisionSummary, error)); ok {
+		return returnFunc(fileID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int64) *User); ok {
-		r0 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(string) *RevisionSummary); ok {
+		r0 = returnFunc(fileID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).(*RevisionSummary)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(fileID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
-type MockInternalDB_GetUserByID_Call struct {
+// MockInternalDB_GetRevisionSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisionSummary'
+type MockInternalDB_GetRevisionSummary_Call struct {
 	*mock.Call
 }
 
-// GetUserByID is a helper method to define mock.On call
-//   - userID int64
-func (_e *MockInternalDB_Expecter) GetUserByID(userID interface{}) *MockInternalDB_GetUserByID_Call {
-	return &MockInternalDB_GetUserByID_Call{Call: _e.mock.On("GetUserByID", userID)}
+// GetRevisionSummary is a helper method to define mock.On call
+//   - fileID string
+func (_e *MockInternalDB_Expecter) GetRevisionSummary(fileID interface{}) *MockInternalDB_GetRevisionSummary_Call {
+	return &MockInternalDB_GetRevisionSummary_Call{Call: _e.mock.On("GetRevisionSummary", fileID)}
 }
 
-func (_c *MockInternalDB_GetUserByID_Call) Run(run func(userID int64)) *MockInternalDB_GetUserByID_Call {
+func (_c *MockInternalDB_GetRevisionSummary_Call) Run(run func(fileID string)) *MockInternalDB_GetRevisionSummary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int64
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(int64)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
@@ -2425,193 +2687,193 @@ func (_c *MockInternalDB_GetUserByID_Call) Run(run func(userID int64)) *MockInte
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByID_Call) Return(user *User, err error) *MockInternalDB_GetUserByID_Call {
-	_c.Call.Return(user, err)
+func (_c *MockInternalDB_GetRevisionSummary_Call) Return(revisionSummary *RevisionSummary, err error) *MockInternalDB_GetRevisionSummary_Call {
+	_c.Call.Return(revisionSummary, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByID_Call) RunAndReturn(run func(userID int64) (*User, error)) *MockInternalDB_GetUserByID_Call {
+func (_c *MockInternalDB_GetRevisionSummary_Call) RunAndReturn(run func(fileID string) (*RevisionSummary, error)) *MockInternalDB_GetRevisionSummary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByIDIncludeDeleted provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUserByIDIncludeDeleted(userID int64) (*User, error) {
-	ret := _mock.Called(userID)
+// GetSeriesWithLabels provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetSeriesWithLabels() ([]SeriesWithLabels, error) {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByIDIncludeDeleted")
+		panic("no return value specified for GetSeriesWithLabels")
 	}
 
-	var r0 *User
+	var r0 []SeriesWithLabels
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int64) (*User, error)); ok {
-		return returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func() ([]SeriesWithLabels, error)); ok {
+		return returnFunc()
 	}
-	if returnFunc, ok := ret.Get(0).(func(int64) *User); ok {
-		r0 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func() []SeriesWithLabels); ok {
+		r0 = returnFunc()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]SeriesWithLabels)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetUserByIDIncludeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByIDIncludeDeleted'
-type MockInternalDB_GetUserByIDIncludeDeleted_Call struct {
+// MockInternalDB_GetSeriesWithLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSeriesWithLabels'
+type MockInternalDB_GetSeriesWithLabels_Call struct {
 	*mock.Call
 }
 
-// GetUserByIDIncludeDeleted is a helper method to define mock.On call
-//   - userID int64
-func (_e *MockInternalDB_Expecter) GetUserByIDIncludeDeleted(userID interface{}) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
-	return &MockInternalDB_GetUserByIDIncludeDeleted_Call{Call: _e.mock.On("GetUserByIDIncludeDeleted", userID)}
+// GetSeriesWithLabels is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetSeriesWithLabels() *MockInternalDB_GetSeriesWithLabels_Call {
+	return &MockInternalDB_GetSeriesWithLabels_Call{Call: _e.mock.On("GetSeriesWithLabels")}
 }
 
-func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) Run(run func(userID int64)) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+func (_c *MockInternalDB_GetSeriesWithLabels_Call) Run(run func()) *MockInternalDB_GetSeriesWithLabels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int64
-		if args[0] != nil {
-			arg0 = args[0].(int64)
-		}
-		run(
-			arg0,
-		)
+		run()
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) Return(user *User, err error) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
-	_c.Call.Return(user, err)
+func (_c *MockInternalDB_GetSeriesWithLabels_Call) Return(seriesWithLabelss []SeriesWithLabels, err error) *MockInternalDB_GetSeriesWithLabels_Call {
+	_c.Call.Return(seriesWithLabelss, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) RunAndReturn(run func(userID int64) (*User, error)) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+func (_c *MockInternalDB_GetSeriesWithLabels_Call) RunAndReturn(run func() ([]SeriesWithLabels, error)) *MockInternalDB_GetSeriesWithLabels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsers provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUsers() ([]User, error) {
-	ret := _mock.Called()
+// GetServerProcess provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetServerProcess(id int64) (*ServerProcess, error) {
+	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUsers")
+		panic("no return value specified for GetServerProcess")
 	}
 
-	var r0 []User
+	var r0 *ServerProcess
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]User, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int64) (*ServerProcess, error)); ok {
+		return returnFunc(id)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []User); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int64) *ServerProcess); ok {
+		r0 = returnFunc(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]User)
+			r0 = ret.Get(0).(*ServerProcess)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = returnFunc(id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockInternalDB_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
-type MockInternalDB_GetUsers_Call struct {
+// MockInternalDB_GetServerProcess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcess'
+type MockInternalDB_GetServerProcess_Call struct {
 	*mock.Call
 }
 
-// GetUsers is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) GetUsers() *MockInternalDB_GetUsers_Call {
-	return &MockInternalDB_GetUsers_Call{Call: _e.mock.On("GetUsers")}
+// GetServerProcess is a helper method to define mock.On call
+//   - id int64
+func (_e *MockInternalDB_Expecter) GetServerProcess(id interface{}) *MockInternalDB_GetServerProcess_Call {
+	return &MockInternalDB_GetServerProcess_Call{Call: _e.mock.On("GetServerProcess", id)}
 }
 
-func (_c *MockInternalDB_GetUsers_Call) Run(run func()) *MockInternalDB_GetUsers_Call {
+func (_c *MockInternalDB_GetServerProcess_Call) Run(run func(id int64)) *MockInternalDB_GetServerProcess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_GetUsers_Call) Return(users []User, err error) *MockInternalDB_GetUsers_Call {
-	_c.Call.Return(users, err)
+func (_c *MockInternalDB_GetServerProcess_Call) Return(serverProcess *ServerProcess, err error) *MockInternalDB_GetServerProcess_Call {
+	_c.Call.Return(serverProcess, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUsers_Call) RunAndReturn(run func() ([]User, error)) *MockInternalDB_GetUsers_Call {
+func (_c *MockInternalDB_GetServerProcess_Call) RunAndReturn(run func(id int64) (*ServerProcess, error)) *MockInternalDB_GetServerProcess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsersPaginated provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) GetUsersPaginated(page int, pageSize int, search string) ([]User, int64, error) {
-	ret := _mock.Called(page, pageSize, search)
+// GetServerProcessEventsPaginated provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetServerProcessEventsPaginated(processID *int64, page int, pageSize int) ([]ServerProcessEvent, int64, error) {
+	ret := _mock.Called(processID, page, pageSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUsersPaginated")
+		panic("no return value specified for GetServerProcessEventsPaginated")
 	}
 
-	var r0 []User
+	var r0 []ServerProcessEvent
 	var r1 int64
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, string) ([]User, int64, error)); ok {
-		return returnFunc(page, pageSize, search)
+	if returnFunc, ok := ret.Get(0).(func(*int64, int, int) ([]ServerProcessEvent, int64, error)); ok {
+		return returnFunc(processID, page, pageSize)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, string) []User); ok {
-		r0 = returnFunc(page, pageSize, search)
+	if returnFunc, ok := ret.Get(0).(func(*int64, int, int) []ServerProcessEvent); ok {
+		r0 = returnFunc(processID, page, pageSize)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]User)
+			r0 = ret.Get(0).([]ServerProcessEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = returnFunc(page, pageSize, search)
+	if returnFunc, ok := ret.Get(1).(func(*int64, int, int) int64); ok {
+		r1 = returnFunc(processID, page, pageSize)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
-	if returnFunc, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = returnFunc(page, pageSize, search)
+	if returnFunc, ok := ret.Get(2).(func(*int64, int, int) error); ok {
+		r2 = returnFunc(processID, page, pageSize)
 	} else {
 		r2 = ret.Error(2)
 	}
 	return r0, r1, r2
 }
 
-// MockInternalDB_GetUsersPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersPaginated'
-type MockInternalDB_GetUsersPaginated_Call struct {
+// MockInternalDB_GetServerProcessEventsPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcessEventsPaginated'
+type MockInternalDB_GetServerProcessEventsPaginated_Call struct {
 	*mock.Call
 }
 
-// GetUsersPaginated is a helper method to define mock.On call
+// GetServerProcessEventsPaginated is a helper method to define mock.On call
+//   - processID *int64
 //   - page int
 //   - pageSize int
-//   - search string
-func (_e *MockInternalDB_Expecter) GetUsersPaginated(page interface{}, pageSize interface{}, search interface{}) *MockInternalDB_GetUsersPaginated_Call {
-	return &MockInternalDB_GetUsersPaginated_Call{Call: _e.mock.On("GetUsersPaginated", page, pageSize, search)}
+func (_e *MockInternalDB_Expecter) GetServerProcessEventsPaginated(processID interface{}, page interface{}, pageSize interface{}) *MockInternalDB_GetServerProcessEventsPaginated_Call {
+	return &MockInternalDB_GetServerProcessEventsPaginated_Call{Call: _e.mock.On("GetServerProcessEventsPaginated", processID, page, pageSize)}
 }
 
-func (_c *MockInternalDB_GetUsersPaginated_Call) Run(run func(page int, pageSize int, search string)) *MockInternalDB_GetUsersPaginated_Call {
+func (_c *MockInternalDB_GetServerProcessEventsPaginated_Call) Run(run func(processID *int64, page int, pageSize int)) *MockInternalDB_GetServerProcessEventsPaginated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 *int64
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(*int64)
 		}
 		var arg1 int
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
@@ -2622,507 +2884,1676 @@ func (_c *MockInternalDB_GetUsersPaginated_Call) Run(run func(page int, pageSize
 	return _c
 }
 
-func (_c *MockInternalDB_GetUsersPaginated_Call) Return(users []User, n int64, err error) *MockInternalDB_GetUsersPaginated_Call {
-	_c.Call.Return(users, n, err)
+func (_c *MockInternalDB_GetServerProcessEventsPaginated_Call) Return(serverProcessEvents []ServerProcessEvent, n int64, err error) *MockInternalDB_GetServerProcessEventsPaginated_Call {
+	_c.Call.Return(serverProcessEvents, n, err)
 	return _c
 }
 
-func (_c *MockInternalDB_GetUsersPaginated_Call) RunAndReturn(run func(page int, pageSize int, search string) ([]User, int64, error)) *MockInternalDB_GetUsersPaginated_Call {
+func (_c *MockInternalDB_GetServerProcessEventsPaginated_Call) RunAndReturn(run func(processID *int64, page int, pageSize int) ([]ServerProcessEvent, int64, error)) *MockInternalDB_GetServerProcessEventsPaginated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertMetric provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) InsertMetric(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string) error {
-	ret := _mock.Called(metricName, metricType, labels, value, timestamp, unit, description)
+// GetServerProcesses provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetServerProcesses() ([]ServerProcess, error) {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertMetric")
+		panic("no return value specified for GetServerProcesses")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, MetricType, map[string]string, float64, *int64, *string, *string) error); ok {
-		r0 = returnFunc(metricName, metricType, labels, value, timestamp, unit, description)
+	var r0 []ServerProcess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]ServerProcess, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []ServerProcess); ok {
+		r0 = returnFunc()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ServerProcess)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockInternalDB_InsertMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMetric'
-type MockInternalDB_InsertMetric_Call struct {
+// MockInternalDB_GetServerProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcesses'
+type MockInternalDB_GetServerProcesses_Call struct {
 	*mock.Call
 }
 
-// InsertMetric is a helper method to define mock.On call
-//   - metricName string
-//   - metricType MetricType
-//   - labels map[string]string
-//   - value float64
-//   - timestamp *int64
-//   - unit *string
-//   - description *string
-func (_e *MockInternalDB_Expecter) InsertMetric(metricName interface{}, metricType interface{}, labels interface{}, value interface{}, timestamp interface{}, unit interface{}, description interface{}) *MockInternalDB_InsertMetric_Call {
-	return &MockInternalDB_InsertMetric_Call{Call: _e.mock.On("InsertMetric", metricName, metricType, labels, value, timestamp, unit, description)}
+// GetServerProcesses is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetServerProcesses() *MockInternalDB_GetServerProcesses_Call {
+	return &MockInternalDB_GetServerProcesses_Call{Call: _e.mock.On("GetServerProcesses")}
 }
 
-func (_c *MockInternalDB_InsertMetric_Call) Run(run func(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string)) *MockInternalDB_InsertMetric_Call {
+func (_c *MockInternalDB_GetServerProcesses_Call) Run(run func()) *MockInternalDB_GetServerProcesses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
-		if args[0] != nil {
-			arg0 = args[0].(string)
-		}
-		var arg1 MetricType
-		if args[1] != nil {
-			arg1 = args[1].(MetricType)
-		}
-		var arg2 map[string]string
-		if args[2] != nil {
-			arg2 = args[2].(map[string]string)
-		}
-		var arg3 float64
-		if args[3] != nil {
-			arg3 = args[3].(float64)
-		}
-		var arg4 *int64
-		if args[4] != nil {
-			arg4 = args[4].(*int64)
-		}
-		var arg5 *string
-		if args[5] != nil {
-			arg5 = args[5].(*string)
-		}
-		var arg6 *string
-		if args[6] != nil {
-			arg6 = args[6].(*string)
-		}
-		run(
-			arg0,
-			arg1,
-			arg2,
-			arg3,
-			arg4,
-			arg5,
-			arg6,
-		)
+		run()
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_InsertMetric_Call) Return(err error) *MockInternalDB_InsertMetric_Call {
-	_c.Call.Return(err)
-	return _c
+func (_c *MockInternalDB_GetServerProcesses_Call) Return(serverProcesss []ServerProcess, err error) *MockInternalDB_GetServerProcesses_Call {
+	_c.Call.Return(serverProcesss, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetServerProcesses_Call) RunAndReturn(run func() ([]ServerProcess, error)) *MockInternalDB_GetServerProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServerProcessesByPath provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetServerProcessesByPath(path string) ([]ServerProcess, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServerProcessesByPath")
+	}
+
+	var r0 []ServerProcess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]ServerProcess, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []ServerProcess); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ServerProcess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetServerProcessesByPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerProcessesByPath'
+type MockInternalDB_GetServerProcessesByPath_Call struct {
+	*mock.Call
+}
+
+// GetServerProcessesByPath is a helper method to define mock.On call
+//   - path string
+func (_e *MockInternalDB_Expecter) GetServerProcessesByPath(path interface{}) *MockInternalDB_GetServerProcessesByPath_Call {
+	return &MockInternalDB_GetServerProcessesByPath_Call{Call: _e.mock.On("GetServerProcessesByPath", path)}
+}
+
+func (_c *MockInternalDB_GetServerProcessesByPath_Call) Run(run func(path string)) *MockInternalDB_GetServerProcessesByPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetServerProcessesByPath_Call) Return(serverProcesss []ServerProcess, err error) *MockInternalDB_GetServerProcessesByPath_Call {
+	_c.Call.Return(serverProcesss, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetServerProcessesByPath_Call) RunAndReturn(run func(path string) ([]ServerProcess, error)) *MockInternalDB_GetServerProcessesByPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSession provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetSession(sessionID string) (*Session, error) {
+	ret := _mock.Called(sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSession")
+	}
+
+	var r0 *Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*Session, error)); ok {
+		return returnFunc(sessionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *Session); ok {
+		r0 = returnFunc(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
+type MockInternalDB_GetSession_Call struct {
+	*mock.Call
+}
+
+// GetSession is a helper method to define mock.On call
+//   - sessionID string
+func (_e *MockInternalDB_Expecter) GetSession(sessionID interface{}) *MockInternalDB_GetSession_Call {
+	return &MockInternalDB_GetSession_Call{Call: _e.mock.On("GetSession", sessionID)}
+}
+
+func (_c *MockInternalDB_GetSession_Call) Run(run func(sessionID string)) *MockInternalDB_GetSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetSession_Call) Return(session *Session, err error) *MockInternalDB_GetSession_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetSession_Call) RunAndReturn(run func(sessionID string) (*Session, error)) *MockInternalDB_GetSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSetting provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetSetting(key string) (*Settings, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSetting")
+	}
+
+	var r0 *Settings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*Settings, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *Settings); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Settings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSetting'
+type MockInternalDB_GetSetting_Call struct {
+	*mock.Call
+}
+
+// GetSetting is a helper method to define mock.On call
+//   - key string
+func (_e *MockInternalDB_Expecter) GetSetting(key interface{}) *MockInternalDB_GetSetting_Call {
+	return &MockInternalDB_GetSetting_Call{Call: _e.mock.On("GetSetting", key)}
+}
+
+func (_c *MockInternalDB_GetSetting_Call) Run(run func(key string)) *MockInternalDB_GetSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetSetting_Call) Return(settings *Settings, err error) *MockInternalDB_GetSetting_Call {
+	_c.Call.Return(settings, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetSetting_Call) RunAndReturn(run func(key string) (*Settings, error)) *MockInternalDB_GetSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSettings provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetSettings() ([]Settings, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 []Settings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]Settings, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []Settings); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Settings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type MockInternalDB_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetSettings() *MockInternalDB_GetSettings_Call {
+	return &MockInternalDB_GetSettings_Call{Call: _e.mock.On("GetSettings")}
+}
+
+func (_c *MockInternalDB_GetSettings_Call) Run(run func()) *MockInternalDB_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetSettings_Call) Return(settingss []Settings, err error) *MockInternalDB_GetSettings_Call {
+	_c.Call.Return(settingss, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetSettings_Call) RunAndReturn(run func() ([]Settings, error)) *MockInternalDB_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStoredFileRevisions provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetStoredFileRevisions() ([]FileRevision, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStoredFileRevisions")
+	}
+
+	var r0 []FileRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]FileRevision, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []FileRevision); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]FileRevision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetStoredFileRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStoredFileRevisions'
+type MockInternalDB_GetStoredFileRevisions_Call struct {
+	*mock.Call
+}
+
+// GetStoredFileRevisions is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetStoredFileRevisions() *MockInternalDB_GetStoredFileRevisions_Call {
+	return &MockInternalDB_GetStoredFileRevisions_Call{Call: _e.mock.On("GetStoredFileRevisions")}
+}
+
+func (_c *MockInternalDB_GetStoredFileRevisions_Call) Run(run func()) *MockInternalDB_GetStoredFileRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetStoredFileRevisions_Call) Return(fileRevisions []FileRevision, err error) *MockInternalDB_GetStoredFileRevisions_Call {
+	_c.Call.Return(fileRevisions, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetStoredFileRevisions_Call) RunAndReturn(run func() ([]FileRevision, error)) *MockInternalDB_GetStoredFileRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUserByEmail(email string) (*User, error) {
+	ret := _mock.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*User, error)); ok {
+		return returnFunc(email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *User); ok {
+		r0 = returnFunc(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockInternalDB_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - email string
+func (_e *MockInternalDB_Expecter) GetUserByEmail(email interface{}) *MockInternalDB_GetUserByEmail_Call {
+	return &MockInternalDB_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", email)}
+}
+
+func (_c *MockInternalDB_GetUserByEmail_Call) Run(run func(email string)) *MockInternalDB_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByEmail_Call) Return(user *User, err error) *MockInternalDB_GetUserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByEmail_Call) RunAndReturn(run func(email string) (*User, error)) *MockInternalDB_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmailIncludeDeleted provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUserByEmailIncludeDeleted(email string) (*User, error) {
+	ret := _mock.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmailIncludeDeleted")
+	}
+
+	var r0 *User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*User, error)); ok {
+		return returnFunc(email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *User); ok {
+		r0 = returnFunc(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetUserByEmailIncludeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmailIncludeDeleted'
+type MockInternalDB_GetUserByEmailIncludeDeleted_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmailIncludeDeleted is a helper method to define mock.On call
+//   - email string
+func (_e *MockInternalDB_Expecter) GetUserByEmailIncludeDeleted(email interface{}) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+	return &MockInternalDB_GetUserByEmailIncludeDeleted_Call{Call: _e.mock.On("GetUserByEmailIncludeDeleted", email)}
+}
+
+func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) Run(run func(email string)) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) Return(user *User, err error) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByEmailIncludeDeleted_Call) RunAndReturn(run func(email string) (*User, error)) *MockInternalDB_GetUserByEmailIncludeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUserByID(userID int64) (*User, error) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int64) (*User, error)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int64) *User); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = returnFunc(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockInternalDB_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - userID int64
+func (_e *MockInternalDB_Expecter) GetUserByID(userID interface{}) *MockInternalDB_GetUserByID_Call {
+	return &MockInternalDB_GetUserByID_Call{Call: _e.mock.On("GetUserByID", userID)}
+}
+
+func (_c *MockInternalDB_GetUserByID_Call) Run(run func(userID int64)) *MockInternalDB_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByID_Call) Return(user *User, err error) *MockInternalDB_GetUserByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByID_Call) RunAndReturn(run func(userID int64) (*User, error)) *MockInternalDB_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByIDIncludeDeleted provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUserByIDIncludeDeleted(userID int64) (*User, error) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByIDIncludeDeleted")
+	}
+
+	var r0 *User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int64) (*User, error)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int64) *User); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = returnFunc(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetUserByIDIncludeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByIDIncludeDeleted'
+type MockInternalDB_GetUserByIDIncludeDeleted_Call struct {
+	*mock.Call
+}
+
+// GetUserByIDIncludeDeleted is a helper method to define mock.On call
+//   - userID int64
+func (_e *MockInternalDB_Expecter) GetUserByIDIncludeDeleted(userID interface{}) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+	return &MockInternalDB_GetUserByIDIncludeDeleted_Call{Call: _e.mock.On("GetUserByIDIncludeDeleted", userID)}
+}
+
+func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) Run(run func(userID int64)) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) Return(user *User, err error) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUserByIDIncludeDeleted_Call) RunAndReturn(run func(userID int64) (*User, error)) *MockInternalDB_GetUserByIDIncludeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUsers() ([]User, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]User, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []User); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInternalDB_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockInternalDB_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) GetUsers() *MockInternalDB_GetUsers_Call {
+	return &MockInternalDB_GetUsers_Call{Call: _e.mock.On("GetUsers")}
+}
+
+func (_c *MockInternalDB_GetUsers_Call) Run(run func()) *MockInternalDB_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUsers_Call) Return(users []User, err error) *MockInternalDB_GetUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUsers_Call) RunAndReturn(run func() ([]User, error)) *MockInternalDB_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersPaginated provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) GetUsersPaginated(page int, pageSize int, search string) ([]User, int64, error) {
+	ret := _mock.Called(page, pageSize, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersPaginated")
+	}
+
+	var r0 []User
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, string) ([]User, int64, error)); ok {
+		return returnFunc(page, pageSize, search)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, string) []User); ok {
+		r0 = returnFunc(page, pageSize, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, string) int64); ok {
+		r1 = returnFunc(page, pageSize, search)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(int, int, string) error); ok {
+		r2 = returnFunc(page, pageSize, search)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockInternalDB_GetUsersPaginated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersPaginated'
+type MockInternalDB_GetUsersPaginated_Call struct {
+	*mock.Call
+}
+
+// GetUsersPaginated is a helper method to define mock.On call
+//   - page int
+//   - pageSize int
+//   - search string
+func (_e *MockInternalDB_Expecter) GetUsersPaginated(page interface{}, pageSize interface{}, search interface{}) *MockInternalDB_GetUsersPaginated_Call {
+	return &MockInternalDB_GetUsersPaginated_Call{Call: _e.mock.On("GetUsersPaginated", page, pageSize, search)}
+}
+
+func (_c *MockInternalDB_GetUsersPaginated_Call) Run(run func(page int, pageSize int, search string)) *MockInternalDB_GetUsersPaginated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_GetUsersPaginated_Call) Return(users []User, n int64, err error) *MockInternalDB_GetUsersPaginated_Call {
+	_c.Call.Return(users, n, err)
+	return _c
+}
+
+func (_c *MockInternalDB_GetUsersPaginated_Call) RunAndReturn(run func(page int, pageSize int, search string) ([]User, int64, error)) *MockInternalDB_GetUsersPaginated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMetric provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) InsertMetric(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string) error {
+	ret := _mock.Called(metricName, metricType, labels, value, timestamp, unit, description)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMetric")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, MetricType, map[string]string, float64, *int64, *string, *string) error); ok {
+		r0 = returnFunc(metricName, metricType, labels, value, timestamp, unit, description)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_InsertMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMetric'
+type MockInternalDB_InsertMetric_Call struct {
+	*mock.Call
+}
+
+// InsertMetric is a helper method to define mock.On call
+//   - metricName string
+//   - metricType MetricType
+//   - labels map[string]string
+//   - value float64
+//   - timestamp *int64
+//   - unit *string
+//   - description *string
+func (_e *MockInternalDB_Expecter) InsertMetric(metricName interface{}, metricType interface{}, labels interface{}, value interface{}, timestamp interface{}, unit interface{}, description interface{}) *MockInternalDB_InsertMetric_Call {
+	return &MockInternalDB_InsertMetric_Call{Call: _e.mock.On("InsertMetric", metricName, metricType, labels, value, timestamp, unit, description)}
+}
+
+func (_c *MockInternalDB_InsertMetric_Call) Run(run func(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string)) *MockInternalDB_InsertMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 MetricType
+		if args[1] != nil {
+			arg1 = args[1].(MetricType)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 *int64
+		if args[4] != nil {
+			arg4 = args[4].(*int64)
+		}
+		var arg5 *string
+		if args[5] != nil {
+			arg5 = args[5].(*string)
+		}
+		var arg6 *string
+		if args[6] != nil {
+			arg6 = args[6].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_InsertMetric_Call) Return(err error) *MockInternalDB_InsertMetric_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_InsertMetric_Call) RunAndReturn(run func(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string) error) *MockInternalDB_InsertMetric_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMetricSample provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) InsertMetricSample(seriesID int64, value float64, timestamp *int64) error {
+	ret := _mock.Called(seriesID, value, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMetricSample")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int64, float64, *int64) error); ok {
+		r0 = returnFunc(seriesID, value, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_InsertMetricSample_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMetricSample'
+type MockInternalDB_InsertMetricSample_Call struct {
+	*mock.Call
+}
+
+// InsertMetricSample is a helper method to define mock.On call
+//   - seriesID int64
+//   - value float64
+//   - timestamp *int64
+func (_e *MockInternalDB_Expecter) InsertMetricSample(seriesID interface{}, value interface{}, timestamp interface{}) *MockInternalDB_InsertMetricSample_Call {
+	return &MockInternalDB_InsertMetricSample_Call{Call: _e.mock.On("InsertMetricSample", seriesID, value, timestamp)}
+}
+
+func (_c *MockInternalDB_InsertMetricSample_Call) Run(run func(seriesID int64, value float64, timestamp *int64)) *MockInternalDB_InsertMetricSample_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_InsertMetricSample_Call) Return(err error) *MockInternalDB_InsertMetricSample_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_InsertMetricSample_Call) RunAndReturn(run func(seriesID int64, value float64, timestamp *int64) error) *MockInternalDB_InsertMetricSample_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrateDown provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) MigrateDown() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateDown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_MigrateDown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateDown'
+type MockInternalDB_MigrateDown_Call struct {
+	*mock.Call
+}
+
+// MigrateDown is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) MigrateDown() *MockInternalDB_MigrateDown_Call {
+	return &MockInternalDB_MigrateDown_Call{Call: _e.mock.On("MigrateDown")}
+}
+
+func (_c *MockInternalDB_MigrateDown_Call) Run(run func()) *MockInternalDB_MigrateDown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_MigrateDown_Call) Return(err error) *MockInternalDB_MigrateDown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_MigrateDown_Call) RunAndReturn(run func() error) *MockInternalDB_MigrateDown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MigrateUp provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) MigrateUp() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateUp")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_MigrateUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateUp'
+type MockInternalDB_MigrateUp_Call struct {
+	*mock.Call
+}
+
+// MigrateUp is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) MigrateUp() *MockInternalDB_MigrateUp_Call {
+	return &MockInternalDB_MigrateUp_Call{Call: _e.mock.On("MigrateUp")}
+}
+
+func (_c *MockInternalDB_MigrateUp_Call) Run(run func()) *MockInternalDB_MigrateUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_MigrateUp_Call) Return(err error) *MockInternalDB_MigrateUp_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_MigrateUp_Call) RunAndReturn(run func() error) *MockInternalDB_MigrateUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneFileRevisions provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) PruneFileRevisions(revisionIDs []int64) error {
+	ret := _mock.Called(revisionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneFileRevisions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]int64) error); ok {
+		r0 = returnFunc(revisionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-func (_c *MockInternalDB_InsertMetric_Call) RunAndReturn(run func(metricName string, metricType MetricType, labels map[string]string, value float64, timestamp *int64, unit *string, description *string) error) *MockInternalDB_InsertMetric_Call {
+// MockInternalDB_PruneFileRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneFileRevisions'
+type MockInternalDB_PruneFileRevisions_Call struct {
+	*mock.Call
+}
+
+// PruneFileRevisions is a helper method to define mock.On call
+//   - revisionIDs []int64
+func (_e *MockInternalDB_Expecter) PruneFileRevisions(revisionIDs interface{}) *MockInternalDB_PruneFileRevisions_Call {
+	return &MockInternalDB_PruneFileRevisions_Call{Call: _e.mock.On("PruneFileRevisions", revisionIDs)}
+}
+
+func (_c *MockInternalDB_PruneFileRevisions_Call) Run(run func(revisionIDs []int64)) *MockInternalDB_PruneFileRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []int64
+		if args[0] != nil {
+			arg0 = args[0].([]int64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_PruneFileRevisions_Call) Return(err error) *MockInternalDB_PruneFileRevisions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_PruneFileRevisions_Call) RunAndReturn(run func(revisionIDs []int64) error) *MockInternalDB_PruneFileRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertMetricSample provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) InsertMetricSample(seriesID int64, value float64, timestamp *int64) error {
-	ret := _mock.Called(seriesID, value, timestamp)
+// ReorderServerProcesses provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) ReorderServerProcesses(updates []ReorderUpdate) error {
+	ret := _mock.Called(updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertMetricSample")
+		panic("no return value specified for ReorderServerProcesses")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int64, float64, *int64) error); ok {
-		r0 = returnFunc(seriesID, value, timestamp)
+	if returnFunc, ok := ret.Get(0).(func([]ReorderUpdate) error); ok {
+		r0 = returnFunc(updates)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_InsertMetricSample_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMetricSample'
-type MockInternalDB_InsertMetricSample_Call struct {
+// MockInternalDB_ReorderServerProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderServerProcesses'
+type MockInternalDB_ReorderServerProcesses_Call struct {
 	*mock.Call
 }
 
-// InsertMetricSample is a helper method to define mock.On call
-//   - seriesID int64
-//   - value float64
-//   - timestamp *int64
-func (_e *MockInternalDB_Expecter) InsertMetricSample(seriesID interface{}, value interface{}, timestamp interface{}) *MockInternalDB_InsertMetricSample_Call {
-	return &MockInternalDB_InsertMetricSample_Call{Call: _e.mock.On("InsertMetricSample", seriesID, value, timestamp)}
+// ReorderServerProcesses is a helper method to define mock.On call
+//   - updates []ReorderUpdate
+func (_e *MockInternalDB_Expecter) ReorderServerProcesses(updates interface{}) *MockInternalDB_ReorderServerProcesses_Call {
+	return &MockInternalDB_ReorderServerProcesses_Call{Call: _e.mock.On("ReorderServerProcesses", updates)}
 }
 
-func (_c *MockInternalDB_InsertMetricSample_Call) Run(run func(seriesID int64, value float64, timestamp *int64)) *MockInternalDB_InsertMetricSample_Call {
+func (_c *MockInternalDB_ReorderServerProcesses_Call) Run(run func(updates []ReorderUpdate)) *MockInternalDB_ReorderServerProcesses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []ReorderUpdate
+		if args[0] != nil {
+			arg0 = args[0].([]ReorderUpdate)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_ReorderServerProcesses_Call) Return(err error) *MockInternalDB_ReorderServerProcesses_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_ReorderServerProcesses_Call) RunAndReturn(run func(updates []ReorderUpdate) error) *MockInternalDB_ReorderServerProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDefaultSettings provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) SetDefaultSettings() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefaultSettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_SetDefaultSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultSettings'
+type MockInternalDB_SetDefaultSettings_Call struct {
+	*mock.Call
+}
+
+// SetDefaultSettings is a helper method to define mock.On call
+func (_e *MockInternalDB_Expecter) SetDefaultSettings() *MockInternalDB_SetDefaultSettings_Call {
+	return &MockInternalDB_SetDefaultSettings_Call{Call: _e.mock.On("SetDefaultSettings")}
+}
+
+func (_c *MockInternalDB_SetDefaultSettings_Call) Run(run func()) *MockInternalDB_SetDefaultSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_SetDefaultSettings_Call) Return(err error) *MockInternalDB_SetDefaultSettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_SetDefaultSettings_Call) RunAndReturn(run func() error) *MockInternalDB_SetDefaultSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFileRevisionPath provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) SetFileRevisionPath(revisionID int64, revisionPath string) error {
+	ret := _mock.Called(revisionID, revisionPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFileRevisionPath")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = returnFunc(revisionID, revisionPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_SetFileRevisionPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFileRevisionPath'
+type MockInternalDB_SetFileRevisionPath_Call struct {
+	*mock.Call
+}
+
+// SetFileRevisionPath is a helper method to define mock.On call
+//   - revisionID int64
+//   - revisionPath string
+func (_e *MockInternalDB_Expecter) SetFileRevisionPath(revisionID interface{}, revisionPath interface{}) *MockInternalDB_SetFileRevisionPath_Call {
+	return &MockInternalDB_SetFileRevisionPath_Call{Call: _e.mock.On("SetFileRevisionPath", revisionID, revisionPath)}
+}
+
+func (_c *MockInternalDB_SetFileRevisionPath_Call) Run(run func(revisionID int64, revisionPath string)) *MockInternalDB_SetFileRevisionPath_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int64
 		if args[0] != nil {
 			arg0 = args[0].(int64)
 		}
-		var arg1 float64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(float64)
-		}
-		var arg2 *int64
-		if args[2] != nil {
-			arg2 = args[2].(*int64)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_InsertMetricSample_Call) Return(err error) *MockInternalDB_InsertMetricSample_Call {
+func (_c *MockInternalDB_SetFileRevisionPath_Call) Return(err error) *MockInternalDB_SetFileRevisionPath_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_InsertMetricSample_Call) RunAndReturn(run func(seriesID int64, value float64, timestamp *int64) error) *MockInternalDB_InsertMetricSample_Call {
+func (_c *MockInternalDB_SetFileRevisionPath_Call) RunAndReturn(run func(revisionID int64, revisionPath string) error) *MockInternalDB_SetFileRevisionPath_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MigrateDown provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) MigrateDown() error {
-	ret := _mock.Called()
+// SetFileRevisionStatus provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) SetFileRevisionStatus(revisionID int64, status string) error {
+	ret := _mock.Called(revisionID, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MigrateDown")
+		panic("no return value specified for SetFileRevisionStatus")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = returnFunc(revisionID, status)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_MigrateDown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateDown'
-type MockInternalDB_MigrateDown_Call struct {
+// MockInternalDB_SetFileRevisionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFileRevisionStatus'
+type MockInternalDB_SetFileRevisionStatus_Call struct {
 	*mock.Call
 }
 
-// MigrateDown is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) MigrateDown() *MockInternalDB_MigrateDown_Call {
-	return &MockInternalDB_MigrateDown_Call{Call: _e.mock.On("MigrateDown")}
+// SetFileRevisionStatus is a helper method to define mock.On call
+//   - revisionID int64
+//   - status string
+func (_e *MockInternalDB_Expecter) SetFileRevisionStatus(revisionID interface{}, status interface{}) *MockInternalDB_SetFileRevisionStatus_Call {
+	return &MockInternalDB_SetFileRevisionStatus_Call{Call: _e.mock.On("SetFileRevisionStatus", revisionID, status)}
 }
 
-func (_c *MockInternalDB_MigrateDown_Call) Run(run func()) *MockInternalDB_MigrateDown_Call {
+func (_c *MockInternalDB_SetFileRevisionStatus_Call) Run(run func(revisionID int64, status string)) *MockInternalDB_SetFileRevisionStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_MigrateDown_Call) Return(err error) *MockInternalDB_MigrateDown_Call {
+func (_c *MockInternalDB_SetFileRevisionStatus_Call) Return(err error) *MockInternalDB_SetFileRevisionStatus_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_MigrateDown_Call) RunAndReturn(run func() error) *MockInternalDB_MigrateDown_Call {
+func (_c *MockInternalDB_SetFileRevisionStatus_Call) RunAndReturn(run func(revisionID int64, status string) error) *MockInternalDB_SetFileRevisionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MigrateUp provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) MigrateUp() error {
-	ret := _mock.Called()
+// SetSetting provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) SetSetting(key string, value string, userID *int64) error {
+	ret := _mock.Called(key, value, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MigrateUp")
+		panic("no return value specified for SetSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(string, string, *int64) error); ok {
+		r0 = returnFunc(key, value, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_MigrateUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrateUp'
-type MockInternalDB_MigrateUp_Call struct {
+// MockInternalDB_SetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSetting'
+type MockInternalDB_SetSetting_Call struct {
 	*mock.Call
 }
 
-// MigrateUp is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) MigrateUp() *MockInternalDB_MigrateUp_Call {
-	return &MockInternalDB_MigrateUp_Call{Call: _e.mock.On("MigrateUp")}
+// SetSetting is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - userID *int64
+func (_e *MockInternalDB_Expecter) SetSetting(key interface{}, value interface{}, userID interface{}) *MockInternalDB_SetSetting_Call {
+	return &MockInternalDB_SetSetting_Call{Call: _e.mock.On("SetSetting", key, value, userID)}
+}
+
+func (_c *MockInternalDB_SetSetting_Call) Run(run func(key string, value string, userID *int64)) *MockInternalDB_SetSetting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_SetSetting_Call) Return(err error) *MockInternalDB_SetSetting_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_SetSetting_Call) RunAndReturn(run func(key string, value string, userID *int64) error) *MockInternalDB_SetSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSettingIfNotExists provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) SetSettingIfNotExists(key string, value string, userID *int64) error {
+	ret := _mock.Called(key, value, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSettingIfNotExists")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, *int64) error); ok {
+		r0 = returnFunc(key, value, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_SetSettingIfNotExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSettingIfNotExists'
+type MockInternalDB_SetSettingIfNotExists_Call struct {
+	*mock.Call
+}
+
+// SetSettingIfNotExists is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - userID *int64
+func (_e *MockInternalDB_Expecter) SetSettingIfNotExists(key interface{}, value interface{}, userID interface{}) *MockInternalDB_SetSettingIfNotExists_Call {
+	return &MockInternalDB_SetSettingIfNotExists_Call{Call: _e.mock.On("SetSettingIfNotExists", key, value, userID)}
 }
 
-func (_c *MockInternalDB_MigrateUp_Call) Run(run func()) *MockInternalDB_MigrateUp_Call {
+func (_c *MockInternalDB_SetSettingIfNotExists_Call) Run(run func(key string, value string, userID *int64)) *MockInternalDB_SetSettingIfNotExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_MigrateUp_Call) Return(err error) *MockInternalDB_MigrateUp_Call {
+func (_c *MockInternalDB_SetSettingIfNotExists_Call) Return(err error) *MockInternalDB_SetSettingIfNotExists_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_MigrateUp_Call) RunAndReturn(run func() error) *MockInternalDB_MigrateUp_Call {
+func (_c *MockInternalDB_SetSettingIfNotExists_Call) RunAndReturn(run func(key string, value string, userID *int64) error) *MockInternalDB_SetSettingIfNotExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReorderServerProcesses provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) ReorderServerProcesses(updates []ReorderUpdate) error {
-	ret := _mock.Called(updates)
+// UpdateChangeSetStatus provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateChangeSetStatus(id string, status string, updatedBy int64) error {
+	ret := _mock.Called(id, status, updatedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReorderServerProcesses")
+		panic("no return value specified for UpdateChangeSetStatus")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func([]ReorderUpdate) error); ok {
-		r0 = returnFunc(updates)
+	if returnFunc, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = returnFunc(id, status, updatedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_ReorderServerProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderServerProcesses'
-type MockInternalDB_ReorderServerProcesses_Call struct {
+// MockInternalDB_UpdateChangeSetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChangeSetStatus'
+type MockInternalDB_UpdateChangeSetStatus_Call struct {
 	*mock.Call
 }
 
-// ReorderServerProcesses is a helper method to define mock.On call
-//   - updates []ReorderUpdate
-func (_e *MockInternalDB_Expecter) ReorderServerProcesses(updates interface{}) *MockInternalDB_ReorderServerProcesses_Call {
-	return &MockInternalDB_ReorderServerProcesses_Call{Call: _e.mock.On("ReorderServerProcesses", updates)}
+// UpdateChangeSetStatus is a helper method to define mock.On call
+//   - id string
+//   - status string
+//   - updatedBy int64
+func (_e *MockInternalDB_Expecter) UpdateChangeSetStatus(id interface{}, status interface{}, updatedBy interface{}) *MockInternalDB_UpdateChangeSetStatus_Call {
+	return &MockInternalDB_UpdateChangeSetStatus_Call{Call: _e.mock.On("UpdateChangeSetStatus", id, status, updatedBy)}
 }
 
-func (_c *MockInternalDB_ReorderServerProcesses_Call) Run(run func(updates []ReorderUpdate)) *MockInternalDB_ReorderServerProcesses_Call {
+func (_c *MockInternalDB_UpdateChangeSetStatus_Call) Run(run func(id string, status string, updatedBy int64)) *MockInternalDB_UpdateChangeSetStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 []ReorderUpdate
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].([]ReorderUpdate)
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_ReorderServerProcesses_Call) Return(err error) *MockInternalDB_ReorderServerProcesses_Call {
+func (_c *MockInternalDB_UpdateChangeSetStatus_Call) Return(err error) *MockInternalDB_UpdateChangeSetStatus_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_ReorderServerProcesses_Call) RunAndReturn(run func(updates []ReorderUpdate) error) *MockInternalDB_ReorderServerProcesses_Call {
+func (_c *MockInternalDB_UpdateChangeSetStatus_Call) RunAndReturn(run func(id string, status string, updatedBy int64) error) *MockInternalDB_UpdateChangeSetStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetDefaultSettings provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) SetDefaultSettings() error {
-	ret := _mock.Called()
+// UpdateFileRevisionChangeSet provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateFileRevisionChangeSet(tx *goqu.TxDatabase, revisionID int64, changeSetID string, updatedBy *int64) error {
+	ret := _mock.Called(tx, revisionID, changeSetID, updatedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetDefaultSettings")
+		panic("no return value specified for UpdateFileRevisionChangeSet")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, *int64) error); ok {
+		r0 = returnFunc(tx, revisionID, changeSetID, updatedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_SetDefaultSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultSettings'
-type MockInternalDB_SetDefaultSettings_Call struct {
+// MockInternalDB_UpdateFileRevisionChangeSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileRevisionChangeSet'
+type MockInternalDB_UpdateFileRevisionChangeSet_Call struct {
 	*mock.Call
 }
 
-// SetDefaultSettings is a helper method to define mock.On call
-func (_e *MockInternalDB_Expecter) SetDefaultSettings() *MockInternalDB_SetDefaultSettings_Call {
-	return &MockInternalDB_SetDefaultSettings_Call{Call: _e.mock.On("SetDefaultSettings")}
+// UpdateFileRevisionChangeSet is a helper method to define mock.On call
+//   - tx *goqu.TxDatabase
+//   - revisionID int64
+//   - changeSetID string
+//   - updatedBy *int64
+func (_e *MockInternalDB_Expecter) UpdateFileRevisionChangeSet(tx interface{}, revisionID interface{}, changeSetID interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionChangeSet_Call {
+	return &MockInternalDB_UpdateFileRevisionChangeSet_Call{Call: _e.mock.On("UpdateFileRevisionChangeSet", tx, revisionID, changeSetID, updatedBy)}
 }
 
-func (_c *MockInternalDB_SetDefaultSettings_Call) Run(run func()) *MockInternalDB_SetDefaultSettings_Call {
+func (_c *MockInternalDB_UpdateFileRevisionChangeSet_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, changeSetID string, updatedBy *int64)) *MockInternalDB_UpdateFileRevisionChangeSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 *goqu.TxDatabase
+		if args[0] != nil {
+			arg0 = args[0].(*goqu.TxDatabase)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_SetDefaultSettings_Call) Return(err error) *MockInternalDB_SetDefaultSettings_Call {
+func (_c *MockInternalDB_UpdateFileRevisionChangeSet_Call) Return(err error) *MockInternalDB_UpdateFileRevisionChangeSet_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_SetDefaultSettings_Call) RunAndReturn(run func() error) *MockInternalDB_SetDefaultSettings_Call {
+func (_c *MockInternalDB_UpdateFileRevisionChangeSet_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, changeSetID string, updatedBy *int64) error) *MockInternalDB_UpdateFileRevisionChangeSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetSetting provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) SetSetting(key string, value string, userID *int64) error {
-	ret := _mock.Called(key, value, userID)
+// UpdateFileRevisionOperation provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateFileRevisionOperation(tx *goqu.TxDatabase, revisionID int64, operation string, updatedBy *int64) error {
+	ret := _mock.Called(tx, revisionID, operation, updatedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetSetting")
+		panic("no return value specified for UpdateFileRevisionOperation")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, *int64) error); ok {
-		r0 = returnFunc(key, value, userID)
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, *int64) error); ok {
+		r0 = returnFunc(tx, revisionID, operation, updatedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_SetSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSetting'
-type MockInternalDB_SetSetting_Call struct {
+// MockInternalDB_UpdateFileRevisionOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileRevisionOperation'
+type MockInternalDB_UpdateFileRevisionOperation_Call struct {
 	*mock.Call
 }
 
-// SetSetting is a helper method to define mock.On call
-//   - key string
-//   - value string
-//   - userID *int64
-func (_e *MockInternalDB_Expecter) SetSetting(key interface{}, value interface{}, userID interface{}) *MockInternalDB_SetSetting_Call {
-	return &MockInternalDB_SetSetting_Call{Call: _e.mock.On("SetSetting", key, value, userID)}
+// UpdateFileRevisionOperation is a helper method to define mock.On call
+//   - tx *goqu.TxDatabase
+//   - revisionID int64
+//   - operation string
+//   - updatedBy *int64
+func (_e *MockInternalDB_Expecter) UpdateFileRevisionOperation(tx interface{}, revisionID interface{}, operation interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionOperation_Call {
+	return &MockInternalDB_UpdateFileRevisionOperation_Call{Call: _e.mock.On("UpdateFileRevisionOperation", tx, revisionID, operation, updatedBy)}
 }
 
-func (_c *MockInternalDB_SetSetting_Call) Run(run func(key string, value string, userID *int64)) *MockInternalDB_SetSetting_Call {
+func (_c *MockInternalDB_UpdateFileRevisionOperation_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, operation string, updatedBy *int64)) *MockInternalDB_UpdateFileRevisionOperation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 *goqu.TxDatabase
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(*goqu.TxDatabase)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
-		var arg2 *int64
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*int64)
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_SetSetting_Call) Return(err error) *MockInternalDB_SetSetting_Call {
+func (_c *MockInternalDB_UpdateFileRevisionOperation_Call) Return(err error) *MockInternalDB_UpdateFileRevisionOperation_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_SetSetting_Call) RunAndReturn(run func(key string, value string, userID *int64) error) *MockInternalDB_SetSetting_Call {
+func (_c *MockInternalDB_UpdateFileRevisionOperation_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, operation string, updatedBy *int64) error) *MockInternalDB_UpdateFileRevisionOperation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetSettingIfNotExists provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) SetSettingIfNotExists(key string, value string, userID *int64) error {
-	ret := _mock.Called(key, value, userID)
+// UpdateFileRevisionPath provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy *int64) error {
+	ret := _mock.Called(tx, revisionID, revisionPath, updatedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetSettingIfNotExists")
+		panic("no return value specified for UpdateFileRevisionPath")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, *int64) error); ok {
-		r0 = returnFunc(key, value, userID)
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, *int64) error); ok {
+		r0 = returnFunc(tx, revisionID, revisionPath, updatedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_SetSettingIfNotExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSettingIfNotExists'
-type MockInternalDB_SetSettingIfNotExists_Call struct {
+// MockInternalDB_UpdateFileRevisionPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileRevisionPath'
+type MockInternalDB_UpdateFileRevisionPath_Call struct {
 	*mock.Call
 }
 
-// SetSettingIfNotExists is a helper method to define mock.On call
-//   - key string
-//   - value string
-//   - userID *int64
-func (_e *MockInternalDB_Expecter) SetSettingIfNotExists(key interface{}, value interface{}, userID interface{}) *MockInternalDB_SetSettingIfNotExists_Call {
-	return &MockInternalDB_SetSettingIfNotExists_Call{Call: _e.mock.On("SetSettingIfNotExists", key, value, userID)}
+// UpdateFileRevisionPath is a helper method to define mock.On call
+//   - tx *goqu.TxDatabase
+//   - revisionID int64
+//   - revisionPath string
+//   - updatedBy *int64
+func (_e *MockInternalDB_Expecter) UpdateFileRevisionPath(tx interface{}, revisionID interface{}, revisionPath interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionPath_Call {
+	return &MockInternalDB_UpdateFileRevisionPath_Call{Call: _e.mock.On("UpdateFileRevisionPath", tx, revisionID, revisionPath, updatedBy)}
 }
 
-func (_c *MockInternalDB_SetSettingIfNotExists_Call) Run(run func(key string, value string, userID *int64)) *MockInternalDB_SetSettingIfNotExists_Call {
+func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy *int64)) *MockInternalDB_UpdateFileRevisionPath_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 *goqu.TxDatabase
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(*goqu.TxDatabase)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
-		var arg2 *int64
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*int64)
+			arg2 = args[2].(string)
+		}
+		var arg3 *int64
+		if args[3] != nil {
+			arg3 = args[3].(*int64)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInternalDB_SetSettingIfNotExists_Call) Return(err error) *MockInternalDB_SetSettingIfNotExists_Call {
+func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Return(err error) *MockInternalDB_UpdateFileRevisionPath_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_SetSettingIfNotExists_Call) RunAndReturn(run func(key string, value string, userID *int64) error) *MockInternalDB_SetSettingIfNotExists_Call {
+func (_c *MockInternalDB_UpdateFileRevisionPath_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy *int64) error) *MockInternalDB_UpdateFileRevisionPath_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateFileRevisionPath provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) UpdateFileRevisionPath(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy int64) error {
-	ret := _mock.Called(tx, revisionID, revisionPath, updatedBy)
+// UpdateFileRevisionSource provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateFileRevisionSource(tx *goqu.TxDatabase, revisionID int64, source string, updatedBy *int64) error {
+	ret := _mock.Called(tx, revisionID, source, updatedBy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateFileRevisionPath")
+		panic("no return value specified for UpdateFileRevisionSource")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, int64) error); ok {
-		r0 = returnFunc(tx, revisionID, revisionPath, updatedBy)
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, *int64) error); ok {
+		r0 = returnFunc(tx, revisionID, source, updatedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockInternalDB_UpdateFileRevisionPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileRevisionPath'
-type MockInternalDB_UpdateFileRevisionPath_Call struct {
+// MockInternalDB_UpdateFileRevisionSource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFileRevisionSource'
+type MockInternalDB_UpdateFileRevisionSource_Call struct {
 	*mock.Call
 }
 
-// UpdateFileRevisionPath is a helper method to define mock.On call
+// UpdateFileRevisionSource is a helper method to define mock.On call
 //   - tx *goqu.TxDatabase
 //   - revisionID int64
-//   - revisionPath string
-//   - updatedBy int64
-func (_e *MockInternalDB_Expecter) UpdateFileRevisionPath(tx interface{}, revisionID interface{}, revisionPath interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionPath_Call {
-	return &MockInternalDB_UpdateFileRevisionPath_Call{Call: _e.mock.On("UpdateFileRevisionPath", tx, revisionID, revisionPath, updatedBy)}
+//   - source string
+//   - updatedBy *int64
+func (_e *MockInternalDB_Expecter) UpdateFileRevisionSource(tx interface{}, revisionID interface{}, source interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionSource_Call {
+	return &MockInternalDB_UpdateFileRevisionSource_Call{Call: _e.mock.On("UpdateFileRevisionSource", tx, revisionID, source, updatedBy)}
 }
 
-func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy int64)) *MockInternalDB_UpdateFileRevisionPath_Call {
+func (_c *MockInternalDB_UpdateFileRevisionSource_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, source string, updatedBy *int64)) *MockInternalDB_UpdateFileRevisionSource_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *goqu.TxDatabase
 		if args[0] != nil {
@@ -3136,9 +4567,9 @@ func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Run(run func(tx *goqu.TxDa
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 int64
+		var arg3 *int64
 		if args[3] != nil {
-			arg3 = args[3].(int64)
+			arg3 = args[3].(*int64)
 		}
 		run(
 			arg0,
@@ -3150,18 +4581,18 @@ func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Run(run func(tx *goqu.TxDa
 	return _c
 }
 
-func (_c *MockInternalDB_UpdateFileRevisionPath_Call) Return(err error) *MockInternalDB_UpdateFileRevisionPath_Call {
+func (_c *MockInternalDB_UpdateFileRevisionSource_Call) Return(err error) *MockInternalDB_UpdateFileRevisionSource_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockInternalDB_UpdateFileRevisionPath_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, revisionPath string, updatedBy int64) error) *MockInternalDB_UpdateFileRevisionPath_Call {
+func (_c *MockInternalDB_UpdateFileRevisionSource_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, source string, updatedBy *int64) error) *MockInternalDB_UpdateFileRevisionSource_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateFileRevisionStatus provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy int64) error {
+func (_mock *MockInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy *int64) error {
 	ret := _mock.Called(tx, revisionID, status, updatedBy)
 
 	if len(ret) == 0 {
@@ -3169,7 +4600,7 @@ func (_mock *MockInternalDB) UpdateFileRevisionStatus(tx *goqu.TxDatabase, revis
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, int64) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(*goqu.TxDatabase, int64, string, *int64) error); ok {
 		r0 = returnFunc(tx, revisionID, status, updatedBy)
 	} else {
 		r0 = ret.Error(0)
@@ -3186,12 +4617,12 @@ type MockInternalDB_UpdateFileRevisionStatus_Call struct {
 //   - tx *goqu.TxDatabase
 //   - revisionID int64
 //   - status string
-//   - updatedBy int64
+//   - updatedBy *int64
 func (_e *MockInternalDB_Expecter) UpdateFileRevisionStatus(tx interface{}, revisionID interface{}, status interface{}, updatedBy interface{}) *MockInternalDB_UpdateFileRevisionStatus_Call {
 	return &MockInternalDB_UpdateFileRevisionStatus_Call{Call: _e.mock.On("UpdateFileRevisionStatus", tx, revisionID, status, updatedBy)}
 }
 
-func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy int64)) *MockInternalDB_UpdateFileRevisionStatus_Call {
+func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) Run(run func(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy *int64)) *MockInternalDB_UpdateFileRevisionStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *goqu.TxDatabase
 		if args[0] != nil {
@@ -3205,9 +4636,9 @@ func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) Run(run func(tx *goqu.Tx
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 int64
+		var arg3 *int64
 		if args[3] != nil {
-			arg3 = args[3].(int64)
+			arg3 = args[3].(*int64)
 		}
 		run(
 			arg0,
@@ -3224,7 +4655,7 @@ func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) Return(err error) *MockI
 	return _c
 }
 
-func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy int64) error) *MockInternalDB_UpdateFileRevisionStatus_Call {
+func (_c *MockInternalDB_UpdateFileRevisionStatus_Call) RunAndReturn(run func(tx *goqu.TxDatabase, revisionID int64, status string, updatedBy *int64) error) *MockInternalDB_UpdateFileRevisionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3286,6 +4717,69 @@ func (_c *MockInternalDB_UpdateProcessEndTime_Call) RunAndReturn(run func(id int
 	return _c
 }
 
+// UpdateProcessPID provides a mock function for the type MockInternalDB
+func (_mock *MockInternalDB) UpdateProcessPID(id int64, pid *int, pidCreateTime *int64) error {
+	ret := _mock.Called(id, pid, pidCreateTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProcessPID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int64, *int, *int64) error); ok {
+		r0 = returnFunc(id, pid, pidCreateTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockInternalDB_UpdateProcessPID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProcessPID'
+type MockInternalDB_UpdateProcessPID_Call struct {
+	*mock.Call
+}
+
+// UpdateProcessPID is a helper method to define mock.On call
+//   - id int64
+//   - pid *int
+//   - pidCreateTime *int64
+func (_e *MockInternalDB_Expecter) UpdateProcessPID(id interface{}, pid interface{}, pidCreateTime interface{}) *MockInternalDB_UpdateProcessPID_Call {
+	return &MockInternalDB_UpdateProcessPID_Call{Call: _e.mock.On("UpdateProcessPID", id, pid, pidCreateTime)}
+}
+
+func (_c *MockInternalDB_UpdateProcessPID_Call) Run(run func(id int64, pid *int, pidCreateTime *int64)) *MockInternalDB_UpdateProcessPID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 *int
+		if args[1] != nil {
+			arg1 = args[1].(*int)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInternalDB_UpdateProcessPID_Call) Return(err error) *MockInternalDB_UpdateProcessPID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockInternalDB_UpdateProcessPID_Call) RunAndReturn(run func(id int64, pid *int, pidCreateTime *int64) error) *MockInternalDB_UpdateProcessPID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateProcessStartTime provides a mock function for the type MockInternalDB
 func (_mock *MockInternalDB) UpdateProcessStartTime(id int64, startTime time.Time) error {
 	ret := _mock.Called(id, startTime)
@@ -3344,16 +4838,16 @@ func (_c *MockInternalDB_UpdateProcessStartTime_Call) RunAndReturn(run func(id i
 }
 
 // UpdateServerProcess provides a mock function for the type MockInternalDB
-func (_mock *MockInternalDB) UpdateServerProcess(id int64, name string, path string, port *int) error {
-	ret := _mock.Called(id, name, path, port)
+func (_mock *MockInternalDB) UpdateServerProcess(id int64, name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64) error {
+	ret := _mock.Called(id, name, path, port, launch, dependsOn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateServerProcess")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int64, string, string, *int) error); ok {
-		r0 = returnFunc(id, name, path, port)
+	if returnFunc, ok := ret.Get(0).(func(int64, string, string, *int, ServerProcessLaunch, []int64) error); ok {
+		r0 = returnFunc(id, name, path, port, launch, dependsOn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3370,11 +4864,13 @@ type MockInternalDB_UpdateServerProcess_Call struct {
 //   - name string
 //   - path string
 //   - port *int
-func (_e *MockInternalDB_Expecter) UpdateServerProcess(id interface{}, name interface{}, path interface{}, port interface{}) *MockInternalDB_UpdateServerProcess_Call {
-	return &MockInternalDB_UpdateServerProcess_Call{Call: _e.mock.On("UpdateServerProcess", id, name, path, port)}
+//   - launch ServerProcessLaunch
+//   - dependsOn []int64
+func (_e *MockInternalDB_Expecter) UpdateServerProcess(id interface{}, name interface{}, path interface{}, port interface{}, launch interface{}, dependsOn interface{}) *MockInternalDB_UpdateServerProcess_Call {
+	return &MockInternalDB_UpdateServerProcess_Call{Call: _e.mock.On("UpdateServerProcess", id, name, path, port, launch, dependsOn)}
 }
 
-func (_c *MockInternalDB_UpdateServerProcess_Call) Run(run func(id int64, name string, path string, port *int)) *MockInternalDB_UpdateServerProcess_Call {
+func (_c *MockInternalDB_UpdateServerProcess_Call) Run(run func(id int64, name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64)) *MockInternalDB_UpdateServerProcess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int64
 		if args[0] != nil {
@@ -3392,11 +4888,21 @@ func (_c *MockInternalDB_UpdateServerProcess_Call) Run(run func(id int64, name s
 		if args[3] != nil {
 			arg3 = args[3].(*int)
 		}
+		var arg4 ServerProcessLaunch
+		if args[4] != nil {
+			arg4 = args[4].(ServerProcessLaunch)
+		}
+		var arg5 []int64
+		if args[5] != nil {
+			arg5 = args[5].([]int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -3407,7 +4913,7 @@ func (_c *MockInternalDB_UpdateServerProcess_Call) Return(err error) *MockIntern
 	return _c
 }
 
-func (_c *MockInternalDB_UpdateServerProcess_Call) RunAndReturn(run func(id int64, name string, path string, port *int) error) *MockInternalDB_UpdateServerProcess_Call {
+func (_c *MockInternalDB_UpdateServerProcess_Call) RunAndReturn(run func(id int64, name string, path string, port *int, launch ServerProcessLaunch, dependsOn []int64) error) *MockInternalDB_UpdateServerProcess_Call {
 	_c.Call.Return(run)
 	return _c
 }
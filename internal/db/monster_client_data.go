@@ -18,6 +18,11 @@ type MonsterClientData struct {
 	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// ClientDataName returns the monster ID and its name.
+func (d MonsterClientData) ClientDataName() (int64, string) {
+	return d.ID, d.Name
+}
+
 func (s *sqliteInternalDB) BulkReplaceMonsterClientData(data []MonsterClientData) error {
 	_, err := s.goqu.Delete("monster_client_data").
 		Prepared(true).
@@ -96,3 +101,25 @@ func (s *sqliteInternalDB) GetAllMonsterClientData(search string) ([]MonsterClie
 
 	return data, nil
 }
+
+func (s *sqliteInternalDB) GetMonsterClientDataByIDs(ids []int64) ([]MonsterClientData, error) {
+	var data []MonsterClientData
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	err := s.goqu.From("monster_client_data").
+		Prepared(true).
+		Where(goqu.C("id").In(ids)).
+		ScanStructs(&data)
+	if err != nil {
+		s.logger.Error(
+			"failed to get monster client data by ids",
+			logger.Field{Key: "count", Value: len(ids)},
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, fmt.Errorf("failed to get monster client data by ids: %w", err)
+	}
+
+	return data, nil
+}
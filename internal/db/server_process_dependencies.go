@@ -0,0 +1,98 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+type serverProcessDependency struct {
+	ProcessID   int64 `db:"process_id"`
+	DependsOnID int64 `db:"depends_on_id"`
+}
+
+// loadServerProcessDependencies sets DependsOn of every process in processes.
+func (s *sqliteInternalDB) loadServerProcessDependencies(processes []ServerProcess) error {
+	if len(processes) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(processes))
+	for _, process := range processes {
+		ids = append(ids, process.ID)
+	}
+
+	dependencies := make([]serverProcessDependency, 0)
+	err := s.goqu.From("server_process_dependencies").
+		Prepared(true).
+		Where(goqu.Ex{"process_id": ids}).
+		Order(goqu.C("depends_on_id").Asc()).
+		ScanStructs(&dependencies)
+	if err != nil {
+		s.logger.Error(
+			"failed to get server process dependencies",
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to get server process dependencies: %w", err)
+	}
+
+	dependsOn := make(map[int64][]int64, len(processes))
+	for _, dependency := range dependencies {
+		dependsOn[dependency.ProcessID] = append(dependsOn[dependency.ProcessID], dependency.DependsOnID)
+	}
+
+	for i := range processes {
+		processes[i].DependsOn = dependsOn[processes[i].ID]
+		if processes[i].DependsOn == nil {
+			processes[i].DependsOn = []int64{}
+		}
+	}
+
+	return nil
+}
+
+// setServerProcessDependencies replaces the dependencies of process id.
+func (s *sqliteInternalDB) setServerProcessDependencies(tx *goqu.TxDatabase, id int64, dependsOn []int64) error {
+	_, err := tx.Delete("server_process_dependencies").
+		Prepared(true).
+		Where(goqu.Ex{"process_id": id}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to clear server process dependencies",
+			logger.Field{Key: "id", Value: id},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to clear server process dependencies %d: %w", id, err)
+	}
+
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	rows := make([]interface{}, 0, len(dependsOn))
+	for _, dependsOnID := range dependsOn {
+		rows = append(rows, goqu.Record{
+			"process_id":    id,
+			"depends_on_id": dependsOnID,
+		})
+	}
+
+	_, err = tx.Insert("server_process_dependencies").
+		Prepared(true).
+		Rows(rows...).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to set server process dependencies",
+			logger.Field{Key: "id", Value: id},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to set server process dependencies %d: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+type ServerProcessEvent struct {
+	ID          int64     `db:"id" json:"id"`
+	ProcessID   int64     `db:"process_id" json:"process_id"`
+	ProcessName string    `db:"process_name" json:"process_name"`
+	EventType   string    `db:"event_type" json:"event_type"`
+	Attempt     int       `db:"attempt" json:"attempt"`
+	Message     *string   `db:"message" json:"message"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+func (s *sqliteInternalDB) CreateServerProcessEvent(processID int64, eventType string, attempt int, message string) error {
+	insertRecord := goqu.Record{
+		"process_id": processID,
+		"event_type": eventType,
+		"attempt":    attempt,
+	}
+
+	if message != "" {
+		insertRecord["message"] = message
+	}
+
+	_, err := s.goqu.Insert("server_process_events").
+		Prepared(true).
+		Rows(insertRecord).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to create server process event",
+			logger.Field{Key: "process_id", Value: processID},
+			logger.Field{Key: "event_type", Value: eventType},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to create server process event: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerProcessEventsPaginated returns the newest events first, for every
+// process when processID is nil.
+func (s *sqliteInternalDB) GetServerProcessEventsPaginated(processID *int64, page, pageSize int) ([]ServerProcessEvent, int64, error) {
+	countQuery := s.goqu.From("server_process_events").
+		Prepared(true).
+		Select(goqu.COUNT("*"))
+
+	if processID != nil {
+		countQuery = countQuery.Where(goqu.Ex{"process_id": *processID})
+	}
+
+	var totalCount int64
+	_, err := countQuery.ScanVal(&totalCount)
+	if err != nil {
+		s.logger.Error(
+			"failed to get server process events count",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get server process events count: %w", err)
+	}
+
+	query := s.goqu.From(goqu.T("server_process_events").As("spe")).
+		Prepared(true).
+		InnerJoin(goqu.T("server_processes").As("sp"), goqu.On(goqu.I("sp.id").Eq(goqu.I("spe.process_id")))).
+		Select(
+			goqu.I("spe.id").As("id"),
+			goqu.I("spe.process_id").As("process_id"),
+			goqu.I("sp.name").As("process_name"),
+			goqu.I("spe.event_type").As("event_type"),
+			goqu.I("spe.attempt").As("attempt"),
+			goqu.I("spe.message").As("message"),
+			goqu.I("spe.created_at").As("created_at"),
+		)
+
+	if processID != nil {
+		query = query.Where(goqu.I("spe.process_id").Eq(*processID))
+	}
+
+	offset := (page - 1) * pageSize
+	events := make([]ServerProcessEvent, 0)
+	err = query.
+		Order(goqu.I("spe.created_at").Desc(), goqu.I("spe.id").Desc()).
+		Limit(uint(pageSize)).
+		Offset(uint(offset)).
+		ScanStructs(&events)
+	if err != nil {
+		s.logger.Error(
+			"failed to get paginated server process events",
+			logger.Field{Key: "error", Value: err},
+		)
+		return nil, 0, fmt.Errorf("failed to get paginated server process events: %w", err)
+	}
+
+	return events, totalCount, nil
+}
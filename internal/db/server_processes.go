@@ -1,6 +1,8 @@
 package db
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -18,6 +20,104 @@ type ServerProcess struct {
 	EndTime       *time.Time `db:"end_time" json:"end_time"`
 	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt     *time.Time `db:"updated_at" json:"updated_at"`
+	// PID and PIDCreateTime, in milliseconds since the epoch, identify the
+	// OS process last started or adopted for this process.
+	PID           *int   `db:"pid" json:"pid"`
+	PIDCreateTime *int64 `db:"pid_create_time" json:"pid_create_time"`
+	// DependsOn are the IDs of the processes that must be running before
+	// this one is started, stored in server_process_dependencies.
+	DependsOn []int64 `db:"-" json:"depends_on"`
+	ServerProcessLaunch
+}
+
+// ServerProcessLaunch is how a server process is launched. WorkingDir defaults
+// to the directory of the binary; StartTimeoutSeconds and
+// CheckIntervalSeconds default to DefaultStartTimeoutSeconds and
+// DefaultCheckIntervalSeconds.
+type ServerProcessLaunch struct {
+	Args                 ProcessArgs `db:"args" json:"args"`
+	Env                  ProcessEnv  `db:"env" json:"env"`
+	WorkingDir           *string     `db:"working_dir" json:"working_dir"`
+	StartTimeoutSeconds  *int        `db:"start_timeout_seconds" json:"start_timeout_seconds"`
+	CheckIntervalSeconds *int        `db:"check_interval_seconds" json:"check_interval_seconds"`
+}
+
+const (
+	DefaultStartTimeoutSeconds  = 60
+	DefaultCheckIntervalSeconds = 2
+)
+
+func (l ServerProcessLaunch) StartTimeout() time.Duration {
+	if l.StartTimeoutSeconds == nil {
+		return DefaultStartTimeoutSeconds * time.Second
+	}
+
+	return time.Duration(*l.StartTimeoutSeconds) * time.Second
+}
+
+func (l ServerProcessLaunch) CheckInterval() time.Duration {
+	if l.CheckIntervalSeconds == nil {
+		return DefaultCheckIntervalSeconds * time.Second
+	}
+
+	return time.Duration(*l.CheckIntervalSeconds) * time.Second
+}
+
+// ProcessArgs are the command line arguments of a server process, stored as a
+// JSON array.
+type ProcessArgs []string
+
+func (a ProcessArgs) Value() (driver.Value, error) {
+	if a == nil {
+		a = ProcessArgs{}
+	}
+
+	data, err := json.Marshal([]string(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (a *ProcessArgs) Scan(src interface{}) error {
+	*a = ProcessArgs{}
+	return scanJSONColumn(src, (*[]string)(a))
+}
+
+// ProcessEnv are the environment variables set for a server process in
+// addition to the agent's own, stored as a JSON object.
+type ProcessEnv map[string]string
+
+func (e ProcessEnv) Value() (driver.Value, error) {
+	if e == nil {
+		e = ProcessEnv{}
+	}
+
+	data, err := json.Marshal(map[string]string(e))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (e *ProcessEnv) Scan(src interface{}) error {
+	*e = ProcessEnv{}
+	return scanJSONColumn(src, (*map[string]string)(e))
+}
+
+func scanJSONColumn(src interface{}, dst interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), dst)
+	case []byte:
+		return json.Unmarshal(v, dst)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", src)
+	}
 }
 
 type ReorderUpdate struct {
@@ -39,6 +139,10 @@ func (s *sqliteInternalDB) GetServerProcesses() ([]ServerProcess, error) {
 		return nil, fmt.Errorf("failed to get server processes: %w", err)
 	}
 
+	if err := s.loadServerProcessDependencies(processes); err != nil {
+		return nil, err
+	}
+
 	return processes, nil
 }
 
@@ -61,43 +165,73 @@ func (s *sqliteInternalDB) GetServerProcess(id int64) (*ServerProcess, error) {
 		return nil, fmt.Errorf("server process %d not found", id)
 	}
 
-	return &process, nil
+	processes := []ServerProcess{process}
+	if err := s.loadServerProcessDependencies(processes); err != nil {
+		return nil, err
+	}
+
+	return &processes[0], nil
 }
 
-func (s *sqliteInternalDB) GetServerProcessByPath(path string) (*ServerProcess, error) {
-	var process ServerProcess
-	found, err := s.goqu.From("server_processes").
+// GetServerProcessesByPath returns every process launching the binary at path.
+// Several processes may share a binary when their launch configuration
+// differs.
+func (s *sqliteInternalDB) GetServerProcessesByPath(path string) ([]ServerProcess, error) {
+	processes := make([]ServerProcess, 0)
+	err := s.goqu.From("server_processes").
 		Prepared(true).
 		Where(goqu.Ex{"path": path}).
-		ScanStruct(&process)
+		Order(goqu.C("sequence_order").Asc()).
+		ScanStructs(&processes)
 	if err != nil {
 		s.logger.Error(
-			"failed to get server process by path",
+			"failed to get server processes by path",
 			logger.Field{Key: "path", Value: path},
 			logger.Field{Key: "error", Value: err},
 		)
-		return nil, fmt.Errorf("failed to get server process by path: %w", err)
+		return nil, fmt.Errorf("failed to get server processes by path: %w", err)
 	}
 
-	if !found {
-		return nil, nil
+	if err := s.loadServerProcessDependencies(processes); err != nil {
+		return nil, err
 	}
 
-	return &process, nil
+	return processes, nil
 }
 
-func (s *sqliteInternalDB) CreateServerProcess(name, path string, port *int, sequenceOrder int) (*ServerProcess, error) {
+func (s *sqliteInternalDB) CreateServerProcess(name, path string, port *int, launch ServerProcessLaunch, dependsOn []int64, sequenceOrder int) (*ServerProcess, error) {
 	insertRecord := goqu.Record{
-		"name":           name,
-		"path":           path,
-		"sequence_order": sequenceOrder,
+		"name":                   name,
+		"path":                   path,
+		"sequence_order":         sequenceOrder,
+		"args":                   launch.Args,
+		"env":                    launch.Env,
+		"working_dir":            launch.WorkingDir,
+		"start_timeout_seconds":  launch.StartTimeoutSeconds,
+		"check_interval_seconds": launch.CheckIntervalSeconds,
 	}
 
 	if port != nil {
 		insertRecord["port"] = *port
 	}
 
-	result, err := s.goqu.Insert("server_processes").
+	tx, err := s.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error(
+					"failed to rollback transaction",
+					logger.Field{Key: "error", Value: rollbackErr},
+				)
+			}
+		}
+	}()
+
+	result, err := tx.Insert("server_processes").
 		Prepared(true).
 		Rows(insertRecord).
 		Executor().
@@ -117,14 +251,27 @@ func (s *sqliteInternalDB) CreateServerProcess(name, path string, port *int, seq
 		return nil, fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	if err = s.setServerProcessDependencies(tx, id, dependsOn); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return s.GetServerProcess(id)
 }
 
-func (s *sqliteInternalDB) UpdateServerProcess(id int64, name, path string, port *int) error {
+func (s *sqliteInternalDB) UpdateServerProcess(id int64, name, path string, port *int, launch ServerProcessLaunch, dependsOn []int64) error {
 	updateRecord := goqu.Record{
-		"name":       name,
-		"path":       path,
-		"updated_at": goqu.L("CURRENT_TIMESTAMP"),
+		"name":                   name,
+		"path":                   path,
+		"args":                   launch.Args,
+		"env":                    launch.Env,
+		"working_dir":            launch.WorkingDir,
+		"start_timeout_seconds":  launch.StartTimeoutSeconds,
+		"check_interval_seconds": launch.CheckIntervalSeconds,
+		"updated_at":             goqu.L("CURRENT_TIMESTAMP"),
 	}
 
 	if port != nil {
@@ -133,7 +280,23 @@ func (s *sqliteInternalDB) UpdateServerProcess(id int64, name, path string, port
 		updateRecord["port"] = nil
 	}
 
-	_, err := s.goqu.Update("server_processes").
+	tx, err := s.BeginTx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.logger.Error(
+					"failed to rollback transaction",
+					logger.Field{Key: "error", Value: rollbackErr},
+				)
+			}
+		}
+	}()
+
+	_, err = tx.Update("server_processes").
 		Prepared(true).
 		Set(updateRecord).
 		Where(goqu.Ex{"id": id}).
@@ -148,6 +311,14 @@ func (s *sqliteInternalDB) UpdateServerProcess(id int64, name, path string, port
 		return fmt.Errorf("failed to update server process %d: %w", id, err)
 	}
 
+	if err = s.setServerProcessDependencies(tx, id, dependsOn); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -272,3 +443,28 @@ func (s *sqliteInternalDB) UpdateProcessEndTime(id int64, endTime time.Time) err
 
 	return nil
 }
+
+// UpdateProcessPID records the OS process of a server process, or clears it
+// when pid is nil.
+func (s *sqliteInternalDB) UpdateProcessPID(id int64, pid *int, pidCreateTime *int64) error {
+	_, err := s.goqu.Update("server_processes").
+		Prepared(true).
+		Set(goqu.Record{
+			"pid":             pid,
+			"pid_create_time": pidCreateTime,
+			"updated_at":      goqu.L("CURRENT_TIMESTAMP"),
+		}).
+		Where(goqu.Ex{"id": id}).
+		Executor().
+		Exec()
+	if err != nil {
+		s.logger.Error(
+			"failed to update process pid",
+			logger.Field{Key: "id", Value: id},
+			logger.Field{Key: "error", Value: err},
+		)
+		return fmt.Errorf("failed to update process pid %d: %w", id, err)
+	}
+
+	return nil
+}
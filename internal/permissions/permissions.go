@@ -9,25 +9,39 @@ import (
 type PermissionAction string
 
 const (
-	ActionViewFiles      PermissionAction = "view_files"
-	ActionEditFiles      PermissionAction = "edit_files"
-	ActionRevertFiles    PermissionAction = "revert_files"
-	ActionUploadGameData PermissionAction = "upload_game_data"
-	ActionManageUsers    PermissionAction = "manage_users"
-	ActionViewMetrics    PermissionAction = "view_metrics"
-	ActionViewGameData   PermissionAction = "view_game_data"
-	ActionManageServer   PermissionAction = "manage_server"
+	ActionViewFiles       PermissionAction = "view_files"
+	ActionEditFiles       PermissionAction = "edit_files"
+	ActionRevertFiles     PermissionAction = "revert_files"
+	ActionUploadGameData  PermissionAction = "upload_game_data"
+	ActionManageUsers     PermissionAction = "manage_users"
+	ActionViewMetrics     PermissionAction = "view_metrics"
+	ActionViewGameData    PermissionAction = "view_game_data"
+	ActionManageServer    PermissionAction = "manage_server"
+	ActionManageFileLocks PermissionAction = "manage_file_locks"
+	ActionVerifyRevisions PermissionAction = "verify_revisions"
+	ActionUploadFiles     PermissionAction = "upload_files"
+	ActionDownloadFiles   PermissionAction = "download_files"
+	ActionRenameFiles     PermissionAction = "rename_files"
+	ActionCopyFiles       PermissionAction = "copy_files"
+	ActionDeleteFiles     PermissionAction = "delete_files"
 )
 
 var rolePermissions = map[PermissionAction][]string{
-	ActionViewFiles:      {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
-	ActionEditFiles:      {constants.RoleSuperAdmin, constants.RoleAdmin},
-	ActionRevertFiles:    {constants.RoleSuperAdmin, constants.RoleAdmin},
-	ActionUploadGameData: {constants.RoleSuperAdmin, constants.RoleAdmin},
-	ActionManageUsers:    {constants.RoleSuperAdmin},
-	ActionViewMetrics:    {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
-	ActionViewGameData:   {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
-	ActionManageServer:   {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionViewFiles:       {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
+	ActionEditFiles:       {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionRevertFiles:     {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionUploadGameData:  {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionManageUsers:     {constants.RoleSuperAdmin},
+	ActionViewMetrics:     {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
+	ActionViewGameData:    {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
+	ActionManageServer:    {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionManageFileLocks: {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionVerifyRevisions: {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionUploadFiles:     {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionDownloadFiles:   {constants.RoleSuperAdmin, constants.RoleAdmin, constants.RoleUser},
+	ActionRenameFiles:     {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionCopyFiles:       {constants.RoleSuperAdmin, constants.RoleAdmin},
+	ActionDeleteFiles:     {constants.RoleSuperAdmin, constants.RoleAdmin},
 }
 
 func normalizeRole(role string) string {
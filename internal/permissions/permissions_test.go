@@ -139,6 +139,96 @@ func TestIsAllowed(t *testing.T) {
 			roles:    []string{constants.RoleUser},
 			expected: true,
 		},
+		{
+			name:     "super_admin can manage file locks",
+			action:   ActionManageFileLocks,
+			roles:    []string{constants.RoleSuperAdmin},
+			expected: true,
+		},
+		{
+			name:     "admin can manage file locks",
+			action:   ActionManageFileLocks,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot manage file locks",
+			action:   ActionManageFileLocks,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
+		{
+			name:     "admin can verify revisions",
+			action:   ActionVerifyRevisions,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot verify revisions",
+			action:   ActionVerifyRevisions,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
+		{
+			name:     "admin can upload files",
+			action:   ActionUploadFiles,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot upload files",
+			action:   ActionUploadFiles,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
+		{
+			name:     "admin can download files",
+			action:   ActionDownloadFiles,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer can download files",
+			action:   ActionDownloadFiles,
+			roles:    []string{constants.RoleUser},
+			expected: true,
+		},
+		{
+			name:     "admin can rename files",
+			action:   ActionRenameFiles,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot rename files",
+			action:   ActionRenameFiles,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
+		{
+			name:     "admin can copy files",
+			action:   ActionCopyFiles,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot copy files",
+			action:   ActionCopyFiles,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
+		{
+			name:     "admin can delete files",
+			action:   ActionDeleteFiles,
+			roles:    []string{constants.RoleAdmin},
+			expected: true,
+		},
+		{
+			name:     "viewer cannot delete files",
+			action:   ActionDeleteFiles,
+			roles:    []string{constants.RoleUser},
+			expected: false,
+		},
 		{
 			name:     "multiple roles with one allowed grants access",
 			action:   ActionEditFiles,
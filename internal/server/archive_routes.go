@@ -0,0 +1,405 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleExportArchive(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionDownloadFiles) {
+		return
+	}
+
+	dirPath, ok := s.resolveRequestDirectory(w, r)
+	if !ok {
+		return
+	}
+
+	revisionsDir, _ := filepath.Abs(s.cfg.RevisionsDirectory)
+	include := func(path string) bool {
+		if absPath, err := filepath.Abs(path); err == nil && absPath == revisionsDir {
+			return false
+		}
+
+		_, err := s.pathResolver.Resolve(path)
+		return err == nil
+	}
+
+	archiveName := filepath.Base(dirPath)
+	if archiveName == "." || archiveName == string(filepath.Separator) {
+		archiveName = "archive"
+	}
+
+	// Streaming a large directory can take longer than the server write
+	// timeout allows.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": archiveName + ".zip"}))
+
+	// The archive is streamed, so a failure part way through can only be
+	// logged; the client receives a truncated zip.
+	if _, err := services.WriteDirectoryArchive(w, dirPath, include); err != nil {
+		s.log.Error("Failed to stream archive", logger.Field{Key: "path", Value: dirPath}, logger.Field{Key: "error", Value: err})
+	}
+}
+
+func (s *Server) handleImportArchive(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionUploadFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	dirPath, ok := s.resolveRequestDirectory(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	changeSetID := r.URL.Query().Get("change_set_id")
+	if changeSetID != "" && !s.requireOpenChangeSet(w, changeSetID) {
+		return
+	}
+
+	// Uploading and importing a large archive can take longer than the server
+	// read and write timeouts allow.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	maxArchiveSize := int64(s.cfg.MaxArchiveSizeMb) * 1024 * 1024
+	archiveData, _, ok := s.readUploadedFileWithLimit(w, r, maxArchiveSize)
+	if !ok {
+		return
+	}
+
+	entries, err := services.ReadArchive(archiveData, maxArchiveSize)
+	if err != nil {
+		message := err.Error()
+		if errors.Is(err, services.ErrArchiveTooLarge) {
+			message = "Archive contents exceed the maximum allowed size"
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{message},
+		})
+		return
+	}
+
+	// An import writes the files it planned with, so they are locked before
+	// they are read. A dry run writes nothing and takes no locks.
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	contexts := make(map[string]*fileUpdateContext, len(entries))
+	defer func() {
+		for _, ctx := range contexts {
+			s.releaseFileLock(ctx.lock)
+		}
+	}()
+
+	if !dryRun {
+		for _, entry := range entries {
+			target := archiveEntryPath(dirPath, entry)
+			ctx := &fileUpdateContext{
+				userID:    userID,
+				sessionID: sessionID,
+				cleanPath: target,
+				fileID:    utils.GenerateMD5Hash(target),
+			}
+
+			if !s.lockFileForUpdate(w, ctx) {
+				return
+			}
+
+			contexts[target] = ctx
+		}
+	}
+
+	files, entryErrors := s.planArchiveImport(dirPath, entries)
+	if len(entryErrors) > 0 {
+		errors := make([]string, len(entryErrors))
+		for i, entryError := range entryErrors {
+			errors[i] = entryError.Name + ": " + entryError.Error
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode":    constants.ErrorCodeBadRequest,
+			"context":      "file-system",
+			"errors":       errors,
+			"entry_errors": entryErrors,
+		})
+		return
+	}
+
+	response := ArchiveImportResponse{
+		DryRun:      dryRun,
+		Added:       []ArchiveImportedFile{},
+		Overwritten: []ArchiveImportedFile{},
+	}
+
+	var changed []archiveImportFile
+	for _, file := range files {
+		if file.info != nil && bytes.Equal(file.previousData, file.data) {
+			response.Unchanged++
+			continue
+		}
+
+		changed = append(changed, file)
+	}
+
+	if dryRun || len(changed) == 0 {
+		for _, file := range changed {
+			response.addFile(file, ArchiveImportedFile{Path: file.path, Size: len(file.data)})
+		}
+
+		_ = utils.WriteJSONResponse(w, response)
+		return
+	}
+
+	response.ChangeSetID = changeSetID
+	if response.ChangeSetID == "" {
+		changeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), "Archive import into "+dirPath, "open", userID)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to create change set: " + err.Error()},
+			})
+			return
+		}
+
+		response.ChangeSetID = changeSet.ID
+	}
+
+	// The archive is imported whole or not at all: when a file cannot be
+	// written, the files written before it are put back.
+	written := []ChangeSetRevertedFile{}
+	for _, file := range changed {
+		ctx := contexts[file.path]
+		ctx.info = file.info
+		ctx.changeSetID = response.ChangeSetID
+
+		revisionID, err := s.writeArchiveFile(ctx, file)
+		if revisionID != 0 {
+			written = append(written, ChangeSetRevertedFile{Path: file.path, RevisionID: revisionID})
+		}
+
+		if err != nil {
+			errors := []string{"Failed to import " + file.path + ": " + err.Error()}
+			if undoErr := s.abandonRequestChangeSet(response.ChangeSetID, changeSetID == "", written, userID); undoErr != nil {
+				errors = append(errors, fmt.Sprintf("Putting back the %d file(s) already imported failed, they remain imported in change set %s: %s", len(written), response.ChangeSetID, undoErr.Error()))
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    errors,
+			})
+			return
+		}
+
+		response.addFile(file, ArchiveImportedFile{Path: file.path, Size: len(file.data), RevisionID: &revisionID})
+	}
+
+	if changeSetID == "" {
+		s.closeRequestChangeSet(response.ChangeSetID, userID)
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+// resolveRequestDirectory reads the path parameter of a request and
+// checks that it names an existing directory.
+func (s *Server) resolveRequestDirectory(w http.ResponseWriter, r *http.Request) (string, bool) {
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return "", false
+	}
+
+	dirPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return "", false
+	}
+
+	info, err := s.fileEditor.Stat(dirPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return "", false
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read directory: " + err.Error()},
+		})
+		return "", false
+	}
+
+	if !info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path is not a directory"},
+		})
+		return "", false
+	}
+
+	return dirPath, true
+}
+
+type archiveImportFile struct {
+	path string
+	// info and previousData are nil when the file does not exist yet.
+	info         fs.FileInfo
+	data         []byte
+	previousData []byte
+}
+
+func archiveEntryPath(dirPath string, entry services.ArchiveEntry) string {
+	return filepath.Join(dirPath, filepath.FromSlash(entry.Name))
+}
+
+// planArchiveImport maps archive entries to files below dirPath and reads the
+// files they would overwrite. Entries that cannot be written are returned as
+// errors, so that nothing is written unless the whole archive can be.
+func (s *Server) planArchiveImport(dirPath string, entries []services.ArchiveEntry) ([]archiveImportFile, []ArchiveEntryError) {
+	var files []archiveImportFile
+	var entryErrors []ArchiveEntryError
+
+	for _, entry := range entries {
+		target := archiveEntryPath(dirPath, entry)
+		if _, err := s.pathResolver.Resolve(target); err != nil {
+			entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "outside the allowed directories"})
+			continue
+		}
+
+		// Entry names cannot climb out of dirPath, but a symbolic link below
+		// it can point elsewhere.
+		within, err := s.pathResolver.IsWithin(dirPath, target)
+		if err != nil {
+			entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "cannot resolve path: " + err.Error()})
+			continue
+		}
+
+		if !within {
+			entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "a symbolic link leads outside the target directory"})
+			continue
+		}
+
+		file := archiveImportFile{path: target, data: entry.Data}
+
+		info, err := s.fileEditor.Stat(target)
+		switch {
+		case err == nil && info.IsDir():
+			entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "a directory exists at this path"})
+			continue
+		case err == nil:
+			previousData, err := s.fileEditor.ReadFile(target)
+			if err != nil {
+				entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "cannot read existing file: " + err.Error()})
+				continue
+			}
+
+			file.info = info
+			file.previousData = previousData
+		case !s.fileEditor.IsNotExist(err):
+			entryErrors = append(entryErrors, ArchiveEntryError{Name: entry.Name, Path: target, Error: "cannot read existing file: " + err.Error()})
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	return files, entryErrors
+}
+
+// writeArchiveFile records a revision for file, an edit when it overwrites an
+// existing file and a create otherwise, and then writes it. When the write
+// fails the recorded revision is returned with the error, so that it can be
+// undone.
+func (s *Server) writeArchiveFile(ctx *fileUpdateContext, file archiveImportFile) (int64, error) {
+	perm := fs.FileMode(0644)
+	if file.info != nil {
+		perm = file.info.Mode().Perm()
+	} else {
+		ctx.operation = revisionOperationCreate
+	}
+
+	revisionID, err := s.recordFileRevision(ctx, file.previousData, file.data)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.fileEditor.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+		return revisionID, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := s.fileEditor.WriteFile(file.path, file.data, perm); err != nil {
+		return revisionID, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return revisionID, nil
+}
+
+func (response *ArchiveImportResponse) addFile(file archiveImportFile, imported ArchiveImportedFile) {
+	if file.info == nil {
+		response.Added = append(response.Added, imported)
+	} else {
+		response.Overwritten = append(response.Overwritten, imported)
+	}
+}
+
+type ArchiveEntryError struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+type ArchiveImportedFile struct {
+	Path       string `json:"path"`
+	Size       int    `json:"size"`
+	RevisionID *int64 `json:"revision_id,omitempty"`
+}
+
+type ArchiveImportResponse struct {
+	DryRun      bool                  `json:"dry_run"`
+	ChangeSetID string                `json:"change_set_id,omitempty"`
+	Added       []ArchiveImportedFile `json:"added"`
+	Overwritten []ArchiveImportedFile `json:"overwritten"`
+	Unchanged   int                   `json:"unchanged"`
+}
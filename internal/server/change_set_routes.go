@@ -0,0 +1,616 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleCreateChangeSet(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	var req CreateChangeSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			switch err.Tag() {
+			case "max":
+				errors = append(errors, err.Field()+" must be at most "+err.Param()+" characters")
+			default:
+				errors = append(errors, "Invalid "+err.Field())
+			}
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	changeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), req.Description, "open", userID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to create change set: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, changeSet)
+}
+
+func (s *Server) handleListChangeSets(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if parsed, err := strconv.Atoi(pageStr); err == nil && parsed >= 1 {
+			page = parsed
+		}
+	}
+
+	if pageSizeStr := r.URL.Query().Get("pageSize"); pageSizeStr != "" {
+		if parsed, err := strconv.Atoi(pageSizeStr); err == nil && parsed >= 1 && parsed <= 100 {
+			pageSize = parsed
+		}
+	}
+
+	status := r.URL.Query().Get("status")
+	if status != "" && status != "open" && status != "committed" && status != "reverted" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid status"},
+		})
+		return
+	}
+
+	changeSets, totalCount, err := s.internalDB.GetChangeSetsPaginated(page, pageSize, status)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get change sets: " + err.Error()},
+		})
+		return
+	}
+
+	if changeSets == nil {
+		changeSets = []db.ChangeSetListItem{}
+	}
+
+	_ = utils.WriteJSONResponse(w, ChangeSetsResponse{
+		Data: changeSets,
+		Pagination: PaginationInfo{
+			TotalCount: totalCount,
+			Page:       page,
+			PageSize:   pageSize,
+		},
+	})
+}
+
+func (s *Server) handleGetChangeSet(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	changeSet, ok := s.getChangeSetFromURL(w, r)
+	if !ok {
+		return
+	}
+
+	revisions, err := s.internalDB.GetChangeSetRevisions(changeSet.ID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get change set revisions: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, ChangeSetDetailsResponse{
+		ChangeSet: *changeSet,
+		Revisions: revisions,
+	})
+}
+
+func (s *Server) handleCommitChangeSet(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	changeSet, ok := s.getChangeSetFromURL(w, r)
+	if !ok {
+		return
+	}
+
+	if changeSet.Status != "open" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Change set cannot be committed because its status is " + changeSet.Status},
+		})
+		return
+	}
+
+	if err := s.internalDB.UpdateChangeSetStatus(changeSet.ID, "committed", userID); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to commit change set: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":       "Change set committed successfully",
+		"change_set_id": changeSet.ID,
+	})
+}
+
+// handleRevertChangeSet restores every file touched by a committed change set
+// to its state before the change set, undoing revisions newest first. Nothing
+// is written if any file has been modified since its last revision in the
+// change set, and if restoring one file fails the files already restored are
+// put back. The undo revisions are grouped in a new change set so that the
+// revert can itself be reverted.
+func (s *Server) handleRevertChangeSet(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionRevertFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	changeSet, ok := s.getChangeSetFromURL(w, r)
+	if !ok {
+		return
+	}
+
+	if changeSet.Status != "committed" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Change set cannot be reverted because its status is " + changeSet.Status},
+		})
+		return
+	}
+
+	allRevisions, err := s.internalDB.GetChangeSetRevisions(changeSet.ID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get change set revisions: " + err.Error()},
+		})
+		return
+	}
+
+	var revisions []db.FileRevision
+	for _, revision := range allRevisions {
+		if revision.Status == "pruned" {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Change set cannot be reverted because some of its revisions were pruned by the retention policy"},
+			})
+			return
+		}
+
+		if revision.Status == "completed" {
+			if _, ok := s.resolveRequestPath(w, revision.OriginalPath, "file-system"); !ok {
+				return
+			}
+
+			revisions = append(revisions, revision)
+		}
+	}
+
+	if len(revisions) == 0 {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Change set has no completed revisions to revert"},
+		})
+		return
+	}
+
+	// Every file stays locked until the revert is done, so that none of them
+	// can change between the conflict check and the restore.
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	locks := make(map[string]*heldFileLock)
+	defer func() {
+		for _, lock := range locks {
+			s.releaseFileLock(lock)
+		}
+	}()
+
+	for _, revision := range revisions {
+		if _, ok := locks[revision.FileID]; ok {
+			continue
+		}
+
+		lock, err := s.acquireFileLock(&fileUpdateContext{
+			userID:    userID,
+			sessionID: sessionID,
+			cleanPath: revision.OriginalPath,
+			fileID:    revision.FileID,
+		})
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{revision.OriginalPath + ": " + err.Error()},
+			})
+			return
+		}
+
+		locks[revision.FileID] = lock
+	}
+
+	latestRevisions := make(map[string]db.FileRevision)
+	for _, revision := range revisions {
+		latestRevisions[revision.FileID] = revision
+	}
+
+	conflicts := []ChangeSetConflict{}
+	checked := make(map[string]bool)
+	for _, revision := range revisions {
+		if checked[revision.FileID] {
+			continue
+		}
+
+		checked[revision.FileID] = true
+		latest := latestRevisions[revision.FileID]
+
+		if latest.Operation == revisionOperationDelete {
+			if _, err := s.fileEditor.Stat(latest.OriginalPath); err == nil {
+				conflicts = append(conflicts, ChangeSetConflict{Path: latest.OriginalPath, RevisionID: latest.ID, Reason: "File was created again after the change set"})
+			} else if !s.fileEditor.IsNotExist(err) {
+				conflicts = append(conflicts, ChangeSetConflict{Path: latest.OriginalPath, RevisionID: latest.ID, Reason: "Cannot read file: " + err.Error()})
+			}
+			continue
+		}
+
+		currentData, err := s.fileEditor.ReadFile(latest.OriginalPath)
+		if err != nil {
+			conflicts = append(conflicts, ChangeSetConflict{Path: latest.OriginalPath, RevisionID: latest.ID, Reason: "Cannot read file: " + err.Error()})
+			continue
+		}
+
+		if utils.CalculateFileHash(currentData) != latest.CurrentHash {
+			conflicts = append(conflicts, ChangeSetConflict{Path: latest.OriginalPath, RevisionID: latest.ID, Reason: "File was modified after the change set"})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		errors := make([]string, 0, len(conflicts))
+		for _, conflict := range conflicts {
+			errors = append(errors, conflict.Path+": "+conflict.Reason)
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+			"conflicts": conflicts,
+		})
+		return
+	}
+
+	revisionCopies := make([][]byte, len(revisions))
+	for i, revision := range revisions {
+		data, err := s.revisionStore.Get(revision.RevisionPath)
+		if err != nil {
+			if s.isMissingRevisionCopy(err) {
+				if markErr := s.markFileRevisionCorrupted(revision.ID, userID); markErr != nil {
+					s.log.Error("Failed to mark revision as corrupted", logger.Field{Key: "error", Value: markErr})
+				}
+
+				_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+					"errorCode": constants.ErrorCodeInternalServerError,
+					"context":   "file-system",
+					"errors":    []string{"Revision file for " + revision.OriginalPath + " is missing or corrupted"},
+				})
+				return
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read revision file: " + err.Error()},
+			})
+			return
+		}
+
+		revisionCopies[i] = data
+	}
+
+	revertChangeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), "Revert of change set "+changeSet.ID, "open", userID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to create change set: " + err.Error()},
+		})
+		return
+	}
+
+	reverted := []ChangeSetRevertedFile{}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		revisionID, err := s.restoreRevisionCopy(userID, sessionID, revertChangeSet.ID, locks[revision.FileID], revision, revisionCopies[i])
+		if err != nil {
+			if revisionID != 0 {
+				reverted = append(reverted, ChangeSetRevertedFile{Path: revision.OriginalPath, RevisionID: revisionID, RevertedRevisionID: revision.ID})
+			}
+
+			errors := []string{"Failed to revert " + revision.OriginalPath + ": " + err.Error()}
+			if undoErr := s.abandonRequestChangeSet(revertChangeSet.ID, true, reverted, userID); undoErr != nil {
+				errors = append(errors, fmt.Sprintf("Undoing the %d file(s) already reverted failed, they remain reverted in change set %s: %s", len(reverted), revertChangeSet.ID, undoErr.Error()))
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    errors,
+			})
+			return
+		}
+
+		reverted = append(reverted, ChangeSetRevertedFile{
+			Path:               revision.OriginalPath,
+			RevisionID:         revisionID,
+			RevertedRevisionID: revision.ID,
+		})
+	}
+
+	s.closeRequestChangeSet(revertChangeSet.ID, userID)
+
+	if err := s.internalDB.UpdateChangeSetStatus(changeSet.ID, "reverted", userID); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Files were reverted but the change set status could not be updated: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":              "Change set reverted successfully",
+		"change_set_id":        changeSet.ID,
+		"revert_change_set_id": revertChangeSet.ID,
+		"reverted":             reverted,
+	})
+}
+
+func (s *Server) restoreRevisionCopy(userID int64, sessionID string, changeSetID string, lock *heldFileLock, revision db.FileRevision, revisionData []byte) (int64, error) {
+	ctx := &fileUpdateContext{
+		userID:      userID,
+		sessionID:   sessionID,
+		cleanPath:   revision.OriginalPath,
+		fileID:      revision.FileID,
+		changeSetID: changeSetID,
+		lock:        lock,
+	}
+
+	return s.restoreRevisionState(ctx, revision, revisionData)
+}
+
+// undoRestoredRevisions puts back the files written by a revert or import
+// that failed part way, newest first, and marks the revisions that wrote them
+// as reverted, the way a single file revert undoes its last revision.
+func (s *Server) undoRestoredRevisions(restored []ChangeSetRevertedFile, userID int64) error {
+	for i := len(restored) - 1; i >= 0; i-- {
+		revision, err := s.internalDB.GetFileRevision(restored[i].RevisionID)
+		if err != nil {
+			return err
+		}
+
+		if revision == nil {
+			return fmt.Errorf("revision %d not found", restored[i].RevisionID)
+		}
+
+		data, err := s.revisionStore.Get(revision.RevisionPath)
+		if err != nil {
+			return fmt.Errorf("failed to read revision file of %s: %w", revision.OriginalPath, err)
+		}
+
+		if err := s.updateFileRevisionStatus(revision.ID, "reverted", userID); err != nil {
+			return err
+		}
+
+		if err := s.applyRevisionContent(revision.OriginalPath, data, revision.Operation == revisionOperationCreate); err != nil {
+			return fmt.Errorf("failed to undo %s: %w", revision.OriginalPath, err)
+		}
+	}
+
+	return nil
+}
+
+// abandonRequestChangeSet undoes the files written by a request that failed
+// part way, see undoRestoredRevisions. A change set created for the request
+// is marked as reverted once they are put back, or closed with
+// closeRequestChangeSet when nothing was written or they could not be.
+func (s *Server) abandonRequestChangeSet(changeSetID string, created bool, written []ChangeSetRevertedFile, userID int64) error {
+	undoErr := s.undoRestoredRevisions(written, userID)
+	if !created {
+		return undoErr
+	}
+
+	if undoErr != nil || len(written) == 0 {
+		s.closeRequestChangeSet(changeSetID, userID)
+		return undoErr
+	}
+
+	if err := s.internalDB.UpdateChangeSetStatus(changeSetID, "reverted", userID); err != nil {
+		s.log.Error("Failed to mark undone change set", logger.Field{Key: "change_set_id", Value: changeSetID}, logger.Field{Key: "error", Value: err})
+	}
+
+	return nil
+}
+
+// closeRequestChangeSet commits a change set that was created for a single
+// request, or deletes it when none of the request's writes were recorded in
+// it. It reports whether the change set was kept.
+func (s *Server) closeRequestChangeSet(changeSetID string, userID int64) bool {
+	revisions, err := s.internalDB.GetChangeSetRevisions(changeSetID)
+	if err != nil {
+		s.log.Error("Failed to get change set revisions", logger.Field{Key: "error", Value: err})
+		return true
+	}
+
+	if len(revisions) == 0 {
+		if err := s.internalDB.DeleteEmptyChangeSet(changeSetID); err != nil {
+			s.log.Error("Failed to delete empty change set", logger.Field{Key: "error", Value: err})
+			return true
+		}
+
+		return false
+	}
+
+	if err := s.internalDB.UpdateChangeSetStatus(changeSetID, "committed", userID); err != nil {
+		s.log.Error("Failed to commit change set", logger.Field{Key: "change_set_id", Value: changeSetID}, logger.Field{Key: "error", Value: err})
+	}
+
+	return true
+}
+
+func (s *Server) getChangeSetFromURL(w http.ResponseWriter, r *http.Request) (*db.ChangeSet, bool) {
+	changeSet, err := s.internalDB.GetChangeSet(chi.URLParam(r, "changeSetID"))
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get change set: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if changeSet == nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Change set not found"},
+		})
+		return nil, false
+	}
+
+	return changeSet, true
+}
+
+// requireOpenChangeSet writes an error response and returns false unless
+// changeSetID refers to a change set that still accepts new revisions.
+func (s *Server) requireOpenChangeSet(w http.ResponseWriter, changeSetID string) bool {
+	changeSet, err := s.internalDB.GetChangeSet(changeSetID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get change set: " + err.Error()},
+		})
+		return false
+	}
+
+	if changeSet == nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Change set not found"},
+		})
+		return false
+	}
+
+	if changeSet.Status != "open" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Change set is not open"},
+		})
+		return false
+	}
+
+	return true
+}
+
+type CreateChangeSetRequest struct {
+	Description string `json:"description" validate:"max=500"`
+}
+
+type ChangeSetsResponse struct {
+	Data       []db.ChangeSetListItem `json:"data"`
+	Pagination PaginationInfo         `json:"pagination"`
+}
+
+type ChangeSetDetailsResponse struct {
+	db.ChangeSet
+	Revisions []db.FileRevision `json:"revisions"`
+}
+
+type ChangeSetConflict struct {
+	Path       string `json:"path"`
+	RevisionID int64  `json:"revision_id"`
+	Reason     string `json:"reason"`
+}
+
+type ChangeSetRevertedFile struct {
+	Path               string `json:"path"`
+	RevisionID         int64  `json:"revision_id"`
+	RevertedRevisionID int64  `json:"reverted_revision_id"`
+}
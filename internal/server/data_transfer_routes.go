@@ -0,0 +1,780 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleExportData(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = services.RecordFormatCSV
+	}
+
+	if format != services.RecordFormatCSV && format != services.RecordFormatJSON {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Format must be csv or json"},
+		})
+		return
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	var columns []string
+	var rows [][]interface{}
+
+	if info.IsDir() {
+		files, ok := s.scanNPCDirectory(w, cleanPath)
+		if !ok {
+			return
+		}
+
+		columns = append([]string{"path", "hash"}, services.NPCRecordColumns()...)
+		for _, file := range files.files {
+			rows = append(rows, append([]interface{}{file.path, file.hash}, services.NPCToRecord(file.data)...))
+		}
+	} else {
+		fileType := s.fileEditor.GetFileType(cleanPath, info)
+		if fileType != services.FileTypeNPC && fileType != services.FileTypeSpawn && fileType != services.FileTypeDrop {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileNotViewable,
+				"context":   "file-system",
+				"errors":    []string{"Only NPC, spawn and drop files can be exported"},
+			})
+			return
+		}
+
+		data, err := s.fileEditor.ReadFile(cleanPath)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read file: " + err.Error()},
+			})
+			return
+		}
+
+		columns, rows, err = s.fileRecords(fileType, data)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read file data: " + err.Error()},
+			})
+			return
+		}
+
+		// Importing the rows back requires the file to be unchanged.
+		w.Header().Set("ETag", fileETag(data))
+	}
+
+	encoded, err := services.EncodeRecords(format, columns, rows)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to encode export: " + err.Error()},
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == services.RecordFormatJSON {
+		contentType = "application/json"
+	}
+
+	fileName := filepath.Base(cleanPath) + "." + format
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(encoded)))
+	_, _ = w.Write(encoded)
+}
+
+// fileRecords returns the content of an NPC, spawn or drop file as export
+// columns and rows.
+func (s *Server) fileRecords(fileType services.FileType, data []byte) ([]string, [][]interface{}, error) {
+	switch fileType {
+	case services.FileTypeNPC:
+		npcData, err := s.fileEditor.ReadNPCFileBytes(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return services.NPCRecordColumns(), [][]interface{}{services.NPCToRecord(npcData)}, nil
+	case services.FileTypeSpawn:
+		spawnData, err := s.fileEditor.ReadSpawnFileBytes(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := make([][]interface{}, 0, len(spawnData))
+		for _, spawn := range spawnData {
+			rows = append(rows, services.SpawnToRecord(spawn))
+		}
+
+		return services.SpawnRecordColumns(), rows, nil
+	case services.FileTypeDrop:
+		dropData, err := s.fileEditor.ReadDropFileBytes(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := make([][]interface{}, 0, len(dropData.Items))
+		for _, item := range dropData.Items {
+			rows = append(rows, services.DropToRecord(item))
+		}
+
+		return services.DropRecordColumns(), rows, nil
+	default:
+		return nil, nil, fmt.Errorf("file type %s has no record layout", fileType)
+	}
+}
+
+// requireImportIfMatch checks the If-Match header of a file import against
+// data, the file content read under the file lock. On a mismatch the current
+// rows are returned in the JSON export layout.
+func (s *Server) requireImportIfMatch(w http.ResponseWriter, r *http.Request, fileType services.FileType, data []byte) bool {
+	return s.requireFileIfMatch(w, r, data, func() (interface{}, error) {
+		columns, rows, err := s.fileRecords(fileType, data)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := services.EncodeRecords(services.RecordFormatJSON, columns, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.RawMessage(encoded), nil
+	})
+}
+
+// handleImportData replaces the content of an NPC, spawn or drop file, or of
+// the NPC files in a directory, with rows uploaded as CSV or JSON in the
+// export layout. Nothing is written unless every row is valid.
+func (s *Server) handleImportData(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	uploadedData, uploadedName, ok := s.readUploadedFile(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(uploadedName)), ".")
+	}
+
+	if format != services.RecordFormatCSV && format != services.RecordFormatJSON {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Format must be csv or json"},
+		})
+		return
+	}
+
+	records, err := services.DecodeRecords(format, uploadedData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if len(records) == 0 {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Uploaded file has no rows"},
+		})
+		return
+	}
+
+	if info.IsDir() {
+		s.importNPCDirectory(w, r, userID, cleanPath, records)
+		return
+	}
+
+	switch s.fileEditor.GetFileType(cleanPath, info) {
+	case services.FileTypeNPC:
+		s.importNPCFile(w, r, records)
+	case services.FileTypeSpawn:
+		s.importSpawnFile(w, r, records)
+	case services.FileTypeDrop:
+		s.importDropFile(w, r, records)
+	default:
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileNotViewable,
+			"context":   "file-system",
+			"errors":    []string{"Only NPC, spawn and drop files can be imported"},
+		})
+	}
+}
+
+func (s *Server) importNPCFile(w http.ResponseWriter, r *http.Request, records []map[string]string) {
+	ctx, ok := s.validateFileUpdateRequest(w, r, services.FileTypeNPC, "NPC")
+	if !ok {
+		return
+	}
+
+	if len(records) != 1 {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{fmt.Sprintf("NPC file import expects exactly one row, got %d", len(records))},
+		})
+		return
+	}
+
+	npcData, fieldErrors := services.NPCFromRecord(records[0])
+	if len(fieldErrors) > 0 {
+		writeImportRowErrors(w, newImportRowErrors(1, "", fieldErrors))
+		return
+	}
+
+	var currentDataBuffer bytes.Buffer
+	if err := binary.Write(&currentDataBuffer, binary.LittleEndian, npcData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to serialize NPC data: " + err.Error()},
+		})
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, ok := s.readFileForImport(w, ctx.cleanPath)
+	if !ok {
+		return
+	}
+
+	if !s.requireImportIfMatch(w, r, services.FileTypeNPC, previousData) {
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentDataBuffer.Bytes())
+	if !ok {
+		return
+	}
+
+	if err := s.fileEditor.WriteNPCFileData(ctx.cleanPath, npcData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File imported successfully",
+		"revision_id": revisionID,
+		"rows":        len(records),
+	})
+}
+
+func (s *Server) importSpawnFile(w http.ResponseWriter, r *http.Request, records []map[string]string) {
+	ctx, ok := s.validateFileUpdateRequest(w, r, services.FileTypeSpawn, "spawn")
+	if !ok {
+		return
+	}
+
+	spawnData := make([]services.NPCSpawnData, len(records))
+	var rowErrors []ImportRowError
+	for i, record := range records {
+		spawn, fieldErrors := services.SpawnFromRecord(record)
+		rowErrors = append(rowErrors, newImportRowErrors(i+1, "", fieldErrors)...)
+		spawnData[i] = spawn
+	}
+
+	if len(rowErrors) > 0 {
+		writeImportRowErrors(w, rowErrors)
+		return
+	}
+
+	var currentDataBuffer bytes.Buffer
+	if err := binary.Write(&currentDataBuffer, binary.LittleEndian, spawnData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to serialize spawn data: " + err.Error()},
+		})
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, ok := s.readFileForImport(w, ctx.cleanPath)
+	if !ok {
+		return
+	}
+
+	if !s.requireImportIfMatch(w, r, services.FileTypeSpawn, previousData) {
+		return
+	}
+
+	spawnWarnings, ok := s.checkSpawnPlacement(w, r, ctx.cleanPath, previousData, spawnData)
+	if !ok {
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentDataBuffer.Bytes())
+	if !ok {
+		return
+	}
+
+	if err := s.fileEditor.WriteSpawnFileData(ctx.cleanPath, spawnData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":      "File imported successfully",
+		"revision_id":  revisionID,
+		"rows":         len(records),
+		"spawn_issues": spawnWarnings,
+	})
+}
+
+func (s *Server) importDropFile(w http.ResponseWriter, r *http.Request, records []map[string]string) {
+	ctx, ok := s.validateFileUpdateRequest(w, r, services.FileTypeDrop, "drop")
+	if !ok {
+		return
+	}
+
+	items := make([]services.DropItemData, len(records))
+	var rowErrors []ImportRowError
+	for i, record := range records {
+		item, fieldErrors := services.DropFromRecord(record)
+		rowErrors = append(rowErrors, newImportRowErrors(i+1, "", fieldErrors)...)
+		items[i] = item
+	}
+
+	if len(rowErrors) > 0 {
+		writeImportRowErrors(w, rowErrors)
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, ok := s.readFileForImport(w, ctx.cleanPath)
+	if !ok {
+		return
+	}
+
+	if !s.requireImportIfMatch(w, r, services.FileTypeDrop, previousData) {
+		return
+	}
+
+	previousDropData, err := s.fileEditor.ReadDropFileBytes(previousData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read drop file data: " + err.Error()},
+		})
+		return
+	}
+
+	dropData := &services.DropFileData{
+		Items:   items,
+		Trailer: previousDropData.Trailer,
+	}
+
+	currentData, err := services.EncodeDropFileData(dropData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to serialize drop data: " + err.Error()},
+		})
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentData)
+	if !ok {
+		return
+	}
+
+	if err = s.fileEditor.WriteDropFileData(ctx.cleanPath, dropData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File imported successfully",
+		"revision_id": revisionID,
+		"rows":        len(records),
+	})
+}
+
+// importNPCDirectory writes one NPC file per row. Each row names its file in
+// the path column, either absolute or relative to dirPath, and the file must
+// already exist directly inside dirPath. The hash column holds the content
+// hash from the export, and a file whose content changed since is not
+// written.
+func (s *Server) importNPCDirectory(w http.ResponseWriter, r *http.Request, userID int64, dirPath string, records []map[string]string) {
+	changeSetID := r.URL.Query().Get("change_set_id")
+	if changeSetID != "" && !s.requireOpenChangeSet(w, changeSetID) {
+		return
+	}
+
+	type importedNPCFile struct {
+		file npcTableFile
+		data *services.NPCFileData
+	}
+
+	var imports []importedNPCFile
+	var rowErrors []ImportRowError
+	seen := make(map[string]int)
+
+	for i, record := range records {
+		row := i + 1
+		rowPath := strings.TrimSpace(record["path"])
+		if rowPath == "" {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Field: "path", Error: "missing value"})
+			continue
+		}
+
+		if !filepath.IsAbs(rowPath) {
+			rowPath = filepath.Join(dirPath, rowPath)
+		}
+
+		rowPath = filepath.Clean(rowPath)
+		if filepath.Dir(rowPath) != dirPath {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "path", Error: "must be a file directly inside " + dirPath})
+			continue
+		}
+
+		if previousRow, ok := seen[rowPath]; ok {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "path", Error: fmt.Sprintf("duplicate of row %d", previousRow)})
+			continue
+		}
+
+		seen[rowPath] = row
+
+		if _, err := s.pathResolver.Resolve(rowPath); err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "path", Error: "outside the allowed directories"})
+			continue
+		}
+
+		info, err := s.fileEditor.Stat(rowPath)
+		if err != nil || info.IsDir() || s.fileEditor.GetFileType(rowPath, info) != services.FileTypeNPC {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "path", Error: "not an existing NPC file"})
+			continue
+		}
+
+		if !s.fileEditor.IsFileEditable(rowPath, info) {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "path", Error: "file is not editable"})
+			continue
+		}
+
+		hash := strings.Trim(strings.TrimSpace(record["hash"]), `"`)
+		if hash == "" {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, Path: rowPath, Field: "hash", Error: "missing value, export the directory to get the current hash of each file"})
+			continue
+		}
+
+		npcData, fieldErrors := services.NPCFromRecord(record)
+		if len(fieldErrors) > 0 {
+			rowErrors = append(rowErrors, newImportRowErrors(row, rowPath, fieldErrors)...)
+			continue
+		}
+
+		imports = append(imports, importedNPCFile{file: npcTableFile{path: rowPath, info: info, hash: hash}, data: npcData})
+	}
+
+	if len(rowErrors) > 0 {
+		writeImportRowErrors(w, rowErrors)
+		return
+	}
+
+	response := NPCImportResponse{
+		Updated: []NPCImportedFile{},
+		Failed:  []NPCBatchUpdateFailure{},
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	for _, imported := range imports {
+		previousData, err := s.fileEditor.ReadFile(imported.file.path)
+		if err != nil {
+			response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: imported.file.path, Error: "failed to read file: " + err.Error()})
+			continue
+		}
+
+		var currentDataBuffer bytes.Buffer
+		if err := binary.Write(&currentDataBuffer, binary.LittleEndian, imported.data); err != nil {
+			response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: imported.file.path, Error: "failed to serialize NPC data: " + err.Error()})
+			continue
+		}
+
+		if bytes.Equal(previousData, currentDataBuffer.Bytes()) {
+			response.Unchanged++
+			continue
+		}
+
+		if response.ChangeSetID == "" {
+			response.ChangeSetID = changeSetID
+			if response.ChangeSetID == "" {
+				changeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), "Import into "+dirPath, "open", userID)
+				if err != nil {
+					response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: imported.file.path, Error: "failed to create change set: " + err.Error()})
+					continue
+				}
+
+				response.ChangeSetID = changeSet.ID
+			}
+		}
+
+		revisionID, err := s.writeNPCFileWithRevision(userID, sessionID, response.ChangeSetID, imported.file, imported.data)
+		if err != nil {
+			response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: imported.file.path, Error: err.Error()})
+			continue
+		}
+
+		response.Updated = append(response.Updated, NPCImportedFile{Path: imported.file.path, RevisionID: revisionID})
+	}
+
+	if response.ChangeSetID != "" && changeSetID == "" && !s.closeRequestChangeSet(response.ChangeSetID, userID) {
+		response.ChangeSetID = ""
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+func (s *Server) readFileForImport(w http.ResponseWriter, path string) ([]byte, bool) {
+	data, err := s.fileEditor.ReadFile(path)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (s *Server) readUploadedFile(w http.ResponseWriter, r *http.Request) ([]byte, string, bool) {
+	return s.readUploadedFileWithLimit(w, r, int64(s.cfg.MaxFileUploadSizeMb)*1024*1024)
+}
+
+// readUploadedFileWithLimit reads the "file" field of a multipart form,
+// rejecting uploads larger than maxUploadSize bytes.
+func (s *Server) readUploadedFileWithLimit(w http.ResponseWriter, r *http.Request, maxUploadSize int64) ([]byte, string, bool) {
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Failed to parse multipart form: " + err.Error()},
+		})
+		return nil, "", false
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get file from form: " + err.Error()},
+		})
+		return nil, "", false
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if fileHeader.Size > maxUploadSize {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"File size exceeds maximum allowed size"},
+		})
+		return nil, "", false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxUploadSize+1))
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return nil, "", false
+	}
+
+	if int64(len(data)) > maxUploadSize {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"File size exceeds maximum allowed size"},
+		})
+		return nil, "", false
+	}
+
+	return data, fileHeader.Filename, true
+}
+
+func newImportRowErrors(row int, path string, fieldErrors []services.RecordFieldError) []ImportRowError {
+	rowErrors := make([]ImportRowError, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		rowErrors[i] = ImportRowError{Row: row, Path: path, Field: fieldError.Field, Error: fieldError.Error}
+	}
+
+	return rowErrors
+}
+
+func writeImportRowErrors(w http.ResponseWriter, rowErrors []ImportRowError) {
+	errors := make([]string, len(rowErrors))
+	for i, rowError := range rowErrors {
+		errors[i] = fmt.Sprintf("Row %d: %s %s", rowError.Row, rowError.Field, rowError.Error)
+	}
+
+	_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+		"errorCode":  constants.ErrorCodeBadRequest,
+		"context":    "file-system",
+		"errors":     errors,
+		"row_errors": rowErrors,
+	})
+}
+
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Path  string `json:"path,omitempty"`
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+type NPCImportedFile struct {
+	Path       string `json:"path"`
+	RevisionID int64  `json:"revision_id"`
+}
+
+type NPCImportResponse struct {
+	ChangeSetID string                  `json:"change_set_id,omitempty"`
+	Updated     []NPCImportedFile       `json:"updated"`
+	Unchanged   int                     `json:"unchanged"`
+	Failed      []NPCBatchUpdateFailure `json:"failed"`
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+// revisionSourceExternal marks revisions recorded for changes made outside
+// the agent. Revisions saved through the API keep the default source, agent.
+const revisionSourceExternal = "external"
+
+const fileEventKeepAliveInterval = 30 * time.Second
+
+// recordExternalChanges records a revision for every change reported by the
+// file watcher that was not made through the agent and notifies the clients
+// listening for file events. It returns when the watcher is stopped.
+func (s *Server) recordExternalChanges() {
+	for event := range s.fileWatcher.Events() {
+		notification, err := s.recordExternalChange(event)
+		if err != nil {
+			s.log.Error("Failed to record external file change", logger.Field{Key: "path", Value: event.Path}, logger.Field{Key: "error", Value: err})
+			continue
+		}
+
+		if notification != nil {
+			s.publishFileEvent(*notification)
+		}
+	}
+}
+
+// recordExternalChange returns nil without recording anything when the
+// change was made by the agent itself.
+func (s *Server) recordExternalChange(event services.FileChangeEvent) (*FileEventNotification, error) {
+	fileID := utils.GenerateMD5Hash(event.Path)
+	currentHash := utils.CalculateFileHash(event.CurrentData)
+
+	madeByAgent, err := s.isAgentFileChange(fileID, currentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if madeByAgent {
+		return nil, nil
+	}
+
+	// External changes have no user; their revisions are recorded without
+	// one and told apart by their source.
+	ctx := &fileUpdateContext{
+		cleanPath: event.Path,
+		fileID:    fileID,
+		source:    revisionSourceExternal,
+	}
+
+	switch event.Operation {
+	case services.FileChangeCreate:
+		ctx.operation = revisionOperationCreate
+	case services.FileChangeDelete:
+		ctx.operation = revisionOperationDelete
+	}
+
+	revisionID, err := s.recordFileRevision(ctx, event.PreviousData, event.CurrentData)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info(
+		"Recorded external file change",
+		logger.Field{Key: "path", Value: event.Path},
+		logger.Field{Key: "operation", Value: event.Operation},
+		logger.Field{Key: "revision_id", Value: revisionID},
+	)
+
+	return &FileEventNotification{
+		Type:         "external_change",
+		Path:         event.Path,
+		FileID:       fileID,
+		Operation:    event.Operation,
+		RevisionID:   revisionID,
+		PreviousHash: utils.CalculateFileHash(event.PreviousData),
+		CurrentHash:  currentHash,
+		DetectedAt:   event.DetectedAt,
+	}, nil
+}
+
+// isAgentFileChange reports whether a file now holding content with hash was
+// last written by the agent: either saved by its newest revision or restored
+// by reverting it.
+func (s *Server) isAgentFileChange(fileID string, hash string) (bool, error) {
+	revisions, _, err := s.internalDB.GetFileRevisionsPaginated(fileID, 1, 1)
+	if err != nil {
+		return false, err
+	}
+
+	if len(revisions) == 0 {
+		return false, nil
+	}
+
+	latest := revisions[0]
+	switch latest.Status {
+	case "completed":
+		return latest.CurrentHash == hash, nil
+	case "reverted":
+		return latest.PreviousHash == hash, nil
+	default:
+		return false, nil
+	}
+}
+
+func (s *Server) subscribeFileEvents() (chan FileEventNotification, func()) {
+	ch := make(chan FileEventNotification, 16)
+
+	s.fileEventsMu.Lock()
+	s.fileEventSubscribers[ch] = struct{}{}
+	s.fileEventsMu.Unlock()
+
+	return ch, func() {
+		s.fileEventsMu.Lock()
+		delete(s.fileEventSubscribers, ch)
+		s.fileEventsMu.Unlock()
+	}
+}
+
+// publishFileEvent sends notification to every subscriber. A subscriber that
+// is not keeping up misses the notification rather than blocking the others.
+func (s *Server) publishFileEvent(notification FileEventNotification) {
+	s.fileEventsMu.Lock()
+	defer s.fileEventsMu.Unlock()
+
+	for ch := range s.fileEventSubscribers {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
+
+func (s *Server) handleFileEvents(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	// The stream stays open for as long as the client listens, so the server
+	// write timeout must not apply to it.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	events, unsubscribe := s.subscribeFileEvents()
+	defer unsubscribe()
+
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(fileEventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case notification := <-events:
+			data, err := json.Marshal(notification)
+			if err != nil {
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Type, data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+type FileEventNotification struct {
+	Type         string    `json:"type"`
+	Path         string    `json:"path"`
+	FileID       string    `json:"file_id"`
+	Operation    string    `json:"operation"`
+	RevisionID   int64     `json:"revision_id"`
+	PreviousHash string    `json:"previous_hash"`
+	CurrentHash  string    `json:"current_hash"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
@@ -0,0 +1,373 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const fileLockExtension = ".lock"
+
+func (s *Server) handleListFileLocks(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionManageFileLocks) {
+		return
+	}
+
+	locks, err := s.listFileLocks()
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to list file locks: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"data": locks,
+	})
+}
+
+func (s *Server) handleReleaseFileLock(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionManageFileLocks) {
+		return
+	}
+
+	fileID := chi.URLParam(r, "fileID")
+	if fileID == "" || strings.ContainsAny(fileID, `/\.`) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid file ID"},
+		})
+		return
+	}
+
+	s.fileLocksMu.Lock()
+	defer s.fileLocksMu.Unlock()
+
+	lockPath := filepath.Join(s.fileLocksDirectory(), fileID+fileLockExtension)
+	lock, err := s.readFileLock(lockPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Lock not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read lock: " + err.Error()},
+		})
+		return
+	}
+
+	if err := s.fileEditor.Remove(lockPath); err != nil && !s.fileEditor.IsNotExist(err) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to release lock: " + err.Error()},
+		})
+		return
+	}
+
+	userID, _ := utils.GetUserIdFromContext(r.Context())
+	s.log.Info(
+		"File lock force-released",
+		logger.Field{Key: "file_id", Value: lock.FileID},
+		logger.Field{Key: "path", Value: lock.Path},
+		logger.Field{Key: "owner_user_id", Value: lock.UserID},
+		logger.Field{Key: "released_by", Value: userID},
+	)
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message": "Lock released successfully",
+		"lock":    lock,
+	})
+}
+
+func (s *Server) fileLocksDirectory() string {
+	return filepath.Join(s.cfg.RevisionsDirectory, "locks")
+}
+
+// heldFileLock is a lock taken by acquireFileLock. The token written into the
+// lock file tells this acquisition apart from any later one of the same file,
+// so that a lock replaced after it went stale is not released by its former
+// holder.
+type heldFileLock struct {
+	path  string
+	token string
+}
+
+// acquireFileLock creates the lock file for ctx.fileID, recording who holds it.
+// An existing lock is replaced only if it is stale.
+func (s *Server) acquireFileLock(ctx *fileUpdateContext) (*heldFileLock, error) {
+	locksDir := s.fileLocksDirectory()
+	if err := s.fileEditor.MkdirAll(locksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create locks directory: %w", err)
+	}
+
+	hostname, _ := s.fileEditor.Hostname()
+	token := utils.GenerateRandomToken(32)
+	content, err := json.Marshal(fileLockRecord{
+		FileLock: FileLock{
+			FileID:     ctx.fileID,
+			Path:       ctx.cleanPath,
+			UserID:     ctx.userID,
+			PID:        os.Getpid(),
+			Hostname:   hostname,
+			AcquiredAt: time.Now().UTC(),
+		},
+		SessionID: ctx.sessionID,
+		Token:     token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %w", err)
+	}
+
+	s.fileLocksMu.Lock()
+	defer s.fileLocksMu.Unlock()
+
+	lockPath := filepath.Join(locksDir, ctx.fileID+fileLockExtension)
+	lockFile, err := s.fileEditor.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil && s.fileEditor.IsExist(err) && s.removeFileLockIfStale(lockPath) {
+		lockFile, err = s.fileEditor.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+
+	if err != nil {
+		if s.fileEditor.IsExist(err) {
+			return nil, fmt.Errorf("file is currently being edited by another process")
+		}
+		return nil, fmt.Errorf("failed to create lock file: %w", err)
+	}
+
+	// A lock without its token could never be released, so it is given up
+	// when it cannot be written.
+	_, err = lockFile.Write(content)
+	if closeErr := lockFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if removeErr := s.fileEditor.Remove(lockPath); removeErr != nil {
+			s.log.Error("Failed to remove lock file", logger.Field{Key: "error", Value: removeErr})
+		}
+		return nil, fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	return &heldFileLock{path: lockPath, token: token}, nil
+}
+
+// releaseFileLock removes the lock file of lock if it still holds lock's
+// token. A lock that expired may have been replaced by another holder since,
+// whose lock is left in place. A nil lock is ignored.
+func (s *Server) releaseFileLock(lock *heldFileLock) {
+	if lock == nil {
+		return
+	}
+
+	s.fileLocksMu.Lock()
+	defer s.fileLocksMu.Unlock()
+
+	data, err := s.fileEditor.ReadFile(lock.path)
+	if err != nil {
+		if !s.fileEditor.IsNotExist(err) {
+			s.log.Error("Failed to read lock file", logger.Field{Key: "path", Value: lock.path}, logger.Field{Key: "error", Value: err})
+		}
+		return
+	}
+
+	var record fileLockRecord
+	if json.Unmarshal(data, &record) != nil || record.Token != lock.token {
+		s.log.Warn("File lock was taken over by another holder, leaving it in place", logger.Field{Key: "path", Value: lock.path})
+		return
+	}
+
+	if err := s.fileEditor.Remove(lock.path); err != nil && !s.fileEditor.IsNotExist(err) {
+		s.log.Error("Failed to remove lock file", logger.Field{Key: "error", Value: err})
+	}
+}
+
+// readFileLock reads a lock file. Locks written before owner information was
+// recorded are empty, so for those only the file ID and modification time are
+// known.
+func (s *Server) readFileLock(lockPath string) (FileLock, error) {
+	info, err := s.fileEditor.Stat(lockPath)
+	if err != nil {
+		return FileLock{}, err
+	}
+
+	data, err := s.fileEditor.ReadFile(lockPath)
+	if err != nil {
+		return FileLock{}, err
+	}
+
+	var lock FileLock
+	if len(data) == 0 || json.Unmarshal(data, &lock) != nil {
+		lock = FileLock{}
+	}
+
+	if lock.FileID == "" {
+		lock.FileID = strings.TrimSuffix(filepath.Base(lockPath), fileLockExtension)
+	}
+
+	if lock.AcquiredAt.IsZero() {
+		lock.AcquiredAt = info.ModTime().UTC()
+	}
+
+	return lock, nil
+}
+
+// staleFileLockReason returns why lock can be safely removed, or an empty
+// string if it may still be held. A lock is only removed once it expired or
+// when its owner process on this host is no longer running; a live lock of
+// another agent on the same host is left alone.
+func (s *Server) staleFileLockReason(lock FileLock) string {
+	ttl := time.Duration(s.cfg.FileLockTTLSeconds) * time.Second
+	if ttl > 0 && time.Since(lock.AcquiredAt) > ttl {
+		return "expired"
+	}
+
+	if lock.PID == 0 || lock.PID == os.Getpid() {
+		return ""
+	}
+
+	if hostname, _ := s.fileEditor.Hostname(); lock.Hostname != hostname {
+		return ""
+	}
+
+	running, err := s.processService.IsPIDRunning(lock.PID)
+	if err != nil || running {
+		return ""
+	}
+
+	return "owner process is not running"
+}
+
+// removeFileLockIfStale must be called with fileLocksMu held.
+func (s *Server) removeFileLockIfStale(lockPath string) bool {
+	lock, err := s.readFileLock(lockPath)
+	if err != nil {
+		return false
+	}
+
+	reason := s.staleFileLockReason(lock)
+	if reason == "" {
+		return false
+	}
+
+	if err := s.fileEditor.Remove(lockPath); err != nil && !s.fileEditor.IsNotExist(err) {
+		s.log.Error("Failed to remove stale lock file", logger.Field{Key: "path", Value: lockPath}, logger.Field{Key: "error", Value: err})
+		return false
+	}
+
+	s.log.Info(
+		"Removed stale file lock",
+		logger.Field{Key: "file_id", Value: lock.FileID},
+		logger.Field{Key: "path", Value: lock.Path},
+		logger.Field{Key: "reason", Value: reason},
+	)
+
+	return true
+}
+
+// releaseStaleFileLocks removes every stale lock in the locks directory. It
+// runs once at startup and then periodically.
+func (s *Server) releaseStaleFileLocks() {
+	entries, err := s.fileEditor.ReadDir(s.fileLocksDirectory())
+	if err != nil {
+		if !s.fileEditor.IsNotExist(err) {
+			s.log.Error("Failed to read locks directory", logger.Field{Key: "error", Value: err})
+		}
+		return
+	}
+
+	s.fileLocksMu.Lock()
+	defer s.fileLocksMu.Unlock()
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileLockExtension) {
+			continue
+		}
+
+		s.removeFileLockIfStale(filepath.Join(s.fileLocksDirectory(), entry.Name()))
+	}
+}
+
+func (s *Server) listFileLocks() ([]FileLockStatus, error) {
+	locks := []FileLockStatus{}
+
+	entries, err := s.fileEditor.ReadDir(s.fileLocksDirectory())
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			return locks, nil
+		}
+
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileLockExtension) {
+			continue
+		}
+
+		lock, err := s.readFileLock(filepath.Join(s.fileLocksDirectory(), entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		reason := s.staleFileLockReason(lock)
+		locks = append(locks, FileLockStatus{
+			FileLock:    lock,
+			Stale:       reason != "",
+			StaleReason: reason,
+			AgeSeconds:  int64(time.Since(lock.AcquiredAt).Seconds()),
+		})
+	}
+
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].AcquiredAt.Before(locks[j].AcquiredAt)
+	})
+
+	return locks, nil
+}
+
+type FileLock struct {
+	FileID     string    `json:"file_id"`
+	Path       string    `json:"path,omitempty"`
+	UserID     int64     `json:"user_id,omitempty"`
+	PID        int       `json:"pid,omitempty"`
+	Hostname   string    `json:"hostname,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// fileLockRecord is the content of a lock file. The session holding the lock
+// is kept for diagnosis on disk but not returned by the API, and neither is
+// the token identifying the acquisition.
+type fileLockRecord struct {
+	FileLock
+	SessionID string `json:"session_id,omitempty"`
+	Token     string `json:"token,omitempty"`
+}
+
+type FileLockStatus struct {
+	FileLock
+	Stale       bool   `json:"stale"`
+	StaleReason string `json:"stale_reason,omitempty"`
+	AgeSeconds  int64  `json:"age_seconds"`
+}
@@ -0,0 +1,770 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+// Revisions that create or delete a file record the operation, so that
+// reverting them removes or recreates the file instead of rewriting it.
+// Deletions keep the removed content as their revision copy.
+const (
+	revisionOperationCreate = "create"
+	revisionOperationDelete = "delete"
+)
+
+func (s *Server) handleUploadFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionUploadFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	dirPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	dirInfo, err := s.fileEditor.Stat(dirPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read directory: " + err.Error()},
+		})
+		return
+	}
+
+	if !dirInfo.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path is not a directory"},
+		})
+		return
+	}
+
+	overwrite, _ := strconv.ParseBool(r.URL.Query().Get("overwrite"))
+
+	changeSetID := r.URL.Query().Get("change_set_id")
+	if changeSetID != "" && !s.requireOpenChangeSet(w, changeSetID) {
+		return
+	}
+
+	// Uploading a large file can take longer than the server read and write
+	// timeouts allow.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	fileData, uploadedName, ok := s.readUploadedFile(w, r)
+	if !ok {
+		return
+	}
+
+	// Only the base name of the uploaded file is used, so a crafted name
+	// cannot place the file outside the target directory.
+	fileName := filepath.Base(filepath.FromSlash(uploadedName))
+	if fileName == "." || fileName == ".." || fileName == string(filepath.Separator) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Uploaded file has no valid name"},
+		})
+		return
+	}
+
+	targetPath, ok := s.resolveRequestPath(w, filepath.Join(dirPath, fileName), "file-system")
+	if !ok {
+		return
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	ctx := &fileUpdateContext{
+		userID:      userID,
+		sessionID:   sessionID,
+		cleanPath:   targetPath,
+		fileID:      utils.GenerateMD5Hash(targetPath),
+		changeSetID: changeSetID,
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, ok := s.prepareFileTarget(w, r, ctx, overwrite)
+	if !ok {
+		return
+	}
+
+	revisionID, ok := s.writeFileWithRevision(w, ctx, previousData, fileData, 0644)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("ETag", fileETag(fileData))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File uploaded successfully",
+		"path":        targetPath,
+		"revision_id": revisionID,
+	})
+}
+
+func (s *Server) handleDownloadFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionDownloadFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
+			"context":   "file-system",
+			"errors":    []string{"Path is a directory, not a file"},
+		})
+		return
+	}
+
+	file, err := s.fileEditor.OpenFile(cleanPath, os.O_RDONLY, 0)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+	defer file.Close()
+
+	// Streaming a large file can take longer than the server write timeout
+	// allows.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filepath.Base(cleanPath)}))
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+
+	// The headers are already sent, so a failure here can only be logged.
+	if _, err := io.Copy(w, file); err != nil {
+		s.log.Error("Failed to stream file", logger.Field{Key: "path", Value: cleanPath}, logger.Field{Key: "error", Value: err})
+	}
+}
+
+func (s *Server) handleRenameFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionRenameFiles) {
+		return
+	}
+
+	move, ok := s.prepareFileMove(w, r)
+	if !ok {
+		return
+	}
+
+	defer s.releaseFileMove(move)
+
+	// The new file and the removal of the old one are recorded in one change
+	// set, so that reverting it restores the file under its old name.
+	changeSetID := move.changeSetID
+	if changeSetID == "" {
+		changeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), "Rename of "+move.source.cleanPath+" to "+move.destination.cleanPath, "open", move.source.userID)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to create change set: " + err.Error()},
+			})
+			return
+		}
+
+		changeSetID = changeSet.ID
+	}
+
+	move.source.changeSetID = changeSetID
+	move.destination.changeSetID = changeSetID
+
+	// Both revisions are recorded before the file is moved, so that a move
+	// that fails leaves the files as they were and only the revisions have to
+	// be marked, see abandonFileMove.
+	revisionID, err := s.recordFileRevision(move.destination, move.existingData, move.data)
+	if err != nil {
+		if move.changeSetID == "" {
+			s.closeRequestChangeSet(changeSetID, move.source.userID)
+		}
+
+		s.writeFileRevisionError(w, err)
+		return
+	}
+
+	move.source.operation = revisionOperationDelete
+	sourceRevisionID, err := s.recordFileRevision(move.source, move.data, nil)
+	if err != nil {
+		s.abandonFileMove(move, changeSetID, revisionID)
+		s.writeFileRevisionError(w, err)
+		return
+	}
+
+	if err := s.moveFile(move); err != nil {
+		errors := []string{"Failed to rename file: " + err.Error()}
+		if undoErr := s.applyRevisionContent(move.destination.cleanPath, move.existingData, move.destination.operation == revisionOperationCreate); undoErr != nil {
+			if move.changeSetID == "" {
+				s.closeRequestChangeSet(changeSetID, move.source.userID)
+			}
+
+			errors = append(errors, "Putting back "+move.destination.cleanPath+" failed, it now has the content of "+move.source.cleanPath+": "+undoErr.Error())
+		} else {
+			s.abandonFileMove(move, changeSetID, revisionID, sourceRevisionID)
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	if move.changeSetID == "" {
+		s.closeRequestChangeSet(changeSetID, move.source.userID)
+	}
+
+	w.Header().Set("ETag", fileETag(move.data))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":            "File renamed successfully",
+		"path":               move.destination.cleanPath,
+		"change_set_id":      changeSetID,
+		"revision_id":        revisionID,
+		"source_revision_id": sourceRevisionID,
+	})
+}
+
+// abandonFileMove marks the revisions of a rename that did not happen as
+// reverted, together with the change set when it was created for the rename.
+func (s *Server) abandonFileMove(move *fileMove, changeSetID string, revisionIDs ...int64) {
+	for _, revisionID := range revisionIDs {
+		if err := s.updateFileRevisionStatus(revisionID, "reverted", move.source.userID); err != nil {
+			s.log.Error("Failed to mark revision of failed rename", logger.Field{Key: "error", Value: err})
+		}
+	}
+
+	if move.changeSetID == "" {
+		if err := s.internalDB.UpdateChangeSetStatus(changeSetID, "reverted", move.source.userID); err != nil {
+			s.log.Error("Failed to mark change set of failed rename", logger.Field{Key: "error", Value: err})
+		}
+	}
+}
+
+// moveFile moves the source file of move to its destination. Within one
+// volume the file is renamed, replacing the destination in one step;
+// otherwise it is copied and the source removed. The source is removed last,
+// so when moveFile fails it still exists and only the destination may have
+// to be put back.
+func (s *Server) moveFile(move *fileMove) error {
+	if err := s.fileEditor.Rename(move.source.cleanPath, move.destination.cleanPath); err == nil {
+		return nil
+	}
+
+	perm := move.source.info.Mode().Perm()
+	if move.destination.info != nil {
+		perm = move.destination.info.Mode().Perm()
+	}
+
+	if err := s.fileEditor.WriteFile(move.destination.cleanPath, move.data, perm); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := s.fileEditor.Remove(move.source.cleanPath); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) handleCopyFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionCopyFiles) {
+		return
+	}
+
+	move, ok := s.prepareFileMove(w, r)
+	if !ok {
+		return
+	}
+
+	defer s.releaseFileMove(move)
+
+	revisionID, ok := s.writeFileWithRevision(w, move.destination, move.existingData, move.data, move.source.info.Mode().Perm())
+	if !ok {
+		return
+	}
+
+	w.Header().Set("ETag", fileETag(move.data))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File copied successfully",
+		"path":        move.destination.cleanPath,
+		"revision_id": revisionID,
+	})
+}
+
+func (s *Server) handleDeleteFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionDeleteFiles) {
+		return
+	}
+
+	ctx, ok := s.prepareFileUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := s.fileEditor.ReadFile(ctx.cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return
+	}
+
+	revisionID, ok := s.deleteFileWithRevision(w, ctx, data)
+	if !ok {
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File deleted successfully",
+		"revision_id": revisionID,
+	})
+}
+
+type fileMove struct {
+	source       *fileUpdateContext
+	destination  *fileUpdateContext
+	changeSetID  string
+	data         []byte
+	existingData []byte
+}
+
+// prepareFileMove validates a rename or copy request and reads the source
+// file and, when it is overwritten, the destination file. Both files stay
+// locked until the caller calls releaseFileMove.
+func (s *Server) prepareFileMove(w http.ResponseWriter, r *http.Request) (*fileMove, bool) {
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return nil, false
+	}
+
+	var req FileMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			errors = append(errors, err.Field()+" is required")
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return nil, false
+	}
+
+	sourcePath, ok := s.resolveRequestPath(w, req.Source, "file-system")
+	if !ok {
+		return nil, false
+	}
+
+	destinationPath, ok := s.resolveRequestPath(w, req.Destination, "file-system")
+	if !ok {
+		return nil, false
+	}
+
+	if sourcePath == destinationPath {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Source and destination are the same file"},
+		})
+		return nil, false
+	}
+
+	if req.ChangeSetID != "" && !s.requireOpenChangeSet(w, req.ChangeSetID) {
+		return nil, false
+	}
+
+	info, err := s.fileEditor.Stat(sourcePath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Source file not found"},
+			})
+			return nil, false
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
+			"context":   "file-system",
+			"errors":    []string{"Source is a directory, not a file"},
+		})
+		return nil, false
+	}
+
+	dirInfo, err := s.fileEditor.Stat(filepath.Dir(destinationPath))
+	if err != nil || !dirInfo.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Destination directory not found"},
+		})
+		return nil, false
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	move := &fileMove{
+		source: &fileUpdateContext{
+			userID:      userID,
+			sessionID:   sessionID,
+			cleanPath:   sourcePath,
+			info:        info,
+			fileID:      utils.GenerateMD5Hash(sourcePath),
+			changeSetID: req.ChangeSetID,
+		},
+		destination: &fileUpdateContext{
+			userID:      userID,
+			sessionID:   sessionID,
+			cleanPath:   destinationPath,
+			fileID:      utils.GenerateMD5Hash(destinationPath),
+			changeSetID: req.ChangeSetID,
+		},
+		changeSetID: req.ChangeSetID,
+	}
+
+	if !s.lockFileForUpdate(w, move.source) {
+		return nil, false
+	}
+
+	if !s.lockFileForUpdate(w, move.destination) {
+		s.releaseFileLock(move.source.lock)
+		return nil, false
+	}
+
+	data, err := s.fileEditor.ReadFile(sourcePath)
+	if err != nil {
+		s.releaseFileMove(move)
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	move.data = data
+	move.existingData, ok = s.prepareFileTarget(w, r, move.destination, req.Overwrite)
+	if !ok {
+		s.releaseFileMove(move)
+		return nil, false
+	}
+
+	return move, true
+}
+
+func (s *Server) releaseFileMove(move *fileMove) {
+	s.releaseFileLock(move.destination.lock)
+	s.releaseFileLock(move.source.lock)
+}
+
+// prepareFileTarget checks the file a new file is written to, under the file
+// lock of ctx. When it exists and may be overwritten, the request must carry
+// its ETag in If-Match; its content is returned and the write is recorded as
+// an edit. Otherwise the write is recorded as creating the file.
+func (s *Server) prepareFileTarget(w http.ResponseWriter, r *http.Request, ctx *fileUpdateContext, overwrite bool) ([]byte, bool) {
+	info, err := s.fileEditor.Stat(ctx.cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			ctx.operation = revisionOperationCreate
+			return nil, true
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
+			"context":   "file-system",
+			"errors":    []string{"Destination is a directory, not a file"},
+		})
+		return nil, false
+	}
+
+	if !overwrite {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"File " + ctx.cleanPath + " already exists"},
+		})
+		return nil, false
+	}
+
+	data, err := s.fileEditor.ReadFile(ctx.cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if !s.requireFileIfMatch(w, r, data, func() (interface{}, error) {
+		return map[string]interface{}{
+			"path":          ctx.cleanPath,
+			"size":          info.Size(),
+			"last_modified": info.ModTime(),
+		}, nil
+	}) {
+		return nil, false
+	}
+
+	ctx.info = info
+	return data, true
+}
+
+func (s *Server) writeFileWithRevision(w http.ResponseWriter, ctx *fileUpdateContext, previousData []byte, data []byte, perm os.FileMode) (int64, bool) {
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, data)
+	if !ok {
+		return 0, false
+	}
+
+	if ctx.info != nil {
+		perm = ctx.info.Mode().Perm()
+	}
+
+	if err := s.fileEditor.WriteFile(ctx.cleanPath, data, perm); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return 0, false
+	}
+
+	return revisionID, true
+}
+
+// deleteFileWithRevision records data as the tombstone copy of the file
+// before removing it.
+func (s *Server) deleteFileWithRevision(w http.ResponseWriter, ctx *fileUpdateContext, data []byte) (int64, bool) {
+	ctx.operation = revisionOperationDelete
+
+	revisionID, ok := s.createFileRevision(w, ctx, data, nil)
+	if !ok {
+		return 0, false
+	}
+
+	if err := s.fileEditor.Remove(ctx.cleanPath); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to delete file: " + err.Error()},
+		})
+		return 0, false
+	}
+
+	return revisionID, true
+}
+
+// restoreRevisionState returns the file to the state it had before revision
+// and records that as a new revision, under the file lock. Undoing a create
+// removes the file, keeping a tombstone copy, and a file that no longer exists
+// is recreated.
+func (s *Server) restoreRevisionState(ctx *fileUpdateContext, revision db.FileRevision, revisionData []byte) (int64, error) {
+	if ctx.lock == nil {
+		lock, err := s.acquireFileLock(ctx)
+		if err != nil {
+			return 0, &fileRevisionError{status: http.StatusConflict, message: err.Error()}
+		}
+
+		ctx.lock = lock
+		defer s.releaseFileLock(lock)
+	}
+
+	var currentData []byte
+	info, err := s.fileEditor.Stat(ctx.cleanPath)
+	switch {
+	case err == nil:
+		if info.IsDir() {
+			return 0, &fileRevisionError{status: http.StatusBadRequest, message: "Path is a directory, not a file"}
+		}
+
+		currentData, err = s.fileEditor.ReadFile(ctx.cleanPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read file: %w", err)
+		}
+	case s.fileEditor.IsNotExist(err):
+		info = nil
+	default:
+		return 0, fmt.Errorf("cannot read file: %w", err)
+	}
+
+	remove := revision.Operation == revisionOperationCreate
+	ctx.info = info
+	switch {
+	case info == nil && remove:
+		return 0, &fileRevisionError{status: http.StatusBadRequest, message: "No changes detected. The file does not exist."}
+	case info == nil:
+		ctx.operation = revisionOperationCreate
+	case remove:
+		ctx.operation = revisionOperationDelete
+		revisionData = nil
+	}
+
+	revisionID, err := s.recordFileRevision(ctx, currentData, revisionData)
+	if err != nil {
+		return 0, err
+	}
+
+	// The revision is returned with the error, as it was recorded although
+	// the file was not restored.
+	if err := s.applyRevisionContent(ctx.cleanPath, revisionData, remove); err != nil {
+		return revisionID, err
+	}
+
+	return revisionID, nil
+}
+
+// applyRevisionContent writes data to path, recreating its directory if it
+// was removed, or removes the file when remove is set.
+func (s *Server) applyRevisionContent(path string, data []byte, remove bool) error {
+	if remove {
+		if err := s.fileEditor.Remove(path); err != nil && !s.fileEditor.IsNotExist(err) {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := s.fileEditor.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := s.fileEditor.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+type FileMoveRequest struct {
+	Source      string `json:"source" validate:"required"`
+	Destination string `json:"destination" validate:"required"`
+	Overwrite   bool   `json:"overwrite"`
+	ChangeSetID string `json:"change_set_id"`
+}
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	defaultFileSearchLimit = 100
+	maxFileSearchLimit     = 1000
+	// maxTextMatchesPerFile caps the matching lines returned for one file.
+	maxTextMatchesPerFile = 20
+	// maxContentSearchFileSize is the largest text file read for a content
+	// search; larger files are reported in errors and skipped.
+	maxContentSearchFileSize = 16 * 1024 * 1024
+)
+
+func (s *Server) handleFileSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	criteria, err := parseFileSearchCriteria(r)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if criteria.IsEmpty() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"At least one search criterion is required"},
+		})
+		return
+	}
+
+	limit := defaultFileSearchLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxFileSearchLimit {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Limit must be between 1 and " + strconv.Itoa(maxFileSearchLimit)},
+			})
+			return
+		}
+	}
+
+	showDotfiles, _ := strconv.ParseBool(r.URL.Query().Get("show_dotfiles"))
+
+	var roots []string
+	if r.URL.Query().Get("path") != "" {
+		dirPath, ok := s.resolveRequestDirectory(w, r)
+		if !ok {
+			return
+		}
+
+		roots = []string{dirPath}
+	} else if s.pathResolver.IsRestricted() {
+		roots = s.pathResolver.Roots()
+	} else {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusForbidden, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathNotAllowed,
+			"context":   "file-system",
+			"errors":    []string{noAllowedRootsMessage},
+		})
+		return
+	}
+
+	search := &fileSearch{
+		criteria:     criteria,
+		limit:        limit,
+		showDotfiles: showDotfiles,
+		response: FileSearchResponse{
+			Results: []FileSearchResult{},
+			Errors:  []NPCTableError{},
+		},
+	}
+	search.revisionsDir, _ = filepath.Abs(s.cfg.RevisionsDirectory)
+
+	for _, root := range roots {
+		if search.response.Truncated {
+			break
+		}
+
+		s.searchDirectory(search, root)
+	}
+
+	_ = utils.WriteJSONResponse(w, search.response)
+}
+
+type fileSearch struct {
+	criteria     *services.FileSearchCriteria
+	limit        int
+	showDotfiles bool
+	revisionsDir string
+	response     FileSearchResponse
+}
+
+// searchDirectory walks root and appends the files matching the search until
+// the limit is reached. Directories that cannot be read are reported in the
+// response errors and skipped.
+func (s *Server) searchDirectory(search *fileSearch, root string) {
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			search.response.Errors = append(search.response.Errors, NPCTableError{Path: path, Error: err.Error()})
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if path == root {
+			return nil
+		}
+
+		if !search.showDotfiles && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			if absPath, err := filepath.Abs(path); err == nil && absPath == search.revisionsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+
+		fileType := s.fileEditor.GetFileType(path, info)
+		if !search.criteria.MatchesMetadata(entry.Name(), info, fileType) {
+			return nil
+		}
+
+		// Links pointing outside the allowed roots are skipped.
+		if _, err := s.pathResolver.Resolve(path); err != nil {
+			return nil
+		}
+
+		result, ok := s.matchFileContent(search, path, info)
+		if !ok {
+			return nil
+		}
+
+		relative, _ := filepath.Rel(root, path)
+		result.FileNode = s.createNodeFromEntry(filepath.Dir(path), entry, strings.Count(relative, string(filepath.Separator))+1)
+		result.FileNode.Path = path
+
+		search.response.Results = append(search.response.Results, result)
+		if len(search.response.Results) >= search.limit {
+			search.response.Truncated = true
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+}
+
+// matchFileContent checks the content and NPC criteria, which need the file to
+// be read. Files that cannot be read are reported in the response errors.
+func (s *Server) matchFileContent(search *fileSearch, path string, info fs.FileInfo) (FileSearchResult, bool) {
+	var result FileSearchResult
+
+	if search.criteria.Content != "" {
+		if info.Size() > maxContentSearchFileSize {
+			search.response.Errors = append(search.response.Errors, NPCTableError{Path: path, Error: "file is too large to search"})
+			return result, false
+		}
+
+		data, err := s.fileEditor.ReadFile(path)
+		if err != nil {
+			search.response.Errors = append(search.response.Errors, NPCTableError{Path: path, Error: err.Error()})
+			return result, false
+		}
+
+		result.Matches = services.FindTextMatches(data, search.criteria.Content, maxTextMatchesPerFile)
+		if len(result.Matches) == 0 {
+			return result, false
+		}
+	}
+
+	if search.criteria.SearchesNPCData() {
+		npcData, err := s.fileEditor.ReadNPCFileData(path)
+		if err != nil {
+			search.response.Errors = append(search.response.Errors, NPCTableError{Path: path, Error: err.Error()})
+			return result, false
+		}
+
+		if !search.criteria.MatchesNPC(npcData) {
+			return result, false
+		}
+
+		result.NPCID = &npcData.Id
+		result.NPCName = utils.ReadStringFromBytes(npcData.Name[:])
+	}
+
+	return result, true
+}
+
+func parseFileSearchCriteria(r *http.Request) (*services.FileSearchCriteria, error) {
+	query := r.URL.Query()
+	criteria := &services.FileSearchCriteria{
+		NamePattern: query.Get("name"),
+		FileType:    services.FileType(query.Get("type")),
+		Content:     query.Get("content"),
+		NPCName:     query.Get("npc_name"),
+	}
+
+	if criteria.NamePattern != "" {
+		if _, err := filepath.Match(criteria.NamePattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid name pattern %s", criteria.NamePattern)
+		}
+	}
+
+	switch criteria.FileType {
+	case "", services.FileTypeNPC, services.FileTypeDrop, services.FileTypeMap, services.FileTypeSpawn, services.FileTypeText, services.FileTypeUnknown:
+	default:
+		return nil, fmt.Errorf("unknown file type %s", criteria.FileType)
+	}
+
+	var err error
+	if criteria.MinSize, err = parseOptionalInt64(query.Get("min_size"), "min_size"); err != nil {
+		return nil, err
+	}
+
+	if criteria.MaxSize, err = parseOptionalInt64(query.Get("max_size"), "max_size"); err != nil {
+		return nil, err
+	}
+
+	if criteria.ModifiedAfter, err = parseOptionalTime(query.Get("modified_after"), "modified_after"); err != nil {
+		return nil, err
+	}
+
+	if criteria.ModifiedBefore, err = parseOptionalTime(query.Get("modified_before"), "modified_before"); err != nil {
+		return nil, err
+	}
+
+	if npcIDParam := query.Get("npc_id"); npcIDParam != "" {
+		npcID, err := strconv.ParseUint(npcIDParam, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid npc_id %s", npcIDParam)
+		}
+
+		id := uint16(npcID)
+		criteria.NPCID = &id
+	}
+
+	return criteria, nil
+}
+
+func parseOptionalInt64(value string, name string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return nil, fmt.Errorf("invalid %s %s", name, value)
+	}
+
+	return &parsed, nil
+}
+
+func parseOptionalTime(value string, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %s, expected an RFC 3339 timestamp", name, value)
+	}
+
+	return &parsed, nil
+}
+
+type FileSearchResult struct {
+	*FileNode
+	Matches []services.TextMatch `json:"matches,omitempty"`
+	NPCID   *uint16              `json:"npc_id,omitempty"`
+	NPCName string               `json:"npc_name,omitempty"`
+}
+
+type FileSearchResponse struct {
+	Results   []FileSearchResult `json:"results"`
+	Truncated bool               `json:"truncated"`
+	Errors    []NPCTableError    `json:"errors"`
+}
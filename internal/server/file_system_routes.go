@@ -4,19 +4,21 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"mime"
 	"net/http"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
 	"github.com/omnihance/omnihance-a3-agent/internal/logger"
 	"github.com/omnihance/omnihance-a3-agent/internal/mw"
 	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
@@ -34,8 +36,41 @@ func (s *Server) InitializeFileSystemRoutes(r *chi.Mux) {
 		r.Put("/text-file", s.handleUpdateTextFile)
 		r.Get("/spawn-file", s.handleSpawnFileData)
 		r.Put("/spawn-file", s.handleUpdateSpawnFile)
+		r.Get("/spawn-file/summary", s.handleSpawnSummary)
+		r.Get("/drop-file", s.handleDropFileData)
+		r.Put("/drop-file", s.handleUpdateDropFile)
+		r.Get("/map-file", s.handleMapFileData)
+		r.Put("/map-file", s.handleUpdateMapFile)
+		r.Get("/hex", s.handleHexView)
+		r.Patch("/hex", s.handleHexPatch)
+		r.Post("/upload", s.handleUploadFile)
+		r.Get("/download", s.handleDownloadFile)
+		r.Post("/rename", s.handleRenameFile)
+		r.Post("/copy", s.handleCopyFile)
+		r.Delete("/file", s.handleDeleteFile)
+		r.Get("/search", s.handleFileSearch)
+		r.Get("/events", s.handleFileEvents)
+		r.Get("/archive", s.handleExportArchive)
+		r.Post("/archive", s.handleImportArchive)
 		r.Post("/revert-file", s.handleRevertFile)
 		r.Get("/revision-summary", s.handleRevisionSummary)
+		r.Get("/revision-integrity", s.handleGetRevisionIntegrityReport)
+		r.Post("/revision-integrity", s.handleVerifyRevisions)
+		r.Get("/revisions", s.handleListFileRevisions)
+		r.Get("/revisions/{revisionID}/download", s.handleDownloadFileRevision)
+		r.Post("/revisions/{revisionID}/revert", s.handleRevertFileToRevision)
+		r.Get("/diff", s.handleFileDiff)
+		r.Get("/npc-table", s.handleNPCTable)
+		r.Post("/npc-table/batch", s.handleNPCBatchUpdate)
+		r.Get("/export", s.handleExportData)
+		r.Post("/import", s.handleImportData)
+		r.Get("/change-sets", s.handleListChangeSets)
+		r.Post("/change-sets", s.handleCreateChangeSet)
+		r.Get("/change-sets/{changeSetID}", s.handleGetChangeSet)
+		r.Post("/change-sets/{changeSetID}/commit", s.handleCommitChangeSet)
+		r.Post("/change-sets/{changeSetID}/revert", s.handleRevertChangeSet)
+		r.Get("/locks", s.handleListFileLocks)
+		r.Delete("/locks/{fileID}", s.handleReleaseFileLock)
 	})
 }
 
@@ -47,9 +82,22 @@ func (s *Server) handleFileTree(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	if pathParam == "" {
-		rootNode, err = s.getSystemRoots(showDotfiles)
+		if !s.pathResolver.IsRestricted() {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusForbidden, map[string]interface{}{
+				"errorCode": constants.ErrorCodePathNotAllowed,
+				"context":   "file-system",
+				"errors":    []string{noAllowedRootsMessage},
+			})
+			return
+		}
+
+		rootNode, err = s.getSystemRoots()
 	} else {
-		cleanPath := filepath.Clean(pathParam)
+		cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+		if !ok {
+			return
+		}
+
 		rootNode, err = s.getDirectoryNode(cleanPath, showDotfiles)
 	}
 
@@ -79,7 +127,9 @@ func (s *Server) handleFileTree(w http.ResponseWriter, r *http.Request) {
 	_ = utils.WriteJSONResponse(w, response)
 }
 
-func (s *Server) getSystemRoots(showDotfiles bool) (*FileNode, error) {
+// getSystemRoots lists the allowed roots. Without ALLOWED_ROOTS the tree is
+// not listed at all, as every file request is denied.
+func (s *Server) getSystemRoots() (*FileNode, error) {
 	hostname, _ := s.fileEditor.Hostname()
 	if hostname == "" {
 		hostname = "A3 Online Server"
@@ -93,38 +143,23 @@ func (s *Server) getSystemRoots(showDotfiles bool) (*FileNode, error) {
 		Children: []*FileNode{},
 	}
 
-	if runtime.GOOS == "windows" {
-		for _, drive := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
-			drivePath := string(drive) + ":\\"
-			if info, err := s.fileEditor.Stat(drivePath); err == nil {
-				modTime := info.ModTime()
-				node := &FileNode{
-					ID:           utils.GenerateMD5Hash(drivePath),
-					Name:         string(drive) + ":",
-					Kind:         "directory",
-					Depth:        1,
-					LastModified: &modTime,
-					Permissions:  info.Mode().String(),
-					Children:     []*FileNode{},
-				}
-				root.Children = append(root.Children, node)
-			}
-		}
-	} else {
-		rootPath := "/"
-		entries, err := s.fileEditor.ReadDir(rootPath)
-		if err != nil {
-			return nil, err
+	for _, rootPath := range s.pathResolver.Roots() {
+		info, err := s.fileEditor.Stat(rootPath)
+		if err != nil || !info.IsDir() {
+			continue
 		}
 
-		for _, entry := range entries {
-			if !showDotfiles && len(entry.Name()) > 0 && entry.Name()[0] == '.' {
-				continue
-			}
-
-			node := s.createNodeFromEntry(rootPath, entry, 1)
-			root.Children = append(root.Children, node)
-		}
+		modTime := info.ModTime()
+		root.Children = append(root.Children, &FileNode{
+			ID:           utils.GenerateMD5Hash(rootPath),
+			Name:         rootPath,
+			Path:         rootPath,
+			Kind:         "directory",
+			Depth:        1,
+			LastModified: &modTime,
+			Permissions:  info.Mode().String(),
+			Children:     []*FileNode{},
+		})
 	}
 
 	return root, nil
@@ -225,7 +260,11 @@ func (s *Server) handleNPCFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
 	info, err := s.fileEditor.Stat(cleanPath)
 
 	if err != nil {
@@ -265,7 +304,7 @@ func (s *Server) handleNPCFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	npcData, err := s.fileEditor.ReadNPCFileData(cleanPath)
+	content, err := s.fileEditor.ReadFile(cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeFileReadError,
@@ -275,45 +314,35 @@ func (s *Server) handleNPCFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := npcData.Id
-	respawnRate := npcData.RespawnRate
-	attackTypeInfo := npcData.AttackTypeInfo
-	targetSelectionInfo := npcData.TargetSelectionInfo
-	defense := npcData.Defense
-	additionalDefense := npcData.AdditionalDefense
-	attackSpeedLow := npcData.AttackSpeedLow
-	attackSpeedHigh := npcData.AttackSpeedHigh
-	movementSpeed := npcData.MovementSpeed
-	level := npcData.Level
-	playerExp := npcData.PlayerExp
-	appearance := npcData.Appearance
-	hp := npcData.HP
-	blueAttackDefense := npcData.BlueAttackDefense
-	redAttackDefense := npcData.RedAttackDefense
-	greyAttackDefense := npcData.GreyAttackDefense
-	mercenaryExp := npcData.MercenaryExp
-	apiData := NPCFileAPIData{
-		Name:                utils.ReadStringFromBytes(npcData.Name[:]),
-		Id:                  &id,
-		RespawnRate:         &respawnRate,
-		AttackTypeInfo:      &attackTypeInfo,
-		TargetSelectionInfo: &targetSelectionInfo,
-		Defense:             &defense,
-		AdditionalDefense:   &additionalDefense,
-		Attacks:             npcData.Attacks[:],
-		AttackSpeedLow:      &attackSpeedLow,
-		AttackSpeedHigh:     &attackSpeedHigh,
-		MovementSpeed:       &movementSpeed,
-		Level:               &level,
-		PlayerExp:           &playerExp,
-		Appearance:          &appearance,
-		HP:                  &hp,
-		BlueAttackDefense:   &blueAttackDefense,
-		RedAttackDefense:    &redAttackDefense,
-		GreyAttackDefense:   &greyAttackDefense,
-		MercenaryExp:        &mercenaryExp,
+	npcData, err := s.fileEditor.ReadNPCFileBytes(content)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read NPC file data: " + err.Error()},
+		})
+		return
+	}
+
+	apiData := newNPCFileAPIData(npcData)
+
+	if resolve, _ := strconv.ParseBool(r.URL.Query().Get("resolve")); resolve {
+		monsterNames, err := resolveClientDataNames([]int64{int64(npcData.Id)}, s.internalDB.GetMonsterClientDataByIDs)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to resolve monster name: " + err.Error()},
+			})
+			return
+		}
+
+		monsterName, found := monsterNames[int64(npcData.Id)]
+		apiData.MonsterName = &monsterName
+		apiData.MonsterFound = &found
 	}
 
+	w.Header().Set("ETag", fileETag(content))
 	_ = utils.WriteJSONResponse(w, apiData)
 }
 
@@ -329,7 +358,11 @@ func (s *Server) handleTextFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
 	info, err := s.fileEditor.Stat(cleanPath)
 
 	if err != nil {
@@ -383,6 +416,7 @@ func (s *Server) handleTextFileData(w http.ResponseWriter, r *http.Request) {
 		Content: string(content),
 	}
 
+	w.Header().Set("ETag", fileETag(content))
 	_ = utils.WriteJSONResponse(w, apiData)
 }
 
@@ -397,7 +431,11 @@ func (s *Server) handleSpawnFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
 	info, err := s.fileEditor.Stat(cleanPath)
 	if err != nil {
 		if s.fileEditor.IsNotExist(err) {
@@ -436,7 +474,7 @@ func (s *Server) handleSpawnFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	spawnData, err := s.fileEditor.ReadSpawnFileData(cleanPath)
+	content, err := s.fileEditor.ReadFile(cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeFileReadError,
@@ -446,42 +484,69 @@ func (s *Server) handleSpawnFileData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apiSpawns := make([]NPCSpawnAPIData, len(spawnData))
-	for i, spawn := range spawnData {
-		id := spawn.Id
-		x := spawn.X
-		y := spawn.Y
-		unknown1 := spawn.Unknown1
-		orientation := spawn.Orientation
-		spwanStep := spawn.SpwanStep
-		apiSpawns[i] = NPCSpawnAPIData{
-			Id:          &id,
-			X:           &x,
-			Y:           &y,
-			Unknown1:    &unknown1,
-			Orientation: &orientation,
-			SpwanStep:   &spwanStep,
-		}
+	spawnData, err := s.fileEditor.ReadSpawnFileBytes(content)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read spawn file data: " + err.Error()},
+		})
+		return
 	}
 
-	apiData := SpawnFileAPIData{
-		Spawns: apiSpawns,
+	apiData := newSpawnFileAPIData(spawnData)
+
+	if resolve, _ := strconv.ParseBool(r.URL.Query().Get("resolve")); resolve {
+		monsterIDs := make([]int64, len(spawnData))
+		for i, spawn := range spawnData {
+			monsterIDs[i] = int64(spawn.Id)
+		}
+
+		monsterNames, err := resolveClientDataNames(monsterIDs, s.internalDB.GetMonsterClientDataByIDs)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to resolve monster names: " + err.Error()},
+			})
+			return
+		}
+
+		missingMonsters := make(map[int64]bool)
+		for i := range apiData.Spawns {
+			monsterID := int64(*apiData.Spawns[i].Id)
+			monsterName, found := monsterNames[monsterID]
+			apiData.Spawns[i].MonsterName = &monsterName
+			apiData.Spawns[i].MonsterFound = &found
+			if !found && !missingMonsters[monsterID] {
+				missingMonsters[monsterID] = true
+				apiData.MissingMonsterIDs = append(apiData.MissingMonsterIDs, monsterID)
+			}
+		}
+
+		if mapID, ok := mapIDFromPath(cleanPath); ok {
+			mapNames, err := resolveClientDataNames([]int64{mapID}, s.internalDB.GetMapClientDataByIDs)
+			if err != nil {
+				_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+					"errorCode": constants.ErrorCodeInternalServerError,
+					"context":   "file-system",
+					"errors":    []string{"Failed to resolve map name: " + err.Error()},
+				})
+				return
+			}
+
+			mapName, found := mapNames[mapID]
+			apiData.MapID = &mapID
+			apiData.MapName = &mapName
+			apiData.MapFound = &found
+		}
 	}
 
+	w.Header().Set("ETag", fileETag(content))
 	_ = utils.WriteJSONResponse(w, apiData)
 }
 
-func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Request, expectedFileType services.FileType, fileTypeName string) (*fileUpdateContext, bool) {
-	userID, ok := utils.GetUserIdFromContext(r.Context())
-	if !ok {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
-			"errorCode": constants.ErrorCodeUnauthorized,
-			"context":   "file-system",
-			"errors":    []string{"User ID not found in context"},
-		})
-		return nil, false
-	}
-
+func (s *Server) handleDropFileData(w http.ResponseWriter, r *http.Request) {
 	pathParam := r.URL.Query().Get("path")
 	if pathParam == "" {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
@@ -489,10 +554,14 @@ func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Reques
 			"context":   "file-system",
 			"errors":    []string{"Path parameter is required"},
 		})
-		return nil, false
+		return
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
 	info, err := s.fileEditor.Stat(cleanPath)
 	if err != nil {
 		if s.fileEditor.IsNotExist(err) {
@@ -501,7 +570,7 @@ func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Reques
 				"context":   "file-system",
 				"errors":    []string{"Path not found"},
 			})
-			return nil, false
+			return
 		}
 
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -509,7 +578,7 @@ func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Reques
 			"context":   "file-system",
 			"errors":    []string{"Cannot read file: " + err.Error()},
 		})
-		return nil, false
+		return
 	}
 
 	if info.IsDir() {
@@ -518,170 +587,542 @@ func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Reques
 			"context":   "file-system",
 			"errors":    []string{"Path is a directory, not a file"},
 		})
-		return nil, false
+		return
 	}
 
 	fileType := s.fileEditor.GetFileType(cleanPath, info)
-	if fileType != expectedFileType {
+	if fileType != services.FileTypeDrop {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
 			"errorCode": constants.ErrorCodeFileNotViewable,
 			"context":   "file-system",
-			"errors":    []string{"File is not a " + fileTypeName + " file"},
+			"errors":    []string{"File is not a drop file"},
 		})
-		return nil, false
+		return
 	}
 
-	if !s.fileEditor.IsFileEditable(cleanPath, info) {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
-			"errorCode": constants.ErrorCodeBadRequest,
+	content, err := s.fileEditor.ReadFile(cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"File is not editable"},
+			"errors":    []string{"Failed to read drop file data: " + err.Error()},
 		})
-		return nil, false
+		return
 	}
 
-	return &fileUpdateContext{
-		userID:    userID,
-		cleanPath: cleanPath,
-		info:      info,
-		fileID:    utils.GenerateMD5Hash(cleanPath),
-	}, true
-}
-
-func (s *Server) createFileRevision(w http.ResponseWriter, ctx *fileUpdateContext, previousData []byte, currentData []byte) (int64, bool) {
-	previousHash := utils.CalculateFileHash(previousData)
-	currentHash := utils.CalculateFileHash(currentData)
-
-	if previousHash == currentHash {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
-			"errorCode": constants.ErrorCodeBadRequest,
+	dropData, err := s.fileEditor.ReadDropFileBytes(content)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"No changes detected. The file content is identical to the existing content."},
+			"errors":    []string{"Failed to read drop file data: " + err.Error()},
 		})
-		return 0, false
+		return
 	}
 
-	lockPath, err := s.acquireFileLock(ctx.fileID)
-	if err != nil {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+	apiData := newDropFileAPIData(dropData)
+
+	if resolve, _ := strconv.ParseBool(r.URL.Query().Get("resolve")); resolve {
+		itemIDs := make([]int64, len(dropData.Items))
+		for i, item := range dropData.Items {
+			itemIDs[i] = int64(item.ItemId)
+		}
+
+		itemNames, err := resolveClientDataNames(itemIDs, s.internalDB.GetItemClientDataByIDs)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to resolve item names: " + err.Error()},
+			})
+			return
+		}
+
+		missingItems := make(map[int64]bool)
+		for i := range apiData.Items {
+			itemID := int64(*apiData.Items[i].ItemId)
+			itemName, found := itemNames[itemID]
+			apiData.Items[i].ItemName = &itemName
+			apiData.Items[i].ItemFound = &found
+			if !found && !missingItems[itemID] {
+				missingItems[itemID] = true
+				apiData.MissingItemIDs = append(apiData.MissingItemIDs, itemID)
+			}
+		}
+	}
+
+	w.Header().Set("ETag", fileETag(content))
+	_ = utils.WriteJSONResponse(w, apiData)
+}
+
+func (s *Server) handleMapFileData(w http.ResponseWriter, r *http.Request) {
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
 			"errorCode": constants.ErrorCodeBadRequest,
 			"context":   "file-system",
-			"errors":    []string{err.Error()},
+			"errors":    []string{"Path parameter is required"},
 		})
-		return 0, false
+		return
 	}
 
-	defer s.releaseFileLock(lockPath)
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
 
-	tx, err := s.internalDB.BeginTx()
+	info, err := s.fileEditor.Stat(cleanPath)
 	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"Failed to begin transaction: " + err.Error()},
+			"errors":    []string{"Cannot read file: " + err.Error()},
 		})
-		return 0, false
+		return
 	}
 
-	defer func() {
-		if err != nil {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				s.log.Error("Failed to rollback transaction", logger.Field{Key: "error", Value: rollbackErr})
-			}
-		}
-	}()
-
-	revisionID, err := s.internalDB.CreateFileRevision(tx, ctx.fileID, ctx.cleanPath, "", previousHash, currentHash, ctx.userID)
-	if err != nil {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
 			"context":   "file-system",
-			"errors":    []string{"Failed to create revision: " + err.Error()},
+			"errors":    []string{"Path is a directory, not a file"},
 		})
-		return 0, false
+		return
 	}
 
-	revisionDir := filepath.Join(s.cfg.RevisionsDirectory, ctx.fileID, strconv.FormatInt(revisionID, 10))
-	if err = s.fileEditor.MkdirAll(revisionDir, 0755); err != nil {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+	fileType := s.fileEditor.GetFileType(cleanPath, info)
+	if fileType != services.FileTypeMap {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileNotViewable,
 			"context":   "file-system",
-			"errors":    []string{"Failed to create revision directory: " + err.Error()},
+			"errors":    []string{"File is not a map file"},
 		})
-		return 0, false
+		return
 	}
 
-	epochTime := time.Now().Unix()
-	fileName := filepath.Base(ctx.cleanPath)
-	revisionFileName := strconv.FormatInt(epochTime, 10) + "_" + fileName
-	revisionPath := filepath.Join(revisionDir, revisionFileName)
-	if err = s.fileEditor.WriteFile(revisionPath, previousData, 0644); err != nil {
-		if removeErr := s.fileEditor.RemoveAll(revisionDir); removeErr != nil {
-			s.log.Error("Failed to remove revision directory during cleanup", logger.Field{Key: "error", Value: removeErr})
-		}
-
+	content, err := s.fileEditor.ReadFile(cleanPath)
+	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"Failed to save revision copy: " + err.Error()},
+			"errors":    []string{"Failed to read map file data: " + err.Error()},
 		})
-		return 0, false
+		return
 	}
 
-	if err = s.internalDB.UpdateFileRevisionPath(tx, revisionID, revisionPath, ctx.userID); err != nil {
-		if removeErr := s.fileEditor.Remove(revisionPath); removeErr != nil {
-			s.log.Error("Failed to remove revision file during cleanup", logger.Field{Key: "error", Value: removeErr})
-		}
-
-		if removeErr := s.fileEditor.RemoveAll(revisionDir); removeErr != nil {
-			s.log.Error("Failed to remove revision directory during cleanup", logger.Field{Key: "error", Value: removeErr})
-		}
-
+	mapData, err := s.fileEditor.ReadMapFileBytes(content)
+	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"Failed to update revision path: " + err.Error()},
+			"errors":    []string{"Failed to read map file data: " + err.Error()},
 		})
-		return 0, false
+		return
 	}
 
-	if err = s.internalDB.UpdateFileRevisionStatus(tx, revisionID, "completed", ctx.userID); err != nil {
-		if removeErr := s.fileEditor.Remove(revisionPath); removeErr != nil {
-			s.log.Error("Failed to remove revision file during cleanup", logger.Field{Key: "error", Value: removeErr})
-		}
+	w.Header().Set("ETag", fileETag(content))
+	_ = utils.WriteJSONResponse(w, newMapFileAPIData(mapData))
+}
+
+func newNPCFileAPIData(npcData *services.NPCFileData) NPCFileAPIData {
+	id := npcData.Id
+	respawnRate := npcData.RespawnRate
+	attackTypeInfo := npcData.AttackTypeInfo
+	targetSelectionInfo := npcData.TargetSelectionInfo
+	defense := npcData.Defense
+	additionalDefense := npcData.AdditionalDefense
+	attackSpeedLow := npcData.AttackSpeedLow
+	attackSpeedHigh := npcData.AttackSpeedHigh
+	movementSpeed := npcData.MovementSpeed
+	level := npcData.Level
+	playerExp := npcData.PlayerExp
+	appearance := npcData.Appearance
+	hp := npcData.HP
+	blueAttackDefense := npcData.BlueAttackDefense
+	redAttackDefense := npcData.RedAttackDefense
+	greyAttackDefense := npcData.GreyAttackDefense
+	mercenaryExp := npcData.MercenaryExp
+	return NPCFileAPIData{
+		Name:                utils.ReadStringFromBytes(npcData.Name[:]),
+		Id:                  &id,
+		RespawnRate:         &respawnRate,
+		AttackTypeInfo:      &attackTypeInfo,
+		TargetSelectionInfo: &targetSelectionInfo,
+		Defense:             &defense,
+		AdditionalDefense:   &additionalDefense,
+		Attacks:             npcData.Attacks[:],
+		AttackSpeedLow:      &attackSpeedLow,
+		AttackSpeedHigh:     &attackSpeedHigh,
+		MovementSpeed:       &movementSpeed,
+		Level:               &level,
+		PlayerExp:           &playerExp,
+		Appearance:          &appearance,
+		HP:                  &hp,
+		BlueAttackDefense:   &blueAttackDefense,
+		RedAttackDefense:    &redAttackDefense,
+		GreyAttackDefense:   &greyAttackDefense,
+		MercenaryExp:        &mercenaryExp,
+	}
+}
+
+func newNPCSpawnAPIData(spawn services.NPCSpawnData) NPCSpawnAPIData {
+	id := spawn.Id
+	x := spawn.X
+	y := spawn.Y
+	unknown1 := spawn.Unknown1
+	orientation := spawn.Orientation
+	spwanStep := spawn.SpwanStep
+	return NPCSpawnAPIData{
+		Id:          &id,
+		X:           &x,
+		Y:           &y,
+		Unknown1:    &unknown1,
+		Orientation: &orientation,
+		SpwanStep:   &spwanStep,
+	}
+}
+
+func newSpawnFileAPIData(spawnData []services.NPCSpawnData) SpawnFileAPIData {
+	apiSpawns := make([]NPCSpawnAPIData, len(spawnData))
+	for i, spawn := range spawnData {
+		apiSpawns[i] = newNPCSpawnAPIData(spawn)
+	}
+
+	return SpawnFileAPIData{
+		Spawns: apiSpawns,
+	}
+}
 
-		if removeErr := s.fileEditor.RemoveAll(revisionDir); removeErr != nil {
-			s.log.Error("Failed to remove revision directory during cleanup", logger.Field{Key: "error", Value: removeErr})
+func newDropFileAPIData(dropData *services.DropFileData) DropFileAPIData {
+	apiItems := make([]DropItemAPIData, len(dropData.Items))
+	for i, item := range dropData.Items {
+		itemId := item.ItemId
+		unknown1 := item.Unknown1
+		dropRate := item.DropRate
+		unknown2 := item.Unknown2
+		apiItems[i] = DropItemAPIData{
+			ItemId:   &itemId,
+			Unknown1: &unknown1,
+			DropRate: &dropRate,
+			Unknown2: &unknown2,
 		}
+	}
 
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+	return DropFileAPIData{
+		Items: apiItems,
+	}
+}
+
+// newMapFileAPIData splits the cells of mapData into rows. It never makes more
+// rows than the cells fill, whatever height the map claims.
+func newMapFileAPIData(mapData *services.MapFileData) MapFileAPIData {
+	width := mapData.Width
+	height := mapData.Height
+
+	var rows uint64
+	if width > 0 {
+		rows = min(uint64(height), uint64(len(mapData.Cells))/uint64(width))
+	}
+
+	cells := make([]MapCellRow, rows)
+	for y := range cells {
+		start := uint64(y) * uint64(width)
+		cells[y] = MapCellRow(mapData.Cells[start : start+uint64(width)])
+	}
+
+	return MapFileAPIData{
+		Width:  &width,
+		Height: &height,
+		Cells:  cells,
+	}
+}
+
+// fileETag returns the entity tag of file content. It is the quoted content
+// hash, the same hash that revisions record.
+func fileETag(data []byte) string {
+	return `"` + utils.CalculateFileHash(data) + `"`
+}
+
+// requireFileIfMatch rejects an update unless its If-Match header matches the
+// current file content, so that an editor cannot overwrite changes it has not
+// seen. On a mismatch the 409 response carries the current version and ETag.
+func (s *Server) requireFileIfMatch(w http.ResponseWriter, r *http.Request, currentData []byte, currentVersion func() (interface{}, error)) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusPreconditionRequired, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
 			"context":   "file-system",
-			"errors":    []string{"Failed to update revision status: " + err.Error()},
+			"errors":    []string{"If-Match header is required. Use the ETag returned when the file was loaded."},
 		})
-		return 0, false
+		return false
 	}
 
-	if err = tx.Commit(); err != nil {
-		if removeErr := s.fileEditor.Remove(revisionPath); removeErr != nil {
-			s.log.Error("Failed to remove revision file during cleanup", logger.Field{Key: "error", Value: removeErr})
+	etag := fileETag(currentData)
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
 		}
+	}
+
+	response := map[string]interface{}{
+		"errorCode": constants.ErrorCodeFileModified,
+		"context":   "file-system",
+		"errors":    []string{"File was modified since it was loaded. Reload it and apply your changes again."},
+		"etag":      etag,
+	}
+
+	current, err := currentVersion()
+	if err != nil {
+		s.log.Error("Failed to read current file version", logger.Field{Key: "error", Value: err})
+	} else {
+		response["current"] = current
+	}
+
+	w.Header().Set("ETag", etag)
+	_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, response)
+	return false
+}
+
+func (s *Server) validateFileUpdateRequest(w http.ResponseWriter, r *http.Request, expectedFileType services.FileType, fileTypeName string) (*fileUpdateContext, bool) {
+	ctx, ok := s.prepareFileUpdate(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	fileType := s.fileEditor.GetFileType(ctx.cleanPath, ctx.info)
+	if fileType != expectedFileType {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileNotViewable,
+			"context":   "file-system",
+			"errors":    []string{"File is not a " + fileTypeName + " file"},
+		})
+		return nil, false
+	}
+
+	if !s.fileEditor.IsFileEditable(ctx.cleanPath, ctx.info) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"File is not editable"},
+		})
+		return nil, false
+	}
+
+	return ctx, true
+}
+
+// prepareFileUpdate validates the parts of an update request shared by all
+// file types: the user, the path and the optional change set.
+func (s *Server) prepareFileUpdate(w http.ResponseWriter, r *http.Request) (*fileUpdateContext, bool) {
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return nil, false
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return nil, false
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return nil, false
+	}
 
-		if removeErr := s.fileEditor.RemoveAll(revisionDir); removeErr != nil {
-			s.log.Error("Failed to remove revision directory during cleanup", logger.Field{Key: "error", Value: removeErr})
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return nil, false
 		}
 
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
-			"errorCode": constants.ErrorCodeInternalServerError,
+			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
-			"errors":    []string{"Failed to commit transaction: " + err.Error()},
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
+			"context":   "file-system",
+			"errors":    []string{"Path is a directory, not a file"},
+		})
+		return nil, false
+	}
+
+	changeSetID := r.URL.Query().Get("change_set_id")
+	if changeSetID != "" && !s.requireOpenChangeSet(w, changeSetID) {
+		return nil, false
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+
+	return &fileUpdateContext{
+		userID:      userID,
+		sessionID:   sessionID,
+		cleanPath:   cleanPath,
+		info:        info,
+		fileID:      utils.GenerateMD5Hash(cleanPath),
+		changeSetID: changeSetID,
+	}, true
+}
+
+// lockFileForUpdate takes the file lock of ctx until the caller releases
+// ctx.lock, so that reading the current content, checking its ETag,
+// recording the revision and writing the new content happen under one lock.
+func (s *Server) lockFileForUpdate(w http.ResponseWriter, ctx *fileUpdateContext) bool {
+	lock, err := s.acquireFileLock(ctx)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
 		})
+		return false
+	}
+
+	ctx.lock = lock
+	return true
+}
+
+func (s *Server) createFileRevision(w http.ResponseWriter, ctx *fileUpdateContext, previousData []byte, currentData []byte) (int64, bool) {
+	revisionID, err := s.recordFileRevision(ctx, previousData, currentData)
+	if err != nil {
+		s.writeFileRevisionError(w, err)
 		return 0, false
 	}
 
 	return revisionID, true
 }
 
+// writeFileRevisionError writes err using the status carried by a
+// fileRevisionError, or 500 for any other error.
+func (s *Server) writeFileRevisionError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var revisionErr *fileRevisionError
+	if errors.As(err, &revisionErr) {
+		status = revisionErr.status
+	}
+
+	errorCode := constants.ErrorCodeInternalServerError
+	if status != http.StatusInternalServerError {
+		errorCode = constants.ErrorCodeBadRequest
+	}
+
+	_ = utils.WriteJSONResponseWithStatus(w, status, map[string]interface{}{
+		"errorCode": errorCode,
+		"context":   "file-system",
+		"errors":    []string{err.Error()},
+	})
+}
+
+// recordFileRevision stores a copy of previousData as a new completed revision
+// of the file described by ctx. Callers that took the file lock with
+// lockFileForUpdate record under it; otherwise the lock is held only while
+// the revision is being recorded, so callers write the new content afterwards.
+func (s *Server) recordFileRevision(ctx *fileUpdateContext, previousData []byte, currentData []byte) (int64, error) {
+	previousHash := utils.CalculateFileHash(previousData)
+	currentHash := utils.CalculateFileHash(currentData)
+
+	// Creating or deleting a file is a change even when its content is empty.
+	if ctx.operation == "" && previousHash == currentHash {
+		return 0, &fileRevisionError{status: http.StatusBadRequest, message: "No changes detected. The file content is identical to the existing content."}
+	}
+
+	if ctx.lock == nil {
+		lock, err := s.acquireFileLock(ctx)
+		if err != nil {
+			return 0, &fileRevisionError{status: http.StatusConflict, message: err.Error()}
+		}
+
+		defer s.releaseFileLock(lock)
+	}
+
+	tx, err := s.internalDB.BeginTx()
+	if err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to begin transaction: " + err.Error()}
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				s.log.Error("Failed to rollback transaction", logger.Field{Key: "error", Value: rollbackErr})
+			}
+		}
+	}()
+
+	revisionID, err := s.internalDB.CreateFileRevision(tx, ctx.fileID, ctx.cleanPath, "", previousHash, currentHash, ctx.actor())
+	if err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to create revision: " + err.Error()}
+	}
+
+	if ctx.changeSetID != "" {
+		if err = s.internalDB.UpdateFileRevisionChangeSet(tx, revisionID, ctx.changeSetID, ctx.actor()); err != nil {
+			return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to assign revision to change set: " + err.Error()}
+		}
+	}
+
+	if ctx.operation != "" {
+		if err = s.internalDB.UpdateFileRevisionOperation(tx, revisionID, ctx.operation, ctx.actor()); err != nil {
+			return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to update revision operation: " + err.Error()}
+		}
+	}
+
+	if ctx.source != "" {
+		if err = s.internalDB.UpdateFileRevisionSource(tx, revisionID, ctx.source, ctx.actor()); err != nil {
+			return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to update revision source: " + err.Error()}
+		}
+	}
+
+	// Stored objects may be shared with other revisions, so they are not
+	// removed when the transaction fails; retention collects them if unused.
+	revisionPath, err := s.revisionStore.Put(previousData)
+	if err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to save revision copy: " + err.Error()}
+	}
+
+	if err = s.internalDB.UpdateFileRevisionPath(tx, revisionID, revisionPath, ctx.actor()); err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to update revision path: " + err.Error()}
+	}
+
+	if err = s.internalDB.UpdateFileRevisionStatus(tx, revisionID, "completed", ctx.actor()); err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to update revision status: " + err.Error()}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, &fileRevisionError{status: http.StatusInternalServerError, message: "Failed to commit transaction: " + err.Error()}
+	}
+
+	return revisionID, nil
+}
+
 func (s *Server) handleUpdateNPCFile(w http.ResponseWriter, r *http.Request) {
 	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
 		return
@@ -717,6 +1158,12 @@ func (s *Server) handleUpdateNPCFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
 	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -727,6 +1174,17 @@ func (s *Server) handleUpdateNPCFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		current, err := s.fileEditor.ReadNPCFileBytes(previousData)
+		if err != nil {
+			return nil, err
+		}
+
+		return newNPCFileAPIData(current), nil
+	}) {
+		return
+	}
+
 	var nameBytes [0x14]byte
 	copy(nameBytes[:], []byte(req.Name))
 
@@ -782,6 +1240,7 @@ func (s *Server) handleUpdateNPCFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", fileETag(currentData))
 	_ = utils.WriteJSONResponse(w, map[string]interface{}{
 		"message":     "File updated successfully",
 		"revision_id": revisionID,
@@ -822,6 +1281,12 @@ func (s *Server) handleUpdateTextFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
 	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -832,6 +1297,12 @@ func (s *Server) handleUpdateTextFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		return TextFileAPIData{Content: string(previousData)}, nil
+	}) {
+		return
+	}
+
 	currentData := []byte(req.Content)
 
 	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentData)
@@ -848,6 +1319,7 @@ func (s *Server) handleUpdateTextFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", fileETag(currentData))
 	_ = utils.WriteJSONResponse(w, map[string]interface{}{
 		"message":     "File updated successfully",
 		"revision_id": revisionID,
@@ -889,6 +1361,12 @@ func (s *Server) handleUpdateSpawnFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
 	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -899,11 +1377,22 @@ func (s *Server) handleUpdateSpawnFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	spawnData := make([]services.NPCSpawnData, len(req.Spawns))
-	for i, spawn := range req.Spawns {
-		spawnData[i] = services.NPCSpawnData{
-			Id:          *spawn.Id,
-			X:           *spawn.X,
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		current, err := s.fileEditor.ReadSpawnFileBytes(previousData)
+		if err != nil {
+			return nil, err
+		}
+
+		return newSpawnFileAPIData(current), nil
+	}) {
+		return
+	}
+
+	spawnData := make([]services.NPCSpawnData, len(req.Spawns))
+	for i, spawn := range req.Spawns {
+		spawnData[i] = services.NPCSpawnData{
+			Id:          *spawn.Id,
+			X:           *spawn.X,
 			Y:           *spawn.Y,
 			Unknown1:    *spawn.Unknown1,
 			Orientation: *spawn.Orientation,
@@ -911,6 +1400,11 @@ func (s *Server) handleUpdateSpawnFile(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	spawnWarnings, ok := s.checkSpawnPlacement(w, r, ctx.cleanPath, previousData, spawnData)
+	if !ok {
+		return
+	}
+
 	var currentDataBuffer bytes.Buffer
 	if err := binary.Write(&currentDataBuffer, binary.LittleEndian, spawnData); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -937,32 +1431,260 @@ func (s *Server) handleUpdateSpawnFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", fileETag(currentData))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":      "File updated successfully",
+		"revision_id":  revisionID,
+		"spawn_issues": spawnWarnings,
+	})
+}
+
+func (s *Server) handleUpdateDropFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	ctx, ok := s.validateFileUpdateRequest(w, r, services.FileTypeDrop, "drop")
+	if !ok {
+		return
+	}
+
+	var req DropFileAPIData
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			errors = append(errors, err.Field()+" is required")
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return
+	}
+
+	previousDropData, err := s.fileEditor.ReadDropFileBytes(previousData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read drop file data: " + err.Error()},
+		})
+		return
+	}
+
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		return newDropFileAPIData(previousDropData), nil
+	}) {
+		return
+	}
+
+	dropData := &services.DropFileData{
+		Items:   make([]services.DropItemData, len(req.Items)),
+		Trailer: previousDropData.Trailer,
+	}
+
+	for i, item := range req.Items {
+		dropData.Items[i] = services.DropItemData{
+			ItemId:   *item.ItemId,
+			Unknown1: *item.Unknown1,
+			DropRate: *item.DropRate,
+			Unknown2: *item.Unknown2,
+		}
+	}
+
+	currentData, err := services.EncodeDropFileData(dropData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to serialize drop data: " + err.Error()},
+		})
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentData)
+	if !ok {
+		return
+	}
+
+	if err = s.fileEditor.WriteDropFileData(ctx.cleanPath, dropData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
+	}
+
+	w.Header().Set("ETag", fileETag(currentData))
 	_ = utils.WriteJSONResponse(w, map[string]interface{}{
 		"message":     "File updated successfully",
 		"revision_id": revisionID,
 	})
 }
 
-func (s *Server) acquireFileLock(fileID string) (string, error) {
-	locksDir := filepath.Join(s.cfg.RevisionsDirectory, "locks")
-	if err := s.fileEditor.MkdirAll(locksDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create locks directory: %w", err)
+func (s *Server) handleUpdateMapFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	ctx, ok := s.validateFileUpdateRequest(w, r, services.FileTypeMap, "map")
+	if !ok {
+		return
+	}
+
+	var req MapFileAPIData
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			errors = append(errors, err.Field()+" is required")
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return
 	}
 
-	lockPath := filepath.Join(locksDir, fileID+".lock")
-	lockFile, err := s.fileEditor.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	previousMapData, err := s.fileEditor.ReadMapFileBytes(previousData)
 	if err != nil {
-		if s.fileEditor.IsExist(err) {
-			return "", fmt.Errorf("file is currently being edited by another process")
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read map file data: " + err.Error()},
+		})
+		return
+	}
+
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		return newMapFileAPIData(previousMapData), nil
+	}) {
+		return
+	}
+
+	if *req.Width != previousMapData.Width || *req.Height != previousMapData.Height {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Map dimensions cannot be changed"},
+		})
+		return
+	}
+
+	if uint64(len(req.Cells)) != uint64(previousMapData.Height) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Cells must contain exactly one row per map height"},
+		})
+		return
+	}
+
+	mapData := &services.MapFileData{
+		Width:   previousMapData.Width,
+		Height:  previousMapData.Height,
+		Cells:   make([]byte, 0, len(previousMapData.Cells)),
+		Trailer: previousMapData.Trailer,
+	}
+
+	for y, row := range req.Cells {
+		if uint64(len(row)) != uint64(previousMapData.Width) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{fmt.Sprintf("Row %d must contain exactly %d cells", y, previousMapData.Width)},
+			})
+			return
 		}
-		return "", fmt.Errorf("failed to create lock file: %w", err)
+
+		mapData.Cells = append(mapData.Cells, row...)
+	}
+
+	currentData, err := services.EncodeMapFileData(mapData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to serialize map data: " + err.Error()},
+		})
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentData)
+	if !ok {
+		return
 	}
 
-	if err := lockFile.Close(); err != nil {
-		s.log.Error("Failed to close lock file", logger.Field{Key: "error", Value: err})
+	if err = s.fileEditor.WriteMapFileData(ctx.cleanPath, mapData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
 	}
 
-	return lockPath, nil
+	w.Header().Set("ETag", fileETag(currentData))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File updated successfully",
+		"revision_id": revisionID,
+	})
 }
 
 func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
@@ -990,18 +1712,14 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
-	info, err := s.fileEditor.Stat(cleanPath)
-	if err != nil {
-		if s.fileEditor.IsNotExist(err) {
-			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
-				"errorCode": constants.ErrorCodeNotFound,
-				"context":   "file-system",
-				"errors":    []string{"Path not found"},
-			})
-			return
-		}
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
 
+	// A missing file can still be reverted when its last revision deleted it.
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil && !s.fileEditor.IsNotExist(err) {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeFileReadError,
 			"context":   "file-system",
@@ -1010,7 +1728,7 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if info.IsDir() {
+	if info != nil && info.IsDir() {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
 			"errorCode": constants.ErrorCodePathIsDirectory,
 			"context":   "file-system",
@@ -1020,8 +1738,15 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	fileID := utils.GenerateMD5Hash(cleanPath)
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
 
-	lockPath, err := s.acquireFileLock(fileID)
+	lock, err := s.acquireFileLock(&fileUpdateContext{
+		userID:    userID,
+		sessionID: sessionID,
+		cleanPath: cleanPath,
+		info:      info,
+		fileID:    fileID,
+	})
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
 			"errorCode": constants.ErrorCodeBadRequest,
@@ -1031,7 +1756,7 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	defer s.releaseFileLock(lockPath)
+	defer s.releaseFileLock(lock)
 
 	revision, err := s.internalDB.GetLastCompletedFileRevision(fileID)
 	if err != nil {
@@ -1052,7 +1777,26 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := s.fileEditor.Stat(revision.RevisionPath); s.fileEditor.IsNotExist(err) {
+	if info == nil && revision.Operation != revisionOperationDelete {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Path not found"},
+		})
+		return
+	}
+
+	if info != nil && revision.Operation == revisionOperationDelete {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"File was created again after it was deleted"},
+		})
+		return
+	}
+
+	revisionData, err := s.revisionStore.Get(revision.RevisionPath)
+	if err != nil && s.isMissingRevisionCopy(err) {
 		tx, err := s.internalDB.BeginTx()
 		if err != nil {
 			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
@@ -1071,7 +1815,7 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 			}
 		}()
 
-		if err = s.internalDB.UpdateFileRevisionStatus(tx, revision.ID, "corrupted", userID); err != nil {
+		if err = s.internalDB.UpdateFileRevisionStatus(tx, revision.ID, "corrupted", &userID); err != nil {
 			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 				"errorCode": constants.ErrorCodeInternalServerError,
 				"context":   "file-system",
@@ -1097,7 +1841,6 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	revisionData, err := s.fileEditor.ReadFile(revision.RevisionPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeFileReadError,
@@ -1125,7 +1868,7 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	if err = s.internalDB.UpdateFileRevisionStatus(tx, revision.ID, "reverted", userID); err != nil {
+	if err = s.internalDB.UpdateFileRevisionStatus(tx, revision.ID, "reverted", &userID); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
 			"context":   "file-system",
@@ -1145,11 +1888,11 @@ func (s *Server) handleRevertFile(w http.ResponseWriter, r *http.Request) {
 
 	err = nil
 
-	if err = s.fileEditor.WriteFile(cleanPath, revisionData, 0644); err != nil {
+	if err = s.applyRevisionContent(cleanPath, revisionData, revision.Operation == revisionOperationCreate); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
 			"context":   "file-system",
-			"errors":    []string{"Failed to write file: " + err.Error()},
+			"errors":    []string{"Failed to revert file: " + err.Error()},
 		})
 		return
 	}
@@ -1171,7 +1914,11 @@ func (s *Server) handleRevisionSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cleanPath := filepath.Clean(pathParam)
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
 	info, err := s.fileEditor.Stat(cleanPath)
 	if err != nil {
 		if s.fileEditor.IsNotExist(err) {
@@ -1214,17 +1961,456 @@ func (s *Server) handleRevisionSummary(w http.ResponseWriter, r *http.Request) {
 	_ = utils.WriteJSONResponse(w, summary)
 }
 
-func (s *Server) releaseFileLock(lockPath string) {
-	if lockPath != "" {
-		if err := s.fileEditor.Remove(lockPath); err != nil {
-			s.log.Error("Failed to remove lock file", logger.Field{Key: "error", Value: err})
+func (s *Server) handleListFileRevisions(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if parsed, err := strconv.Atoi(pageStr); err == nil && parsed >= 1 {
+			page = parsed
 		}
 	}
-}
 
-type FileNode struct {
-	ID            string            `json:"id"`
-	Name          string            `json:"name"`
+	if pageSizeStr := r.URL.Query().Get("pageSize"); pageSizeStr != "" {
+		if parsed, err := strconv.Atoi(pageSizeStr); err == nil && parsed >= 1 && parsed <= 100 {
+			pageSize = parsed
+		}
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	fileID := utils.GenerateMD5Hash(cleanPath)
+	revisions, totalCount, err := s.internalDB.GetFileRevisionsPaginated(fileID, page, pageSize)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to get file revisions: " + err.Error()},
+		})
+		return
+	}
+
+	if revisions == nil {
+		revisions = []db.FileRevisionListItem{}
+	}
+
+	_ = utils.WriteJSONResponse(w, FileRevisionsResponse{
+		Data: revisions,
+		Pagination: PaginationInfo{
+			TotalCount: totalCount,
+			Page:       page,
+			PageSize:   pageSize,
+		},
+	})
+}
+
+func (s *Server) handleDownloadFileRevision(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(chi.URLParam(r, "revisionID"), 10, 64)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid revision ID"},
+		})
+		return
+	}
+
+	revision, err := s.internalDB.GetFileRevision(revisionID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if _, ok := s.resolveRequestPath(w, revision.OriginalPath, "file-system"); !ok {
+		return
+	}
+
+	if revision.RevisionPath == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Revision has no stored copy"},
+		})
+		return
+	}
+
+	revisionData, err := s.revisionStore.Get(revision.RevisionPath)
+	if err != nil {
+		if s.isMissingRevisionCopy(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Revision file is missing or corrupted"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read revision file: " + err.Error()},
+		})
+		return
+	}
+
+	fileName := strconv.FormatInt(revision.ID, 10) + "_" + filepath.Base(revision.OriginalPath)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(revisionData)))
+	_, _ = w.Write(revisionData)
+}
+
+func (s *Server) handleRevertFileToRevision(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionRevertFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(chi.URLParam(r, "revisionID"), 10, 64)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid revision ID"},
+		})
+		return
+	}
+
+	revision, err := s.internalDB.GetFileRevision(revisionID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if revision.Status != "completed" && revision.Status != "reverted" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Revision cannot be restored because its status is " + revision.Status},
+		})
+		return
+	}
+
+	if _, ok := s.resolveRequestPath(w, revision.OriginalPath, "file-system"); !ok {
+		return
+	}
+
+	revisionData, err := s.revisionStore.Get(revision.RevisionPath)
+	if err != nil {
+		if s.isMissingRevisionCopy(err) {
+			if markErr := s.markFileRevisionCorrupted(revision.ID, userID); markErr != nil {
+				s.log.Error("Failed to mark revision as corrupted", logger.Field{Key: "error", Value: markErr})
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Revision file is missing or corrupted"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read revision file: " + err.Error()},
+		})
+		return
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	ctx := &fileUpdateContext{
+		userID:    userID,
+		sessionID: sessionID,
+		cleanPath: revision.OriginalPath,
+		fileID:    revision.FileID,
+	}
+
+	newRevisionID, err := s.restoreRevisionState(ctx, *revision, revisionData)
+	if err != nil {
+		s.writeFileRevisionError(w, err)
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":                 "File reverted successfully",
+		"revision_id":             newRevisionID,
+		"reverted_to_revision_id": revision.ID,
+	})
+}
+
+func (s *Server) handleFileDiff(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	fromRevisionID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"A valid from revision ID is required"},
+		})
+		return
+	}
+
+	fromRevision, err := s.internalDB.GetFileRevision(fromRevisionID)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if _, ok := s.resolveRequestPath(w, fromRevision.OriginalPath, "file-system"); !ok {
+		return
+	}
+
+	fromData, ok := s.readRevisionCopy(w, fromRevision)
+	if !ok {
+		return
+	}
+
+	response := FileDiffResponse{
+		OriginalPath:   fromRevision.OriginalPath,
+		FromRevisionID: fromRevision.ID,
+	}
+
+	var toData []byte
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		toRevisionID, err := strconv.ParseInt(toParam, 10, 64)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Invalid to revision ID"},
+			})
+			return
+		}
+
+		toRevision, err := s.internalDB.GetFileRevision(toRevisionID)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{err.Error()},
+			})
+			return
+		}
+
+		if toRevision.FileID != fromRevision.FileID {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Revisions belong to different files"},
+			})
+			return
+		}
+
+		if toData, ok = s.readRevisionCopy(w, toRevision); !ok {
+			return
+		}
+
+		response.ToRevisionID = &toRevision.ID
+	} else {
+		toData, err = s.fileEditor.ReadFile(fromRevision.OriginalPath)
+		if err != nil {
+			if s.fileEditor.IsNotExist(err) {
+				_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+					"errorCode": constants.ErrorCodeNotFound,
+					"context":   "file-system",
+					"errors":    []string{"Path not found"},
+				})
+				return
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read file: " + err.Error()},
+			})
+			return
+		}
+	}
+
+	response.FileType = s.fileEditor.GetFileType(fromRevision.OriginalPath, services.NewDataFileInfo(fromRevision.OriginalPath, fromData))
+	response.Identical = utils.CalculateFileHash(fromData) == utils.CalculateFileHash(toData)
+
+	switch response.FileType {
+	case services.FileTypeNPC:
+		fromNPCData, fromErr := s.fileEditor.ReadNPCFileBytes(fromData)
+		toNPCData, toErr := s.fileEditor.ReadNPCFileBytes(toData)
+		if err = errors.Join(fromErr, toErr); err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read NPC file data: " + err.Error()},
+			})
+			return
+		}
+
+		response.Fields = services.DiffNPCFileData(fromNPCData, toNPCData)
+	case services.FileTypeSpawn:
+		fromSpawnData, fromErr := s.fileEditor.ReadSpawnFileBytes(fromData)
+		toSpawnData, toErr := s.fileEditor.ReadSpawnFileBytes(toData)
+		if err = errors.Join(fromErr, toErr); err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to read spawn file data: " + err.Error()},
+			})
+			return
+		}
+
+		response.Rows = []SpawnRowChangeAPIData{}
+		for _, change := range services.DiffSpawnData(fromSpawnData, toSpawnData) {
+			row := SpawnRowChangeAPIData{
+				Type:     change.Type,
+				OldIndex: change.OldIndex,
+				NewIndex: change.NewIndex,
+			}
+
+			if change.Old != nil {
+				old := newNPCSpawnAPIData(*change.Old)
+				row.Old = &old
+			}
+
+			if change.New != nil {
+				current := newNPCSpawnAPIData(*change.New)
+				row.New = &current
+			}
+
+			response.Rows = append(response.Rows, row)
+		}
+	case services.FileTypeText:
+		fileName := filepath.Base(fromRevision.OriginalPath)
+		toLabel := "current/" + fileName
+		if response.ToRevisionID != nil {
+			toLabel = "revision-" + strconv.FormatInt(*response.ToRevisionID, 10) + "/" + fileName
+		}
+
+		unified := services.UnifiedDiff(
+			"revision-"+strconv.FormatInt(fromRevision.ID, 10)+"/"+fileName,
+			toLabel,
+			string(fromData),
+			string(toData),
+		)
+		response.Unified = &unified
+	default:
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileNotViewable,
+			"context":   "file-system",
+			"errors":    []string{"Diff is not supported for this file type"},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+func (s *Server) readRevisionCopy(w http.ResponseWriter, revision *db.FileRevision) ([]byte, bool) {
+	if revision.RevisionPath == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "file-system",
+			"errors":    []string{"Revision has no stored copy"},
+		})
+		return nil, false
+	}
+
+	data, err := s.revisionStore.Get(revision.RevisionPath)
+	if err != nil {
+		if s.isMissingRevisionCopy(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Revision file is missing or corrupted"},
+			})
+			return nil, false
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read revision file: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	return data, true
+}
+
+// isMissingRevisionCopy reports whether a revision copy could not be read
+// because it is gone or damaged, as opposed to a transient read error.
+func (s *Server) isMissingRevisionCopy(err error) bool {
+	return s.fileEditor.IsNotExist(err) || errors.Is(err, services.ErrRevisionCorrupted)
+}
+
+func (s *Server) markFileRevisionCorrupted(revisionID int64, userID int64) error {
+	return s.updateFileRevisionStatus(revisionID, "corrupted", userID)
+}
+
+func (s *Server) updateFileRevisionStatus(revisionID int64, status string, userID int64) error {
+	tx, err := s.internalDB.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := s.internalDB.UpdateFileRevisionStatus(tx, revisionID, status, &userID); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.log.Error("Failed to rollback transaction", logger.Field{Key: "error", Value: rollbackErr})
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type FileNode struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Path          string            `json:"path,omitempty"`
 	Kind          string            `json:"kind"`
 	Depth         int               `json:"depth"`
 	LastModified  *time.Time        `json:"last_modified,omitempty"`
@@ -1264,6 +2450,8 @@ type NPCFileAPIData struct {
 	RedAttackDefense    *uint16              `json:"red_attack_defense" validate:"required"`
 	GreyAttackDefense   *uint16              `json:"grey_attack_defense" validate:"required"`
 	MercenaryExp        *uint16              `json:"mercenary_exp" validate:"required"`
+	MonsterName         *string              `json:"monster_name,omitempty"`
+	MonsterFound        *bool                `json:"monster_found,omitempty"`
 }
 
 type TextFileAPIData struct {
@@ -1271,21 +2459,119 @@ type TextFileAPIData struct {
 }
 
 type SpawnFileAPIData struct {
-	Spawns []NPCSpawnAPIData `json:"spawns" validate:"required"`
+	Spawns            []NPCSpawnAPIData `json:"spawns" validate:"required"`
+	MapID             *int64            `json:"map_id,omitempty"`
+	MapName           *string           `json:"map_name,omitempty"`
+	MapFound          *bool             `json:"map_found,omitempty"`
+	MissingMonsterIDs []int64           `json:"missing_monster_ids,omitempty"`
 }
 
 type NPCSpawnAPIData struct {
-	Id          *uint16 `json:"id" validate:"required"`
-	X           *byte   `json:"x" validate:"required"`
-	Y           *byte   `json:"y" validate:"required"`
-	Unknown1    *uint16 `json:"unknown1" validate:"required"`
-	Orientation *byte   `json:"orientation" validate:"required"`
-	SpwanStep   *byte   `json:"spwan_step" validate:"required"`
+	Id           *uint16 `json:"id" validate:"required"`
+	X            *byte   `json:"x" validate:"required"`
+	Y            *byte   `json:"y" validate:"required"`
+	Unknown1     *uint16 `json:"unknown1" validate:"required"`
+	Orientation  *byte   `json:"orientation" validate:"required"`
+	SpwanStep    *byte   `json:"spwan_step" validate:"required"`
+	MonsterName  *string `json:"monster_name,omitempty"`
+	MonsterFound *bool   `json:"monster_found,omitempty"`
+}
+
+type DropFileAPIData struct {
+	Items          []DropItemAPIData `json:"items" validate:"required,dive"`
+	MissingItemIDs []int64           `json:"missing_item_ids,omitempty"`
+}
+
+type DropItemAPIData struct {
+	ItemId    *uint16 `json:"item_id" validate:"required"`
+	Unknown1  *uint16 `json:"unknown1" validate:"required"`
+	DropRate  *uint32 `json:"drop_rate" validate:"required"`
+	Unknown2  *uint32 `json:"unknown2" validate:"required"`
+	ItemName  *string `json:"item_name,omitempty"`
+	ItemFound *bool   `json:"item_found,omitempty"`
+}
+
+type MapFileAPIData struct {
+	Width  *uint32      `json:"width" validate:"required"`
+	Height *uint32      `json:"height" validate:"required"`
+	Cells  []MapCellRow `json:"cells" validate:"required"`
+}
+
+// MapCellRow is one row of map cells. It is encoded as an array of numbers
+// rather than the base64 string encoding/json uses for byte slices; decoding
+// rejects values outside 0-255.
+type MapCellRow []uint8
+
+func (row MapCellRow) MarshalJSON() ([]byte, error) {
+	data := make([]byte, 0, len(row)*4+2)
+	data = append(data, '[')
+	for i, cell := range row {
+		if i > 0 {
+			data = append(data, ',')
+		}
+		data = strconv.AppendUint(data, uint64(cell), 10)
+	}
+
+	return append(data, ']'), nil
+}
+
+type FileRevisionsResponse struct {
+	Data       []db.FileRevisionListItem `json:"data"`
+	Pagination PaginationInfo            `json:"pagination"`
+}
+
+type FileDiffResponse struct {
+	FileType       services.FileType       `json:"file_type"`
+	OriginalPath   string                  `json:"original_path"`
+	FromRevisionID int64                   `json:"from_revision_id"`
+	ToRevisionID   *int64                  `json:"to_revision_id"`
+	Identical      bool                    `json:"identical"`
+	Fields         []services.FieldChange  `json:"fields,omitempty"`
+	Rows           []SpawnRowChangeAPIData `json:"rows,omitempty"`
+	Unified        *string                 `json:"unified,omitempty"`
+}
+
+type SpawnRowChangeAPIData struct {
+	Type     string           `json:"type"`
+	OldIndex *int             `json:"old_index"`
+	NewIndex *int             `json:"new_index"`
+	Old      *NPCSpawnAPIData `json:"old"`
+	New      *NPCSpawnAPIData `json:"new"`
 }
 
 type fileUpdateContext struct {
-	userID    int64
-	cleanPath string
-	info      fs.FileInfo
-	fileID    string
+	userID      int64
+	sessionID   string
+	cleanPath   string
+	info        fs.FileInfo
+	fileID      string
+	changeSetID string
+	// operation is set for revisions that create or delete the file; it is
+	// left empty for edits.
+	operation string
+	// source is set for revisions not made through the API; it is left
+	// empty for the default, agent.
+	source string
+	// lock is set while the update holds the file lock, see
+	// lockFileForUpdate.
+	lock *heldFileLock
+}
+
+// actor returns the user the revision is recorded for, or nil for changes
+// made outside the agent, which have no user.
+func (ctx *fileUpdateContext) actor() *int64 {
+	if ctx.source == revisionSourceExternal {
+		return nil
+	}
+
+	return &ctx.userID
+}
+
+type fileRevisionError struct {
+	status  int
+	message string
+}
+
+func (e *fileRevisionError) Error() string {
+	return e.message
 }
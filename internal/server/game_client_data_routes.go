@@ -3,6 +3,9 @@ package server
 import (
 	"io"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -21,6 +24,7 @@ func (s *Server) InitializeGameClientDataRoutes(r *chi.Mux) {
 		r.Get("/maps", s.handleMaps)
 		r.Post("/upload-mc-file", s.handleUploadMCFile)
 		r.Get("/items", s.handleItems)
+		r.Post("/upload-it-file", s.handleUploadITFile)
 	})
 }
 
@@ -315,6 +319,169 @@ func (s *Server) handleUploadMCFile(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) handleUploadITFile(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionUploadGameData) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "game-data",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	maxUploadSize := int64(s.cfg.MaxFileUploadSizeMb) * 1024 * 1024
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "game-data",
+			"errors":    []string{"Failed to parse multipart form: " + err.Error()},
+		})
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "game-data",
+			"errors":    []string{"Failed to get file from form: " + err.Error()},
+		})
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if fileHeader.Size > maxUploadSize {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "game-data",
+			"errors":    []string{"File size exceeds maximum allowed size"},
+		})
+		return
+	}
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "game-data",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return
+	}
+
+	if len(fileData) > int(maxUploadSize) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "game-data",
+			"errors":    []string{"File size exceeds maximum allowed size"},
+		})
+		return
+	}
+
+	utils.DecodeULL(&fileData, len(fileData))
+	itemData, err := s.fileEditor.ReadClientItemFileBytes(fileData)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "game-data",
+			"errors":    []string{"Failed to parse item file: " + err.Error()},
+		})
+		return
+	}
+
+	now := time.Now()
+	dbItemData := make([]db.ItemClientData, 0, len(itemData))
+	uniqueItemMap := make(map[uint32]bool)
+	for _, item := range itemData {
+		name := utils.ReadStringFromBytes(item.Name[:])
+		if _, ok := uniqueItemMap[item.ID]; ok {
+			continue
+		}
+
+		uniqueItemMap[item.ID] = true
+		dbItemData = append(dbItemData, db.ItemClientData{
+			ID:        int64(item.ID),
+			Name:      name,
+			CreatedBy: &userID,
+			UpdatedBy: &userID,
+			UpdatedAt: &now,
+		})
+	}
+
+	if err := s.internalDB.BulkReplaceItemClientData(dbItemData); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "game-data",
+			"errors":    []string{"Failed to save item data: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message": "Item list file uploaded successfully",
+		"count":   len(dbItemData),
+	})
+}
+
+// clientDataEntry is an entry of the uploaded monster, map or item client data.
+type clientDataEntry interface {
+	ClientDataName() (int64, string)
+}
+
+// resolveClientDataNames looks up the names of ids with lookup, one of the
+// Get*ClientDataByIDs methods of the internal database. IDs missing from the
+// client data are missing from the returned map.
+func resolveClientDataNames[T clientDataEntry](ids []int64, lookup func(ids []int64) ([]T, error)) (map[int64]string, error) {
+	data, err := lookup(uniqueIDs(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(data))
+	for _, entry := range data {
+		id, name := entry.ClientDataName()
+		names[id] = name
+	}
+
+	return names, nil
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
+}
+
+// mapIDFromPath derives the map ID from a spawn file name such as "12.n_ndt".
+// Only spawn files are named after a map; NPC and drop files describe a single
+// monster, so their responses carry no map name.
+func mapIDFromPath(path string) (int64, bool) {
+	base := filepath.Base(path)
+	id, err := strconv.ParseInt(strings.TrimSuffix(base, filepath.Ext(base)), 10, 64)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
 type GameClientDataResponse struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	defaultHexViewLength = 512
+	maxHexViewLength     = 64 * 1024
+)
+
+func (s *Server) handleHexView(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	var offset int64
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsed, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || parsed < 0 {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Offset must be a non-negative integer"},
+			})
+			return
+		}
+		offset = parsed
+	}
+
+	length := defaultHexViewLength
+	if lengthStr := r.URL.Query().Get("length"); lengthStr != "" {
+		parsed, err := strconv.Atoi(lengthStr)
+		if err != nil || parsed < 1 || parsed > maxHexViewLength {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Length must be between 1 and " + strconv.Itoa(maxHexViewLength)},
+			})
+			return
+		}
+		length = parsed
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	if info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodePathIsDirectory,
+			"context":   "file-system",
+			"errors":    []string{"Path is a directory, not a file"},
+		})
+		return
+	}
+
+	file, err := s.fileEditor.OpenFile(cleanPath, os.O_RDONLY, 0)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+	defer file.Close()
+
+	// The ETag covers the whole file so that it can be sent back with a
+	// patch. Hashing a large file on every page is costly, so it is only
+	// computed when the client asks for it before patching.
+	if withETag, _ := strconv.ParseBool(r.URL.Query().Get("etag")); withETag {
+		hash, err := utils.CalculateReaderHash(file)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeFileReadError,
+				"context":   "file-system",
+				"errors":    []string{"Cannot read file: " + err.Error()},
+			})
+			return
+		}
+
+		w.Header().Set("ETag", `"`+hash+`"`)
+	}
+
+	data, err := readFileRange(file, offset, length)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	response := HexViewResponse{
+		Path:     cleanPath,
+		FileSize: info.Size(),
+		FileType: s.fileEditor.GetFileType(cleanPath, info),
+		Offset:   offset,
+		Length:   len(data),
+		Rows:     services.FormatHexRows(data, offset),
+	}
+
+	if next := offset + int64(len(data)); next < info.Size() {
+		response.NextOffset = &next
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+func (s *Server) handleHexPatch(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	ctx, ok := s.prepareFileUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	var req HexPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			errors = append(errors, err.Field()+" is required")
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	patch, err := services.ParseHexBytes(req.Bytes)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if !s.lockFileForUpdate(w, ctx) {
+		return
+	}
+
+	defer s.releaseFileLock(ctx.lock)
+
+	previousData, err := s.fileEditor.ReadFile(ctx.cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read file: " + err.Error()},
+		})
+		return
+	}
+
+	if !s.requireFileIfMatch(w, r, previousData, func() (interface{}, error) {
+		end := min(*req.Offset+int64(len(patch)), int64(len(previousData)))
+		start := min(*req.Offset, end)
+		return services.FormatHexRows(previousData[start:end], start), nil
+	}) {
+		return
+	}
+
+	currentData, err := services.ApplyBytePatch(previousData, *req.Offset, patch)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	revisionID, ok := s.createFileRevision(w, ctx, previousData, currentData)
+	if !ok {
+		return
+	}
+
+	if err = s.fileEditor.WriteFile(ctx.cleanPath, currentData, ctx.info.Mode().Perm()); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to write file: " + err.Error()},
+		})
+		return
+	}
+
+	w.Header().Set("ETag", fileETag(currentData))
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"message":     "File patched successfully",
+		"revision_id": revisionID,
+	})
+}
+
+// readFileRange reads up to length bytes at offset, returning fewer bytes
+// when the range passes the end of the file.
+func readFileRange(file *os.File, offset int64, length int) ([]byte, error) {
+	data := make([]byte, length)
+	n, err := file.ReadAt(data, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return data[:n], nil
+}
+
+type HexViewResponse struct {
+	Path       string            `json:"path"`
+	FileSize   int64             `json:"file_size"`
+	FileType   services.FileType `json:"file_type"`
+	Offset     int64             `json:"offset"`
+	Length     int               `json:"length"`
+	NextOffset *int64            `json:"next_offset"`
+	Rows       []services.HexRow `json:"rows"`
+}
+
+type HexPatchRequest struct {
+	Offset *int64 `json:"offset" validate:"required,min=0"`
+	Bytes  string `json:"bytes" validate:"required"`
+}
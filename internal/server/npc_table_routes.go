@@ -0,0 +1,604 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleNPCTable(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	conditions := make([]npcCondition, 0, len(r.URL.Query()["filter"]))
+	for _, filter := range r.URL.Query()["filter"] {
+		parts := strings.SplitN(filter, ":", 3)
+		if len(parts) != 3 {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Invalid filter " + filter + ", expected field:op:value"},
+			})
+			return
+		}
+
+		condition, err := newNPCCondition(parts[0], parts[1], parts[2])
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{err.Error()},
+			})
+			return
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	sortField := r.URL.Query().Get("sort")
+	if sortField != "" && sortField != "path" && sortField != "name" && !slices.Contains(services.NPCFieldNames(), sortField) {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Unknown sort field " + sortField},
+		})
+		return
+	}
+
+	descending := strings.EqualFold(r.URL.Query().Get("order"), "desc")
+
+	files, ok := s.scanNPCDirectory(w, pathParam)
+	if !ok {
+		return
+	}
+
+	response := NPCTableResponse{
+		Rows:   []NPCTableRow{},
+		Fields: append([]string{"name"}, services.NPCFieldNames()...),
+		Errors: files.errors,
+	}
+
+	matched := make([]npcTableFile, 0, len(files.files))
+	for _, file := range files.files {
+		if matchesNPCConditions(file.data, conditions) {
+			matched = append(matched, file)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		comparison := compareNPCTableFiles(matched[i], matched[j], sortField)
+		if descending {
+			return comparison > 0
+		}
+
+		return comparison < 0
+	})
+
+	for _, file := range matched {
+		response.Rows = append(response.Rows, NPCTableRow{
+			Path:           file.path,
+			NPCFileAPIData: newNPCFileAPIData(file.data),
+		})
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+func (s *Server) handleNPCBatchUpdate(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionEditFiles) {
+		return
+	}
+
+	userID, ok := utils.GetUserIdFromContext(r.Context())
+	if !ok {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusUnauthorized, map[string]interface{}{
+			"errorCode": constants.ErrorCodeUnauthorized,
+			"context":   "file-system",
+			"errors":    []string{"User ID not found in context"},
+		})
+		return
+	}
+
+	var req NPCBatchUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Invalid request body: " + err.Error()},
+		})
+		return
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var errors []string
+		for _, err := range err.(validator.ValidationErrors) {
+			switch err.Tag() {
+			case "required":
+				errors = append(errors, err.Field()+" is required")
+			case "min":
+				errors = append(errors, err.Field()+" must not be empty")
+			default:
+				errors = append(errors, "Invalid "+err.Field())
+			}
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    errors,
+		})
+		return
+	}
+
+	conditions := make([]npcCondition, 0, len(req.Where))
+	for _, where := range req.Where {
+		if where.Value == nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Value is required for field " + where.Field},
+			})
+			return
+		}
+
+		condition, err := newNPCCondition(where.Field, where.Op, fmt.Sprint(where.Value))
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{err.Error()},
+			})
+			return
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	for _, update := range req.Updates {
+		if !slices.Contains(services.NPCFieldNames(), update.Field) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Unknown or read-only field " + update.Field},
+			})
+			return
+		}
+	}
+
+	if req.ChangeSetID != "" && !s.requireOpenChangeSet(w, req.ChangeSetID) {
+		return
+	}
+
+	files, ok := s.scanNPCDirectory(w, req.Path)
+	if !ok {
+		return
+	}
+
+	response := NPCBatchUpdateResponse{
+		DryRun:  req.DryRun,
+		Updated: []NPCBatchUpdateResult{},
+		Failed:  []NPCBatchUpdateFailure{},
+	}
+
+	for _, scanErr := range files.errors {
+		response.Failed = append(response.Failed, NPCBatchUpdateFailure(scanErr))
+	}
+
+	sessionID, _ := utils.GetSessionIDFromContext(r.Context())
+	for _, file := range files.files {
+		if !matchesNPCConditions(file.data, conditions) {
+			continue
+		}
+
+		response.Matched++
+
+		updated := *file.data
+		if err := applyNPCFieldUpdates(&updated, req.Updates); err != nil {
+			response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: file.path, Error: err.Error()})
+			continue
+		}
+
+		changes := services.DiffNPCFileData(file.data, &updated)
+		if len(changes) == 0 {
+			continue
+		}
+
+		result := NPCBatchUpdateResult{Path: file.path, Changes: changes}
+		if req.DryRun {
+			response.Updated = append(response.Updated, result)
+			continue
+		}
+
+		if response.ChangeSetID == "" {
+			response.ChangeSetID = req.ChangeSetID
+			if response.ChangeSetID == "" {
+				changeSet, err := s.internalDB.CreateChangeSet(utils.GenerateRandomToken(32), "NPC batch update of "+filepath.Clean(req.Path), "open", userID)
+				if err != nil {
+					response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: file.path, Error: "failed to create change set: " + err.Error()})
+					continue
+				}
+
+				response.ChangeSetID = changeSet.ID
+			}
+		}
+
+		revisionID, err := s.writeNPCFileWithRevision(userID, sessionID, response.ChangeSetID, file, &updated)
+		if err != nil {
+			response.Failed = append(response.Failed, NPCBatchUpdateFailure{Path: file.path, Error: err.Error()})
+			continue
+		}
+
+		result.RevisionID = &revisionID
+		response.Updated = append(response.Updated, result)
+	}
+
+	// A change set supplied by the caller stays open for further edits; one
+	// created for this batch is committed straight away, or deleted if every
+	// write failed.
+	if response.ChangeSetID != "" && req.ChangeSetID == "" && !s.closeRequestChangeSet(response.ChangeSetID, userID) {
+		response.ChangeSetID = ""
+	}
+
+	_ = utils.WriteJSONResponse(w, response)
+}
+
+// writeNPCFileWithRevision writes updated under the file lock, failing if the
+// file no longer has the content hash it had when it was loaded.
+func (s *Server) writeNPCFileWithRevision(userID int64, sessionID string, changeSetID string, file npcTableFile, updated *services.NPCFileData) (int64, error) {
+	var buffer bytes.Buffer
+	if err := binary.Write(&buffer, binary.LittleEndian, updated); err != nil {
+		return 0, fmt.Errorf("failed to serialize NPC data: %w", err)
+	}
+
+	ctx := &fileUpdateContext{
+		userID:      userID,
+		sessionID:   sessionID,
+		cleanPath:   file.path,
+		info:        file.info,
+		fileID:      utils.GenerateMD5Hash(file.path),
+		changeSetID: changeSetID,
+	}
+
+	lock, err := s.acquireFileLock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx.lock = lock
+	defer s.releaseFileLock(lock)
+
+	previousData, err := s.fileEditor.ReadFile(file.path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if utils.CalculateFileHash(previousData) != file.hash {
+		return 0, fmt.Errorf("file was modified since it was loaded")
+	}
+
+	revisionID, err := s.recordFileRevision(ctx, previousData, buffer.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.fileEditor.WriteNPCFileData(file.path, updated); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return revisionID, nil
+}
+
+type npcTableFile struct {
+	path string
+	info fs.FileInfo
+	data *services.NPCFileData
+	// hash is the content hash the file had when it was loaded; it is only
+	// written while it still has that content.
+	hash string
+}
+
+type npcDirectoryScan struct {
+	files  []npcTableFile
+	errors []NPCTableError
+}
+
+func (s *Server) scanNPCDirectory(w http.ResponseWriter, path string) (*npcDirectoryScan, bool) {
+	dirPath, ok := s.resolveRequestPath(w, path, "file-system")
+	if !ok {
+		return nil, false
+	}
+
+	info, err := s.fileEditor.Stat(dirPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return nil, false
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read directory: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	if !info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path is not a directory"},
+		})
+		return nil, false
+	}
+
+	entries, err := s.fileEditor.ReadDir(dirPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read directory: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	scan := &npcDirectoryScan{errors: []NPCTableError{}}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		entryInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		fullPath := filepath.Join(dirPath, entry.Name())
+		if s.fileEditor.GetFileType(fullPath, entryInfo) != services.FileTypeNPC {
+			continue
+		}
+
+		// Links pointing outside the allowed roots are skipped.
+		if _, err := s.pathResolver.Resolve(fullPath); err != nil {
+			continue
+		}
+
+		data, err := s.fileEditor.ReadFile(fullPath)
+		if err != nil {
+			scan.errors = append(scan.errors, NPCTableError{Path: fullPath, Error: err.Error()})
+			continue
+		}
+
+		npcData, err := s.fileEditor.ReadNPCFileBytes(data)
+		if err != nil {
+			scan.errors = append(scan.errors, NPCTableError{Path: fullPath, Error: err.Error()})
+			continue
+		}
+
+		scan.files = append(scan.files, npcTableFile{path: fullPath, info: entryInfo, data: npcData, hash: utils.CalculateFileHash(data)})
+	}
+
+	return scan, true
+}
+
+type npcCondition struct {
+	field  string
+	op     string
+	number float64
+	text   string
+}
+
+func newNPCCondition(field, op, value string) (npcCondition, error) {
+	condition := npcCondition{field: field, op: op}
+
+	if field == "name" {
+		if op != "eq" && op != "ne" && op != "contains" {
+			return condition, fmt.Errorf("unsupported operator %s for field name", op)
+		}
+
+		condition.text = value
+		return condition, nil
+	}
+
+	if !slices.Contains(services.NPCFieldNames(), field) {
+		return condition, fmt.Errorf("unknown field %s", field)
+	}
+
+	switch op {
+	case "eq", "ne", "gt", "gte", "lt", "lte":
+	default:
+		return condition, fmt.Errorf("unsupported operator %s for field %s", op, field)
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return condition, fmt.Errorf("value for field %s must be a number", field)
+	}
+
+	condition.number = number
+	return condition, nil
+}
+
+func matchesNPCConditions(data *services.NPCFileData, conditions []npcCondition) bool {
+	for _, condition := range conditions {
+		if condition.field == "name" {
+			name := utils.ReadStringFromBytes(data.Name[:])
+			var matched bool
+			switch condition.op {
+			case "eq":
+				matched = strings.EqualFold(name, condition.text)
+			case "ne":
+				matched = !strings.EqualFold(name, condition.text)
+			case "contains":
+				matched = strings.Contains(strings.ToLower(name), strings.ToLower(condition.text))
+			}
+
+			if !matched {
+				return false
+			}
+
+			continue
+		}
+
+		value, err := services.NPCFieldValue(data, condition.field)
+		if err != nil {
+			return false
+		}
+
+		var matched bool
+		switch condition.op {
+		case "eq":
+			matched = value == condition.number
+		case "ne":
+			matched = value != condition.number
+		case "gt":
+			matched = value > condition.number
+		case "gte":
+			matched = value >= condition.number
+		case "lt":
+			matched = value < condition.number
+		case "lte":
+			matched = value <= condition.number
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func applyNPCFieldUpdates(data *services.NPCFileData, updates []NPCFieldUpdateAPIData) error {
+	for _, update := range updates {
+		current, err := services.NPCFieldValue(data, update.Field)
+		if err != nil {
+			return err
+		}
+
+		value := *update.Value
+		switch update.Op {
+		case "add":
+			value = current + value
+		case "multiply":
+			value = current * value
+		}
+
+		if err := services.SetNPCFieldValue(data, update.Field, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareNPCTableFiles orders two NPC files by the given field, falling back
+// to their paths when the values are equal.
+func compareNPCTableFiles(a, b npcTableFile, field string) int {
+	switch field {
+	case "", "path":
+	case "name":
+		nameA := strings.ToLower(utils.ReadStringFromBytes(a.data.Name[:]))
+		nameB := strings.ToLower(utils.ReadStringFromBytes(b.data.Name[:]))
+		if comparison := strings.Compare(nameA, nameB); comparison != 0 {
+			return comparison
+		}
+	default:
+		valueA, _ := services.NPCFieldValue(a.data, field)
+		valueB, _ := services.NPCFieldValue(b.data, field)
+		if valueA != valueB {
+			if valueA < valueB {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return strings.Compare(a.path, b.path)
+}
+
+type NPCTableRow struct {
+	Path string `json:"path"`
+	NPCFileAPIData
+}
+
+type NPCTableError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+type NPCTableResponse struct {
+	Rows   []NPCTableRow   `json:"rows"`
+	Fields []string        `json:"fields"`
+	Errors []NPCTableError `json:"errors"`
+}
+
+type NPCConditionAPIData struct {
+	Field string      `json:"field" validate:"required"`
+	Op    string      `json:"op" validate:"required"`
+	Value interface{} `json:"value"`
+}
+
+type NPCFieldUpdateAPIData struct {
+	Field string   `json:"field" validate:"required"`
+	Op    string   `json:"op" validate:"required,oneof=set add multiply"`
+	Value *float64 `json:"value" validate:"required"`
+}
+
+type NPCBatchUpdateRequest struct {
+	Path        string                  `json:"path" validate:"required"`
+	Where       []NPCConditionAPIData   `json:"where" validate:"dive"`
+	Updates     []NPCFieldUpdateAPIData `json:"updates" validate:"required,min=1,dive"`
+	DryRun      bool                    `json:"dry_run"`
+	ChangeSetID string                  `json:"change_set_id"`
+}
+
+type NPCBatchUpdateResult struct {
+	Path       string                 `json:"path"`
+	RevisionID *int64                 `json:"revision_id,omitempty"`
+	Changes    []services.FieldChange `json:"changes"`
+}
+
+type NPCBatchUpdateFailure struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+type NPCBatchUpdateResponse struct {
+	ChangeSetID string                  `json:"change_set_id,omitempty"`
+	DryRun      bool                    `json:"dry_run"`
+	Matched     int                     `json:"matched"`
+	Updated     []NPCBatchUpdateResult  `json:"updated"`
+	Failed      []NPCBatchUpdateFailure `json:"failed"`
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+// noAllowedRootsMessage is returned for every file request while ALLOWED_ROOTS
+// is not set.
+const noAllowedRootsMessage = "ALLOWED_ROOTS is not set, so file access is disabled. Set ALLOWED_ROOTS to the server directories."
+
+// resolveRequestPath checks that path is inside the allowed roots and returns
+// its clean form. On failure the error response is written and false returned.
+func (s *Server) resolveRequestPath(w http.ResponseWriter, path string, errorContext string) (string, bool) {
+	cleanPath, err := s.pathResolver.Resolve(path)
+	if err != nil {
+		if errors.Is(err, services.ErrPathNotAllowed) {
+			message := "Path is outside the allowed directories"
+			if errors.Is(err, services.ErrNoAllowedRoots) {
+				message = noAllowedRootsMessage
+			}
+
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusForbidden, map[string]interface{}{
+				"errorCode": constants.ErrorCodePathNotAllowed,
+				"context":   errorContext,
+				"errors":    []string{message},
+			})
+			return "", false
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   errorContext,
+			"errors":    []string{"Cannot resolve path: " + err.Error()},
+		})
+		return "", false
+	}
+
+	return cleanPath, true
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	defaultProcessLogLines = 200
+	maxProcessLogLines     = 5000
+)
+
+// processLogQuery is where a log request starts reading. Offset is -1 to read
+// the last Limit lines.
+type processLogQuery struct {
+	Offset int64
+	Limit  int
+}
+
+// parseProcessLogQuery reads the tail, offset and limit query parameters.
+// Without an offset the last tail lines are read.
+func parseProcessLogQuery(r *http.Request, defaultTail int) (*processLogQuery, error) {
+	query := &processLogQuery{Offset: -1, Limit: defaultTail}
+
+	if tailStr := r.URL.Query().Get("tail"); tailStr != "" {
+		tail, err := strconv.Atoi(tailStr)
+		if err != nil || tail < 0 || tail > maxProcessLogLines {
+			return nil, fmt.Errorf("tail must be between 0 and %d", maxProcessLogLines)
+		}
+		query.Limit = tail
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("offset must be a non-negative number")
+		}
+		query.Offset = offset
+		query.Limit = maxProcessLogLines
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxProcessLogLines {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxProcessLogLines)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// getLogProcessID writes an error response and returns false when the id URL
+// parameter does not name a server process.
+func (s *Server) getLogProcessID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{"Invalid process ID"},
+		})
+		return 0, false
+	}
+
+	if _, err := s.internalDB.GetServerProcess(id); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+			"errorCode": constants.ErrorCodeNotFound,
+			"context":   "server",
+			"errors":    []string{err.Error()},
+		})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (s *Server) handleGetProcessLogs(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionManageServer) {
+		return
+	}
+
+	id, ok := s.getLogProcessID(w, r)
+	if !ok {
+		return
+	}
+
+	query, err := parseProcessLogQuery(r, defaultProcessLogLines)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	page := s.processLogs.Read(id, query.Offset, query.Limit)
+
+	_ = utils.WriteJSONResponse(w, ProcessLogsResponse{
+		ProcessLogPage: page,
+		LogFile:        s.processLogs.LogFilePath(id),
+	})
+}
+
+// handleStreamProcessLogs sends the last tail lines followed by every new line
+// as server-sent events. Each line is a log event whose id is its offset, so a
+// reconnecting client continues after the last line it received.
+func (s *Server) handleStreamProcessLogs(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionManageServer) {
+		return
+	}
+
+	id, ok := s.getLogProcessID(w, r)
+	if !ok {
+		return
+	}
+
+	query, err := parseProcessLogQuery(r, 100)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		if lastOffset, err := strconv.ParseInt(lastEventID, 10, 64); err == nil && lastOffset >= 0 {
+			query.Offset = lastOffset + 1
+			query.Limit = maxProcessLogLines
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	page, lines, unfollow := s.processLogs.Follow(id, query.Offset, query.Limit)
+	defer unfollow()
+
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+
+	if page.Truncated {
+		if _, err := fmt.Fprintf(w, "event: truncated\ndata: {\"first_offset\":%d}\n\n", page.FirstOffset); err != nil {
+			return
+		}
+	}
+
+	next := page.NextOffset
+	if len(page.Lines) > 0 {
+		next = page.Lines[len(page.Lines)-1].Offset + 1
+	}
+
+	if !writeProcessLogLines(w, page.Lines) {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(fileEventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-lines:
+			if line.Offset < next {
+				continue
+			}
+
+			// Lines the stream fell behind on are read back from memory.
+			if line.Offset > next {
+				missed := s.processLogs.Read(id, next, int(line.Offset-next))
+				if !writeProcessLogLines(w, missed.Lines) {
+					return
+				}
+			}
+
+			if !writeProcessLogLines(w, []services.ProcessLogLine{line}) {
+				return
+			}
+			next = line.Offset + 1
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeProcessLogLines(w http.ResponseWriter, lines []services.ProcessLogLine) bool {
+	for _, line := range lines {
+		data, err := json.Marshal(line)
+		if err != nil {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "event: log\nid: %d\ndata: %s\n\n", line.Offset, data); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ProcessLogsResponse struct {
+	services.ProcessLogPage
+	LogFile string `json:"log_file"`
+}
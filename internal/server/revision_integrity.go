@@ -0,0 +1,50 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleGetRevisionIntegrityReport(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionVerifyRevisions) {
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"data": s.revisionIntegrity.LastReport(),
+	})
+}
+
+func (s *Server) handleVerifyRevisions(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionVerifyRevisions) {
+		return
+	}
+
+	report, err := s.revisionIntegrity.Verify()
+	if err != nil {
+		if errors.Is(err, services.ErrRevisionVerificationRunning) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusConflict, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "file-system",
+				"errors":    []string{"Revision verification is already running"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to verify revisions: " + err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, map[string]interface{}{
+		"data": report,
+	})
+}
@@ -26,6 +26,7 @@ func (s *Server) RegisterRoutes() http.Handler {
 		AllowedOrigins:   []string{"https://omnihance.com", "https://*.omnihance.com", "http://localhost:*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowedHeaders:   []string{"*"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -4,12 +4,14 @@ import (
 	"embed"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/omnihance/omnihance-a3-agent/internal/config"
 	"github.com/omnihance/omnihance-a3-agent/internal/db"
 	"github.com/omnihance/omnihance-a3-agent/internal/logger"
 	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/robfig/cron/v3"
 )
 
 type Server struct {
@@ -20,8 +22,17 @@ type Server struct {
 	version              string
 	internalDB           db.InternalDB
 	fileEditor           services.FileEditorService
+	pathResolver         services.PathResolverService
+	revisionStore        services.RevisionStoreService
+	revisionIntegrity    services.RevisionIntegrityService
+	fileWatcher          services.FileWatcherService
 	processService       services.ProcessService
 	serverManagerService services.ServerManagerService
+	processLogs          services.ProcessLogService
+	cron                 *cron.Cron
+	fileLocksMu          sync.Mutex
+	fileEventsMu         sync.Mutex
+	fileEventSubscribers map[chan FileEventNotification]struct{}
 }
 
 func NewServer(
@@ -32,8 +43,13 @@ func NewServer(
 	version string,
 	internalDB db.InternalDB,
 	fileEditor services.FileEditorService,
+	pathResolver services.PathResolverService,
+	revisionStore services.RevisionStoreService,
+	revisionIntegrity services.RevisionIntegrityService,
+	fileWatcher services.FileWatcherService,
 	processService services.ProcessService,
 	serverManagerService services.ServerManagerService,
+	processLogs services.ProcessLogService,
 ) *http.Server {
 	newServer := &Server{
 		cfg:                  cfg,
@@ -43,10 +59,28 @@ func NewServer(
 		version:              version,
 		internalDB:           internalDB,
 		fileEditor:           fileEditor,
+		pathResolver:         pathResolver,
+		revisionStore:        revisionStore,
+		revisionIntegrity:    revisionIntegrity,
+		fileWatcher:          fileWatcher,
 		processService:       processService,
 		serverManagerService: serverManagerService,
+		processLogs:          processLogs,
+		fileEventSubscribers: make(map[chan FileEventNotification]struct{}),
 	}
 
+	newServer.releaseStaleFileLocks()
+
+	newServer.cron = cron.New(cron.WithSeconds())
+	_, err := newServer.cron.AddFunc("@every 1m", func() { newServer.releaseStaleFileLocks() })
+	if err != nil {
+		newServer.log.Error("Failed to schedule stale file lock cleanup", logger.Field{Key: "error", Value: err})
+	}
+
+	newServer.cron.Start()
+
+	go newServer.recordExternalChanges()
+
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%s", newServer.cfg.Port),
 		Handler:           newServer.RegisterRoutes(),
@@ -2,8 +2,10 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/omnihance/omnihance-a3-agent/internal/db"
 	"github.com/omnihance/omnihance-a3-agent/internal/mw"
 	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
 	"github.com/omnihance/omnihance-a3-agent/internal/utils"
 )
 
@@ -30,6 +33,10 @@ func (s *Server) InitializeServerRoutes(r *chi.Mux) {
 		r.Post("/processes/{id}/start", s.handleStartProcess)
 		r.Post("/processes/{id}/stop", s.handleStopProcess)
 		r.Get("/processes/{id}/status", s.handleGetProcessStatus)
+		r.Get("/processes/{id}/events", s.handleGetProcessEvents)
+		r.Get("/processes/{id}/logs", s.handleGetProcessLogs)
+		r.Get("/processes/{id}/logs/stream", s.handleStreamProcessLogs)
+		r.Get("/events", s.handleGetProcessEvents)
 	})
 }
 
@@ -74,8 +81,18 @@ func (s *Server) handleCreateServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	launch, ok := s.validateServerProcessLaunch(w, req.ServerProcessLaunchRequest)
+	if !ok {
+		return
+	}
+
 	cleanPath := filepath.Clean(req.Path)
-	if !s.validateServerProcessPath(w, cleanPath, nil) {
+	if !s.validateServerProcessPath(w, cleanPath, launch, nil) {
+		return
+	}
+
+	dependsOn, ok := s.validateServerProcessDependencies(w, nil, req.DependsOn)
+	if !ok {
 		return
 	}
 
@@ -89,7 +106,7 @@ func (s *Server) handleCreateServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	process, err := s.internalDB.CreateServerProcess(req.Name, cleanPath, req.Port, maxOrder+1)
+	process, err := s.internalDB.CreateServerProcess(req.Name, cleanPath, req.Port, launch, dependsOn, maxOrder+1)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
@@ -163,12 +180,22 @@ func (s *Server) handleUpdateServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	launch, ok := s.validateServerProcessLaunch(w, req.ServerProcessLaunchRequest)
+	if !ok {
+		return
+	}
+
 	cleanPath := filepath.Clean(req.Path)
-	if !s.validateServerProcessPath(w, cleanPath, &id) {
+	if !s.validateServerProcessPath(w, cleanPath, launch, &id) {
+		return
+	}
+
+	dependsOn, ok := s.validateServerProcessDependencies(w, &id, req.DependsOn)
+	if !ok {
 		return
 	}
 
-	if err := s.internalDB.UpdateServerProcess(id, req.Name, cleanPath, req.Port); err != nil {
+	if err := s.internalDB.UpdateServerProcess(id, req.Name, cleanPath, req.Port, launch, dependsOn); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
 			"context":   "server",
@@ -206,8 +233,7 @@ func (s *Server) handleDeleteServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	proc, err := s.internalDB.GetServerProcess(id)
-	if err != nil {
+	if _, err := s.internalDB.GetServerProcess(id); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
 			"errorCode": constants.ErrorCodeNotFound,
 			"context":   "server",
@@ -216,7 +242,7 @@ func (s *Server) handleDeleteServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	isRunning, err := s.processService.IsProcessRunning(proc.Path)
+	status, err := s.serverManagerService.GetProcessStatus(id)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
@@ -226,7 +252,7 @@ func (s *Server) handleDeleteServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if isRunning {
+	if status.Running {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
 			"errorCode": constants.ErrorCodeBadRequest,
 			"context":   "server",
@@ -235,6 +261,32 @@ func (s *Server) handleDeleteServerProcess(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	processes, err := s.internalDB.GetServerProcesses()
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "server",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	var dependents []string
+	for _, process := range processes {
+		if slices.Contains(process.DependsOn, id) {
+			dependents = append(dependents, process.Name)
+		}
+	}
+
+	if len(dependents) > 0 {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{"Cannot delete a process that other processes depend on: " + strings.Join(dependents, ", ")},
+		})
+		return
+	}
+
 	if err := s.internalDB.DeleteServerProcess(id); err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
@@ -293,17 +345,24 @@ func (s *Server) handleStartFullServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.serverManagerService.StartServerSequence(); err != nil {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+	outcomes, err := s.serverManagerService.StartServerSequence()
+	if err != nil {
+		response := map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
 			"context":   "server",
 			"errors":    []string{err.Error()},
-		})
+		}
+		if outcomes != nil {
+			response["processes"] = outcomes
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, response)
 		return
 	}
 
 	_ = utils.WriteJSONResponse(w, map[string]interface{}{
-		"message": "Server started successfully",
+		"message":   "Server started successfully",
+		"processes": outcomes,
 	})
 }
 
@@ -312,17 +371,24 @@ func (s *Server) handleStopFullServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.serverManagerService.StopServerSequence(); err != nil {
-		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+	outcomes, err := s.serverManagerService.StopServerSequence()
+	if err != nil {
+		response := map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
 			"context":   "server",
 			"errors":    []string{err.Error()},
-		})
+		}
+		if outcomes != nil {
+			response["processes"] = outcomes
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, response)
 		return
 	}
 
 	_ = utils.WriteJSONResponse(w, map[string]interface{}{
-		"message": "Server stopped successfully",
+		"message":   "Server stopped successfully",
+		"processes": outcomes,
 	})
 }
 
@@ -411,8 +477,76 @@ func (s *Server) handleGetProcessStatus(w http.ResponseWriter, r *http.Request)
 	_ = utils.WriteJSONResponse(w, status)
 }
 
-func (s *Server) validateServerProcessPath(w http.ResponseWriter, path string, excludeID *int64) bool {
-	cleanPath := filepath.Clean(path)
+// handleGetProcessEvents lists the supervisor's crash and restart events,
+// newest first, for one process or, without an id in the URL, for all of them.
+func (s *Server) handleGetProcessEvents(w http.ResponseWriter, r *http.Request) {
+	var processID *int64
+	if idStr := chi.URLParam(r, "id"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "server",
+				"errors":    []string{"Invalid process ID"},
+			})
+			return
+		}
+
+		if _, err := s.internalDB.GetServerProcess(id); err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "server",
+				"errors":    []string{err.Error()},
+			})
+			return
+		}
+
+		processID = &id
+	}
+
+	page := 1
+	pageSize := 20
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if parsed, err := strconv.Atoi(pageStr); err == nil && parsed >= 1 {
+			page = parsed
+		}
+	}
+
+	if pageSizeStr := r.URL.Query().Get("pageSize"); pageSizeStr != "" {
+		if parsed, err := strconv.Atoi(pageSizeStr); err == nil && parsed >= 1 && parsed <= 100 {
+			pageSize = parsed
+		}
+	}
+
+	events, totalCount, err := s.internalDB.GetServerProcessEventsPaginated(processID, page, pageSize)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "server",
+			"errors":    []string{err.Error()},
+		})
+		return
+	}
+
+	_ = utils.WriteJSONResponse(w, ServerProcessEventsResponse{
+		Data: events,
+		Pagination: PaginationInfo{
+			TotalCount: totalCount,
+			Page:       page,
+			PageSize:   pageSize,
+		},
+	})
+}
+
+// validateServerProcessPath also rejects a process launching the same binary
+// with the same arguments in the same working directory as an existing one.
+// Processes sharing a binary must differ in one of them.
+func (s *Server) validateServerProcessPath(w http.ResponseWriter, path string, launch db.ServerProcessLaunch, excludeID *int64) bool {
+	cleanPath, ok := s.resolveRequestPath(w, path, "server")
+	if !ok {
+		return false
+	}
 
 	info, err := s.fileEditor.Stat(cleanPath)
 	if err != nil {
@@ -461,7 +595,7 @@ func (s *Server) validateServerProcessPath(w http.ResponseWriter, path string, e
 		return false
 	}
 
-	existingProcess, err := s.internalDB.GetServerProcessByPath(cleanPath)
+	existingProcesses, err := s.internalDB.GetServerProcessesByPath(cleanPath)
 	if err != nil {
 		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
 			"errorCode": constants.ErrorCodeInternalServerError,
@@ -471,12 +605,16 @@ func (s *Server) validateServerProcessPath(w http.ResponseWriter, path string, e
 		return false
 	}
 
-	if existingProcess != nil {
-		if excludeID == nil || existingProcess.ID != *excludeID {
+	for _, existingProcess := range existingProcesses {
+		if excludeID != nil && existingProcess.ID == *excludeID {
+			continue
+		}
+
+		if slices.Equal(existingProcess.Args, launch.Args) && launchWorkingDir(path, existingProcess.WorkingDir) == launchWorkingDir(path, launch.WorkingDir) {
 			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
 				"errorCode": constants.ErrorCodeBadRequest,
 				"context":   "server",
-				"errors":    []string{"A process with this path already exists"},
+				"errors":    []string{"A process with this path, arguments and working directory already exists"},
 			})
 			return false
 		}
@@ -485,18 +623,166 @@ func (s *Server) validateServerProcessPath(w http.ResponseWriter, path string, e
 	return true
 }
 
+// launchWorkingDir returns the directory a process launching the binary at
+// path runs in.
+func launchWorkingDir(path string, workingDir *string) string {
+	if workingDir == nil {
+		return filepath.Dir(path)
+	}
+
+	return *workingDir
+}
+
+// validateServerProcessDependencies checks that every dependency is another
+// existing process and, when updating process id, that the dependencies do not
+// form a cycle. A new process cannot be part of a cycle, as no process depends
+// on it yet. The dependencies are returned without duplicates.
+func (s *Server) validateServerProcessDependencies(w http.ResponseWriter, id *int64, dependsOn []int64) ([]int64, bool) {
+	processes, err := s.internalDB.GetServerProcesses()
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeInternalServerError,
+			"context":   "server",
+			"errors":    []string{"Failed to check dependencies: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	unique := make([]int64, 0, len(dependsOn))
+	for _, dependsOnID := range dependsOn {
+		if slices.Contains(unique, dependsOnID) {
+			continue
+		}
+
+		if id != nil && dependsOnID == *id {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "server",
+				"errors":    []string{"A process cannot depend on itself"},
+			})
+			return nil, false
+		}
+
+		exists := slices.ContainsFunc(processes, func(process db.ServerProcess) bool {
+			return process.ID == dependsOnID
+		})
+		if !exists {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "server",
+				"errors":    []string{fmt.Sprintf("Dependency %d does not exist", dependsOnID)},
+			})
+			return nil, false
+		}
+
+		unique = append(unique, dependsOnID)
+	}
+
+	if id == nil {
+		return unique, true
+	}
+
+	for i := range processes {
+		if processes[i].ID == *id {
+			processes[i].DependsOn = unique
+		}
+	}
+
+	if err := services.ValidateProcessDependencies(processes); err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{"Invalid dependencies: " + err.Error()},
+		})
+		return nil, false
+	}
+
+	return unique, true
+}
+
+// validateServerProcessLaunch checks the launch configuration of a request and
+// returns it with the working directory cleaned.
+func (s *Server) validateServerProcessLaunch(w http.ResponseWriter, req ServerProcessLaunchRequest) (db.ServerProcessLaunch, bool) {
+	launch := db.ServerProcessLaunch{
+		Args:                 db.ProcessArgs(req.Args),
+		Env:                  db.ProcessEnv(req.Env),
+		StartTimeoutSeconds:  req.StartTimeoutSeconds,
+		CheckIntervalSeconds: req.CheckIntervalSeconds,
+	}
+
+	for key := range req.Env {
+		if key == "" || strings.ContainsAny(key, "=\x00") {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+				"errorCode": constants.ErrorCodeBadRequest,
+				"context":   "server",
+				"errors":    []string{fmt.Sprintf("Invalid environment variable name %q", key)},
+			})
+			return launch, false
+		}
+	}
+
+	if launch.CheckInterval() > launch.StartTimeout() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{"Check interval must not be longer than the start timeout"},
+		})
+		return launch, false
+	}
+
+	if req.WorkingDir == nil || strings.TrimSpace(*req.WorkingDir) == "" {
+		return launch, true
+	}
+
+	workingDir := filepath.Clean(*req.WorkingDir)
+	resolvedDir, ok := s.resolveRequestPath(w, workingDir, "server")
+	if !ok {
+		return launch, false
+	}
+
+	info, err := s.fileEditor.Stat(resolvedDir)
+	if err != nil || !info.IsDir() {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "server",
+			"errors":    []string{"Working directory does not exist or is not a directory"},
+		})
+		return launch, false
+	}
+
+	launch.WorkingDir = &workingDir
+	return launch, true
+}
+
+type ServerProcessLaunchRequest struct {
+	Args                 []string          `json:"args"`
+	Env                  map[string]string `json:"env"`
+	WorkingDir           *string           `json:"working_dir"`
+	StartTimeoutSeconds  *int              `json:"start_timeout_seconds" validate:"omitempty,min=1,max=3600"`
+	CheckIntervalSeconds *int              `json:"check_interval_seconds" validate:"omitempty,min=1,max=300"`
+}
+
 type CreateServerProcessRequest struct {
-	Name string `json:"name" validate:"required"`
-	Path string `json:"path" validate:"required"`
-	Port *int   `json:"port"`
+	Name      string  `json:"name" validate:"required"`
+	Path      string  `json:"path" validate:"required"`
+	Port      *int    `json:"port"`
+	DependsOn []int64 `json:"depends_on"`
+	ServerProcessLaunchRequest
 }
 
 type UpdateServerProcessRequest struct {
-	Name string `json:"name" validate:"required"`
-	Path string `json:"path" validate:"required"`
-	Port *int   `json:"port"`
+	Name      string  `json:"name" validate:"required"`
+	Path      string  `json:"path" validate:"required"`
+	Port      *int    `json:"port"`
+	DependsOn []int64 `json:"depends_on"`
+	ServerProcessLaunchRequest
 }
 
 type ReorderServerProcessesRequest struct {
 	Updates []db.ReorderUpdate `json:"updates" validate:"required"`
 }
+
+type ServerProcessEventsResponse struct {
+	Data       []db.ServerProcessEvent `json:"data"`
+	Pagination PaginationInfo          `json:"pagination"`
+}
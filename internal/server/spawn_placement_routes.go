@@ -0,0 +1,283 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/constants"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/permissions"
+	"github.com/omnihance/omnihance-a3-agent/internal/services"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+func (s *Server) handleSpawnSummary(w http.ResponseWriter, r *http.Request) {
+	if !s.requireUserPermission(w, r, permissions.ActionViewFiles) {
+		return
+	}
+
+	pathParam := r.URL.Query().Get("path")
+	if pathParam == "" {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeBadRequest,
+			"context":   "file-system",
+			"errors":    []string{"Path parameter is required"},
+		})
+		return
+	}
+
+	cleanPath, ok := s.resolveRequestPath(w, pathParam, "file-system")
+	if !ok {
+		return
+	}
+
+	info, err := s.fileEditor.Stat(cleanPath)
+	if err != nil {
+		if s.fileEditor.IsNotExist(err) {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusNotFound, map[string]interface{}{
+				"errorCode": constants.ErrorCodeNotFound,
+				"context":   "file-system",
+				"errors":    []string{"Path not found"},
+			})
+			return
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Cannot read file: " + err.Error()},
+		})
+		return
+	}
+
+	if info.IsDir() || s.fileEditor.GetFileType(cleanPath, info) != services.FileTypeSpawn {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileNotViewable,
+			"context":   "file-system",
+			"errors":    []string{"File is not a spawn file"},
+		})
+		return
+	}
+
+	spawnData, err := s.fileEditor.ReadSpawnFileData(cleanPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read spawn file data: " + err.Error()},
+		})
+		return
+	}
+
+	mapPath, mapData, ok := s.loadSpawnMap(w, r, cleanPath)
+	if !ok {
+		return
+	}
+
+	summary := SpawnSummaryAPIData{
+		Path:        cleanPath,
+		TotalSpawns: len(spawnData),
+		Groups:      []SpawnGroupAPIData{},
+		Issues:      services.ValidateSpawnPlacement(spawnData, mapData),
+	}
+
+	if mapData != nil {
+		summary.MapPath = &mapPath
+		summary.MapWidth = &mapData.Width
+		summary.MapHeight = &mapData.Height
+	}
+
+	if mapID, ok := mapIDFromPath(cleanPath); ok {
+		summary.MapID = &mapID
+	}
+
+	for _, group := range services.GroupSpawnsByMonster(spawnData) {
+		summary.Groups = append(summary.Groups, SpawnGroupAPIData{SpawnGroup: group})
+	}
+
+	if resolve, _ := strconv.ParseBool(r.URL.Query().Get("resolve")); resolve {
+		monsterIDs := make([]int64, len(summary.Groups))
+		for i, group := range summary.Groups {
+			monsterIDs[i] = int64(group.MonsterID)
+		}
+
+		monsterNames, err := resolveClientDataNames(monsterIDs, s.internalDB.GetMonsterClientDataByIDs)
+		if err != nil {
+			_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+				"errorCode": constants.ErrorCodeInternalServerError,
+				"context":   "file-system",
+				"errors":    []string{"Failed to resolve monster names: " + err.Error()},
+			})
+			return
+		}
+
+		for i := range summary.Groups {
+			monsterName, found := monsterNames[int64(summary.Groups[i].MonsterID)]
+			summary.Groups[i].MonsterName = &monsterName
+			summary.Groups[i].MonsterFound = &found
+		}
+
+		if summary.MapID != nil {
+			mapNames, err := resolveClientDataNames([]int64{*summary.MapID}, s.internalDB.GetMapClientDataByIDs)
+			if err != nil {
+				_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+					"errorCode": constants.ErrorCodeInternalServerError,
+					"context":   "file-system",
+					"errors":    []string{"Failed to resolve map name: " + err.Error()},
+				})
+				return
+			}
+
+			mapName, found := mapNames[*summary.MapID]
+			summary.MapName = &mapName
+			summary.MapFound = &found
+		}
+	}
+
+	_ = utils.WriteJSONResponse(w, summary)
+}
+
+// checkSpawnPlacement validates spawns about to replace previousData in
+// spawnPath against the map they belong to. Added or changed spawns outside
+// the map or on blocked cells are rejected with the error response written
+// and false returned. Duplicate coordinates, and problems with spawns the
+// edit left as they were, are returned as warnings. Without a map file only
+// duplicates are checked.
+func (s *Server) checkSpawnPlacement(w http.ResponseWriter, r *http.Request, spawnPath string, previousData []byte, spawns []services.NPCSpawnData) ([]services.SpawnIssue, bool) {
+	_, mapData, ok := s.loadSpawnMap(w, r, spawnPath)
+	if !ok {
+		return nil, false
+	}
+
+	// A previous file that cannot be read leaves every spawn counted as
+	// changed.
+	previous, _ := s.fileEditor.ReadSpawnFileBytes(previousData)
+	untouched := services.UntouchedSpawns(previous, spawns)
+
+	var invalid []services.SpawnIssue
+	warnings := []services.SpawnIssue{}
+	for _, issue := range services.ValidateSpawnPlacement(spawns, mapData) {
+		if issue.IsWarning() || untouched[issue.Index] {
+			warnings = append(warnings, issue)
+		} else {
+			invalid = append(invalid, issue)
+		}
+	}
+
+	if len(invalid) > 0 {
+		errors := make([]string, len(invalid))
+		for i, issue := range invalid {
+			errors[i] = fmt.Sprintf("Spawn %d: %s", issue.Index, issue.Detail)
+		}
+
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusBadRequest, map[string]interface{}{
+			"errorCode":    constants.ErrorCodeBadRequest,
+			"context":      "file-system",
+			"errors":       errors,
+			"spawn_issues": invalid,
+		})
+		return nil, false
+	}
+
+	return warnings, true
+}
+
+// loadSpawnMap reads the map a spawn file belongs to, taken from the map_path
+// query parameter or else found with findSpawnMapFile. It returns a nil map
+// when there is none, or when the map it found cannot be read. On failure to
+// read the map given in map_path the error response is written and false
+// returned.
+func (s *Server) loadSpawnMap(w http.ResponseWriter, r *http.Request, spawnPath string) (string, *services.MapFileData, bool) {
+	mapPath := r.URL.Query().Get("map_path")
+	if mapPath != "" {
+		cleanPath, ok := s.resolveRequestPath(w, mapPath, "file-system")
+		if !ok {
+			return "", nil, false
+		}
+		mapPath = cleanPath
+	} else {
+		mapPath = s.findSpawnMapFile(spawnPath)
+		if mapPath == "" {
+			return "", nil, true
+		}
+
+		mapData, err := s.fileEditor.ReadMapFileData(mapPath)
+		if err != nil {
+			s.log.Warn("Failed to read map file, checking spawns for duplicates only", logger.Field{Key: "path", Value: mapPath}, logger.Field{Key: "error", Value: err})
+			return "", nil, true
+		}
+
+		return mapPath, mapData, true
+	}
+
+	mapData, err := s.fileEditor.ReadMapFileData(mapPath)
+	if err != nil {
+		_ = utils.WriteJSONResponseWithStatus(w, http.StatusInternalServerError, map[string]interface{}{
+			"errorCode": constants.ErrorCodeFileReadError,
+			"context":   "file-system",
+			"errors":    []string{"Failed to read map file data: " + err.Error()},
+		})
+		return "", nil, false
+	}
+
+	return mapPath, mapData, true
+}
+
+// findSpawnMapFile returns the map file with the same number as the spawn
+// file, such as "12.map" for "12.n_ndt", in the spawn file's directory. It
+// returns "" when there is no such map inside the allowed roots.
+func (s *Server) findSpawnMapFile(spawnPath string) string {
+	mapID, ok := mapIDFromPath(spawnPath)
+	if !ok {
+		return ""
+	}
+
+	return s.findFileInDirectory(filepath.Dir(spawnPath), strconv.FormatInt(mapID, 10)+services.MapFileExtension)
+}
+
+// findFileInDirectory returns the path of the regular file in dir whose name
+// equals name, ignoring case, or "" if there is none.
+func (s *Server) findFileInDirectory(dir string, name string) string {
+	entries, err := s.fileEditor.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.EqualFold(entry.Name(), name) {
+			continue
+		}
+
+		path, err := s.pathResolver.Resolve(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			s.log.Warn("Skipping map file outside the allowed directories", logger.Field{Key: "path", Value: filepath.Join(dir, entry.Name())})
+			continue
+		}
+
+		return path
+	}
+
+	return ""
+}
+
+type SpawnSummaryAPIData struct {
+	Path        string                `json:"path"`
+	TotalSpawns int                   `json:"total_spawns"`
+	MapID       *int64                `json:"map_id,omitempty"`
+	MapName     *string               `json:"map_name,omitempty"`
+	MapFound    *bool                 `json:"map_found,omitempty"`
+	MapPath     *string               `json:"map_path,omitempty"`
+	MapWidth    *uint32               `json:"map_width,omitempty"`
+	MapHeight   *uint32               `json:"map_height,omitempty"`
+	Groups      []SpawnGroupAPIData   `json:"groups"`
+	Issues      []services.SpawnIssue `json:"issues"`
+}
+
+type SpawnGroupAPIData struct {
+	services.SpawnGroup
+	MonsterName  *string `json:"monster_name,omitempty"`
+	MonsterFound *bool   `json:"monster_found,omitempty"`
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrArchiveTooLarge is returned by ReadArchive when the extracted files would
+// exceed the allowed size.
+var ErrArchiveTooLarge = errors.New("archive contents exceed the maximum extracted size")
+
+type ArchiveEntry struct {
+	// Name is the slash separated path of the file inside the archive.
+	Name string
+	Data []byte
+}
+
+// WriteDirectoryArchive writes a zip archive of the files and directories
+// below root to w, named relative to root. Paths for which include returns
+// false are skipped. Symbolic links to files are stored as the file they point
+// to; links to directories are skipped so that the walk cannot loop.
+func WriteDirectoryArchive(w io.Writer, root string, include func(path string) bool) (int, error) {
+	zw := zip.NewWriter(w)
+	files := 0
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if filePath == root {
+			return nil
+		}
+
+		if !include(filePath) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(relative)
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			_, err := zw.CreateHeader(&zip.FileHeader{Name: name + "/", Modified: info.ModTime()})
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+
+		writer, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if _, err := io.Copy(writer, file); err != nil {
+			return err
+		}
+
+		files++
+		return nil
+	})
+	if err != nil {
+		return files, fmt.Errorf("failed to archive %s: %w", root, err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return files, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return files, nil
+}
+
+// ReadArchive returns the files in a zip archive in archive order. Directory
+// entries are skipped. Entries whose names leave the archive root, that are
+// not regular files or that appear twice are rejected, as is an archive whose
+// files add up to more than maxExtractedSize bytes.
+func ReadArchive(data []byte, maxExtractedSize int64) ([]ArchiveEntry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	var entries []ArchiveEntry
+	seen := make(map[string]bool)
+	var total int64
+
+	for _, file := range zr.File {
+		name, err := CleanArchiveEntryName(file.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if !file.Mode().IsRegular() {
+			return nil, fmt.Errorf("archive entry %s is not a regular file", file.Name)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("archive entry %s appears more than once", file.Name)
+		}
+		seen[name] = true
+
+		// The sizes recorded in the archive are not trusted; the limit is
+		// applied to the bytes actually extracted.
+		content, err := readArchiveFile(file, maxExtractedSize-total)
+		if err != nil {
+			return nil, err
+		}
+
+		total += int64(len(content))
+		entries = append(entries, ArchiveEntry{Name: name, Data: content})
+	}
+
+	return entries, nil
+}
+
+func readArchiveFile(file *zip.File, remaining int64) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive entry %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, remaining+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive entry %s: %w", file.Name, err)
+	}
+
+	if int64(len(content)) > remaining {
+		return nil, ErrArchiveTooLarge
+	}
+
+	return content, nil
+}
+
+// CleanArchiveEntryName normalises the name of an archive entry and rejects
+// names that are absolute or would be extracted outside the target directory.
+func CleanArchiveEntryName(name string) (string, error) {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(slashed, "/") || (len(slashed) >= 2 && slashed[1] == ':') {
+		return "", fmt.Errorf("archive entry %s has an absolute path", name)
+	}
+
+	cleaned := path.Clean(slashed)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("archive entry %s is outside the archive root", name)
+	}
+
+	return cleaned, nil
+}
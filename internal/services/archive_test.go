@@ -0,0 +1,103 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDirectoryArchiveRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "npc"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "empty"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "skipped"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "npc", "12"), []byte("npc data"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "0.n_ndt"), []byte("spawn data"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "skipped", "secret"), []byte("secret"), 0644))
+
+	var buffer bytes.Buffer
+	files, err := WriteDirectoryArchive(&buffer, root, func(path string) bool {
+		return filepath.Base(path) != "skipped"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, files)
+
+	entries, err := ReadArchive(buffer.Bytes(), 1024)
+	require.NoError(t, err)
+	assert.Equal(t, []ArchiveEntry{
+		{Name: "0.n_ndt", Data: []byte("spawn data")},
+		{Name: "npc/12", Data: []byte("npc data")},
+	}, entries)
+
+	zr, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t, names, "empty/")
+	assert.NotContains(t, names, "skipped/")
+}
+
+func TestReadArchiveRejectsUnsafeArchives(t *testing.T) {
+	build := func(names ...string) []byte {
+		var buffer bytes.Buffer
+		zw := zip.NewWriter(&buffer)
+		for _, name := range names {
+			writer, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = writer.Write([]byte("content"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buffer.Bytes()
+	}
+
+	_, err := ReadArchive(build("../escape"), 1024)
+	assert.Error(t, err)
+
+	_, err = ReadArchive(build("a/b", "a/./b"), 1024)
+	assert.Error(t, err)
+
+	_, err = ReadArchive(build("a", "b"), 10)
+	assert.ErrorIs(t, err, ErrArchiveTooLarge)
+
+	_, err = ReadArchive([]byte("not a zip"), 1024)
+	assert.Error(t, err)
+}
+
+func TestCleanArchiveEntryName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		valid    bool
+	}{
+		{name: "npc/12", expected: "npc/12", valid: true},
+		{name: "npc\\12", expected: "npc/12", valid: true},
+		{name: "a/../b", expected: "b", valid: true},
+		{name: "../b", valid: false},
+		{name: "a/../../b", valid: false},
+		{name: "/etc/passwd", valid: false},
+		{name: "C:/Windows/win.ini", valid: false},
+		{name: ".", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleaned, err := CleanArchiveEntryName(tt.name)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cleaned)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	RecordFormatCSV  = "csv"
+	RecordFormatJSON = "json"
+)
+
+// Record columns use the same names as the JSON API so that exported rows can
+// be matched against API responses.
+var (
+	spawnRecordColumns = []string{"id", "x", "y", "unknown1", "orientation", "spwan_step"}
+	dropRecordColumns  = []string{"item_id", "unknown1", "drop_rate", "unknown2"}
+)
+
+type RecordFieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+func NPCRecordColumns() []string {
+	return append([]string{"name"}, NPCFieldNames()...)
+}
+
+func NPCToRecord(data *NPCFileData) []interface{} {
+	record := []interface{}{utils.ReadStringFromBytes(data.Name[:])}
+	for _, field := range NPCFieldNames() {
+		value, _ := npcField(reflect.ValueOf(data).Elem(), field)
+		record = append(record, value.Uint())
+	}
+
+	return record
+}
+
+// NPCFromRecord builds NPC data from a record keyed by NPCRecordColumns,
+// checking every value against the size of its binary field.
+func NPCFromRecord(record map[string]string) (*NPCFileData, []RecordFieldError) {
+	var data NPCFileData
+	var fieldErrors []RecordFieldError
+
+	if name, ok := record["name"]; !ok {
+		fieldErrors = append(fieldErrors, RecordFieldError{Field: "name", Error: "missing value"})
+	} else if len(name) > len(data.Name) {
+		fieldErrors = append(fieldErrors, RecordFieldError{Field: "name", Error: fmt.Sprintf("must be at most %d bytes", len(data.Name))})
+	} else {
+		copy(data.Name[:], name)
+	}
+
+	for _, field := range NPCFieldNames() {
+		target, _ := npcField(reflect.ValueOf(&data).Elem(), field)
+		if err := setRecordValue(target, record, field); err != nil {
+			fieldErrors = append(fieldErrors, *err)
+		}
+	}
+
+	return &data, fieldErrors
+}
+
+func SpawnRecordColumns() []string {
+	return spawnRecordColumns
+}
+
+func SpawnToRecord(spawn NPCSpawnData) []interface{} {
+	return structToRecord(reflect.ValueOf(spawn))
+}
+
+func SpawnFromRecord(record map[string]string) (NPCSpawnData, []RecordFieldError) {
+	var spawn NPCSpawnData
+	return spawn, structFromRecord(reflect.ValueOf(&spawn).Elem(), spawnRecordColumns, record)
+}
+
+func DropRecordColumns() []string {
+	return dropRecordColumns
+}
+
+func DropToRecord(item DropItemData) []interface{} {
+	return structToRecord(reflect.ValueOf(item))
+}
+
+func DropFromRecord(record map[string]string) (DropItemData, []RecordFieldError) {
+	var item DropItemData
+	return item, structFromRecord(reflect.ValueOf(&item).Elem(), dropRecordColumns, record)
+}
+
+func structToRecord(value reflect.Value) []interface{} {
+	record := make([]interface{}, value.NumField())
+	for i := range record {
+		record[i] = value.Field(i).Uint()
+	}
+
+	return record
+}
+
+func structFromRecord(value reflect.Value, columns []string, record map[string]string) []RecordFieldError {
+	var fieldErrors []RecordFieldError
+	for i, column := range columns {
+		if err := setRecordValue(value.Field(i), record, column); err != nil {
+			fieldErrors = append(fieldErrors, *err)
+		}
+	}
+
+	return fieldErrors
+}
+
+func setRecordValue(target reflect.Value, record map[string]string, field string) *RecordFieldError {
+	raw, ok := record[field]
+	if !ok {
+		return &RecordFieldError{Field: field, Error: "missing value"}
+	}
+
+	bits := target.Type().Bits()
+	parsed, err := strconv.ParseUint(strings.TrimSpace(raw), 10, bits)
+	if err != nil {
+		maxValue := uint64(1)<<bits - 1
+		return &RecordFieldError{Field: field, Error: fmt.Sprintf("must be an integer between 0 and %d", maxValue)}
+	}
+
+	target.SetUint(parsed)
+	return nil
+}
+
+func EncodeRecords(format string, columns []string, rows [][]interface{}) ([]byte, error) {
+	switch format {
+	case RecordFormatCSV:
+		var buffer bytes.Buffer
+		writer := csv.NewWriter(&buffer)
+		if err := writer.Write(columns); err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			values := make([]string, len(row))
+			for i, value := range row {
+				values[i] = fmt.Sprint(value)
+			}
+
+			if err := writer.Write(values); err != nil {
+				return nil, err
+			}
+		}
+
+		writer.Flush()
+		return buffer.Bytes(), writer.Error()
+	case RecordFormatJSON:
+		// Objects are written by hand so that keys keep the column order.
+		var buffer bytes.Buffer
+		buffer.WriteString("[")
+		for i, row := range rows {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+
+			buffer.WriteString("\n  {")
+			for j, value := range row {
+				if j > 0 {
+					buffer.WriteString(", ")
+				}
+
+				key, _ := json.Marshal(columns[j])
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return nil, err
+				}
+
+				buffer.Write(key)
+				buffer.WriteString(": ")
+				buffer.Write(encoded)
+			}
+
+			buffer.WriteString("}")
+		}
+
+		if len(rows) > 0 {
+			buffer.WriteString("\n")
+		}
+
+		buffer.WriteString("]\n")
+		return buffer.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// DecodeRecords parses CSV with a header row, or a JSON array of objects, into
+// records keyed by column name.
+func DecodeRecords(format string, data []byte) ([]map[string]string, error) {
+	switch format {
+	case RecordFormatCSV:
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("CSV has no header row")
+		}
+
+		header := rows[0]
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+
+		records := make([]map[string]string, 0, len(rows)-1)
+		for _, row := range rows[1:] {
+			record := make(map[string]string, len(header))
+			for i, column := range header {
+				record[column] = row[i]
+			}
+
+			records = append(records, record)
+		}
+
+		return records, nil
+	case RecordFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		var objects []map[string]interface{}
+		if err := decoder.Decode(&objects); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		records := make([]map[string]string, 0, len(objects))
+		for i, object := range objects {
+			record := make(map[string]string, len(object))
+			for key, value := range object {
+				switch v := value.(type) {
+				case string:
+					record[key] = v
+				case json.Number:
+					record[key] = v.String()
+				default:
+					return nil, fmt.Errorf("invalid JSON: row %d field %s must be a string or a number", i+1, key)
+				}
+			}
+
+			records = append(records, record)
+		}
+
+		return records, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNPCRecordRoundTrip(t *testing.T) {
+	data := &NPCFileData{Id: 12, Level: 55, HP: 4000}
+	copy(data.Name[:], "Goblin")
+	data.Attacks[2].Damage = 90
+
+	for _, format := range []string{RecordFormatCSV, RecordFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			encoded, err := EncodeRecords(format, NPCRecordColumns(), [][]interface{}{NPCToRecord(data)})
+			require.NoError(t, err)
+
+			records, err := DecodeRecords(format, encoded)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+
+			decoded, fieldErrors := NPCFromRecord(records[0])
+			require.Empty(t, fieldErrors)
+			assert.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestNPCFromRecordLimits(t *testing.T) {
+	record := map[string]string{"name": "ThisNameIsLongerThan20Bytes"}
+	for _, field := range NPCFieldNames() {
+		record[field] = "0"
+	}
+
+	record["id"] = "65536"
+	record["level"] = "-1"
+	delete(record, "hp")
+
+	_, fieldErrors := NPCFromRecord(record)
+
+	assert.Equal(t, []RecordFieldError{
+		{Field: "name", Error: "must be at most 20 bytes"},
+		{Field: "id", Error: "must be an integer between 0 and 65535"},
+		{Field: "level", Error: "must be an integer between 0 and 255"},
+		{Field: "hp", Error: "missing value"},
+	}, fieldErrors)
+}
+
+func TestSpawnAndDropRecords(t *testing.T) {
+	spawn := NPCSpawnData{Id: 7, X: 10, Y: 20, Orientation: 3, SpwanStep: 1}
+	encoded, err := EncodeRecords(RecordFormatCSV, SpawnRecordColumns(), [][]interface{}{SpawnToRecord(spawn)})
+	require.NoError(t, err)
+	assert.Equal(t, "id,x,y,unknown1,orientation,spwan_step\n7,10,20,0,3,1\n", string(encoded))
+
+	records, err := DecodeRecords(RecordFormatCSV, encoded)
+	require.NoError(t, err)
+	decodedSpawn, fieldErrors := SpawnFromRecord(records[0])
+	require.Empty(t, fieldErrors)
+	assert.Equal(t, spawn, decodedSpawn)
+
+	item := DropItemData{ItemId: 100, DropRate: 250000}
+	encoded, err = EncodeRecords(RecordFormatJSON, DropRecordColumns(), [][]interface{}{DropToRecord(item)})
+	require.NoError(t, err)
+	assert.Equal(t, "[\n  {\"item_id\": 100, \"unknown1\": 0, \"drop_rate\": 250000, \"unknown2\": 0}\n]\n", string(encoded))
+
+	records, err = DecodeRecords(RecordFormatJSON, encoded)
+	require.NoError(t, err)
+	decodedItem, fieldErrors := DropFromRecord(records[0])
+	require.Empty(t, fieldErrors)
+	assert.Equal(t, item, decodedItem)
+}
+
+func TestDecodeRecordsErrors(t *testing.T) {
+	_, err := DecodeRecords(RecordFormatCSV, []byte(""))
+	assert.Error(t, err)
+
+	_, err = DecodeRecords(RecordFormatCSV, []byte("id,x\n1\n"))
+	assert.Error(t, err)
+
+	_, err = DecodeRecords(RecordFormatJSON, []byte(`[{"id": true}]`))
+	assert.Error(t, err)
+}
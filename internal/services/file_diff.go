@@ -0,0 +1,351 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	DiffChangeAdded   = "added"
+	DiffChangeRemoved = "removed"
+	DiffChangeChanged = "changed"
+)
+
+// maxDiffEdits bounds the work done by diffSequences. Inputs that differ by
+// more edits than this are reported as a full replacement.
+const maxDiffEdits = 1000
+
+const unifiedDiffContext = 3
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type SpawnRowChange struct {
+	Type     string
+	OldIndex *int
+	NewIndex *int
+	Old      *NPCSpawnData
+	New      *NPCSpawnData
+}
+
+func DiffNPCFileData(oldData, newData *NPCFileData) []FieldChange {
+	changes := []FieldChange{}
+
+	oldName := utils.ReadStringFromBytes(oldData.Name[:])
+	newName := utils.ReadStringFromBytes(newData.Name[:])
+	if oldName != newName {
+		changes = append(changes, FieldChange{Field: "name", Old: oldName, New: newName})
+	}
+
+	return append(changes, diffStructFields("", reflect.ValueOf(*oldData), reflect.ValueOf(*newData), "Name")...)
+}
+
+func diffStructFields(prefix string, oldValue, newValue reflect.Value, skip ...string) []FieldChange {
+	var changes []FieldChange
+	structType := oldValue.Type()
+
+fields:
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		for _, name := range skip {
+			if field.Name == name {
+				continue fields
+			}
+		}
+
+		name := prefix + strings.Split(field.Tag.Get("json"), ",")[0]
+		oldField := oldValue.Field(i)
+		newField := newValue.Field(i)
+
+		switch field.Type.Kind() {
+		case reflect.Array:
+			for j := 0; j < oldField.Len(); j++ {
+				elementName := fmt.Sprintf("%s[%d]", name, j)
+				if oldField.Index(j).Kind() == reflect.Struct {
+					changes = append(changes, diffStructFields(elementName+".", oldField.Index(j), newField.Index(j))...)
+				} else if oldField.Index(j).Interface() != newField.Index(j).Interface() {
+					changes = append(changes, FieldChange{Field: elementName, Old: oldField.Index(j).Interface(), New: newField.Index(j).Interface()})
+				}
+			}
+		case reflect.Struct:
+			changes = append(changes, diffStructFields(name+".", oldField, newField)...)
+		default:
+			if oldField.Interface() != newField.Interface() {
+				changes = append(changes, FieldChange{Field: name, Old: oldField.Interface(), New: newField.Interface()})
+			}
+		}
+	}
+
+	return changes
+}
+
+func DiffSpawnData(oldData, newData []NPCSpawnData) []SpawnRowChange {
+	changes := []SpawnRowChange{}
+	var removed, added []int
+
+	flush := func() {
+		paired := min(len(removed), len(added))
+		for i := 0; i < paired; i++ {
+			oldIndex, newIndex := removed[i], added[i]
+			changes = append(changes, SpawnRowChange{
+				Type:     DiffChangeChanged,
+				OldIndex: &oldIndex,
+				NewIndex: &newIndex,
+				Old:      &oldData[oldIndex],
+				New:      &newData[newIndex],
+			})
+		}
+
+		for _, oldIndex := range removed[paired:] {
+			changes = append(changes, SpawnRowChange{
+				Type:     DiffChangeRemoved,
+				OldIndex: &oldIndex,
+				Old:      &oldData[oldIndex],
+			})
+		}
+
+		for _, newIndex := range added[paired:] {
+			changes = append(changes, SpawnRowChange{
+				Type:     DiffChangeAdded,
+				NewIndex: &newIndex,
+				New:      &newData[newIndex],
+			})
+		}
+
+		removed, added = nil, nil
+	}
+
+	for _, op := range diffSequences(oldData, newData) {
+		switch op.kind {
+		case diffDelete:
+			removed = append(removed, op.oldIndex)
+		case diffInsert:
+			added = append(added, op.newIndex)
+		default:
+			flush()
+		}
+	}
+
+	flush()
+
+	return changes
+}
+
+// UnifiedDiff returns a unified line diff between two texts, or an empty
+// string when they are identical.
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+	ops := diffSequences(oldLines, newLines)
+
+	var hunks []string
+	for start := 0; start < len(ops); {
+		if ops[start].kind == diffEqual {
+			start++
+			continue
+		}
+
+		hunkStart := max(start-unifiedDiffContext, 0)
+		hunkEnd := start
+		for hunkEnd < len(ops) {
+			if ops[hunkEnd].kind != diffEqual {
+				hunkEnd++
+				continue
+			}
+
+			equalRun := hunkEnd
+			for equalRun < len(ops) && ops[equalRun].kind == diffEqual {
+				equalRun++
+			}
+
+			if equalRun == len(ops) || equalRun-hunkEnd > 2*unifiedDiffContext {
+				hunkEnd = min(hunkEnd+unifiedDiffContext, len(ops))
+				break
+			}
+
+			hunkEnd = equalRun
+		}
+
+		hunks = append(hunks, formatHunk(ops[hunkStart:hunkEnd], oldLines, newLines))
+		start = hunkEnd
+	}
+
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	return "--- " + oldName + "\n+++ " + newName + "\n" + strings.Join(hunks, "")
+}
+
+func formatHunk(ops []diffOp, oldLines, newLines []string) string {
+	var body strings.Builder
+	oldStart, newStart := -1, -1
+	oldCount, newCount := 0, 0
+
+	for _, op := range ops {
+		switch op.kind {
+		case diffEqual:
+			body.WriteString(" " + oldLines[op.oldIndex] + "\n")
+			oldCount++
+			newCount++
+		case diffDelete:
+			body.WriteString("-" + oldLines[op.oldIndex] + "\n")
+			oldCount++
+		case diffInsert:
+			body.WriteString("+" + newLines[op.newIndex] + "\n")
+			newCount++
+		}
+
+		if oldStart == -1 && op.kind != diffInsert {
+			oldStart = op.oldIndex
+		}
+
+		if newStart == -1 && op.kind != diffDelete {
+			newStart = op.newIndex
+		}
+	}
+
+	if oldStart == -1 {
+		oldStart = ops[0].oldIndex - 1
+	}
+
+	if newStart == -1 {
+		newStart = ops[0].newIndex - 1
+	}
+
+	return fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount), body.String())
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffDelete
+	diffInsert
+)
+
+// diffOp describes one step of an edit script. For inserts oldIndex is the
+// position in the old sequence before which the element is inserted, and for
+// deletes newIndex is the matching position in the new sequence.
+type diffOp struct {
+	kind     diffKind
+	oldIndex int
+	newIndex int
+}
+
+// diffSequences computes a shortest edit script between a and b using the
+// Myers algorithm.
+func diffSequences[T comparable](a, b []T) []diffOp {
+	n, m := len(a), len(b)
+	maxEdits := min(n+m, maxDiffEdits)
+	offset := maxEdits + 1
+	v := make([]int, 2*maxEdits+3)
+	var trace [][]int
+
+	for d := 0; d <= maxEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(trace, n, m)
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	for i := 0; i < n; i++ {
+		ops = append(ops, diffOp{kind: diffDelete, oldIndex: i, newIndex: 0})
+	}
+
+	for j := 0; j < m; j++ {
+		ops = append(ops, diffOp{kind: diffInsert, oldIndex: n, newIndex: j})
+	}
+
+	return ops
+}
+
+// backtrackDiff walks the saved frontiers back from (n, m). trace[d] holds the
+// diagonals -d-1..d+1 of the frontier before step d.
+func backtrackDiff(trace [][]int, n, m int) []diffOp {
+	var ops []diffOp
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		offset := d + 1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: diffEqual, oldIndex: x, newIndex: y})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, diffOp{kind: diffInsert, oldIndex: x, newIndex: y})
+			} else {
+				x--
+				ops = append(ops, diffOp{kind: diffDelete, oldIndex: x, newIndex: y})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
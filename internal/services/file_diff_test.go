@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffNPCFileData(t *testing.T) {
+	oldData := &NPCFileData{Id: 12, Level: 50, HP: 1000}
+	copy(oldData.Name[:], "Goblin")
+
+	newData := *oldData
+	copy(newData.Name[:], "Orc\x00\x00\x00")
+	newData.HP = 1200
+	newData.Attacks[1].Damage = 40
+
+	changes := DiffNPCFileData(oldData, &newData)
+
+	assert.Equal(t, []FieldChange{
+		{Field: "name", Old: "Goblin", New: "Orc"},
+		{Field: "attacks[1].damage", Old: uint16(0), New: uint16(40)},
+		{Field: "hp", Old: uint32(1000), New: uint32(1200)},
+	}, changes)
+}
+
+func TestDiffNPCFileDataNoChanges(t *testing.T) {
+	data := &NPCFileData{Id: 12}
+
+	assert.Empty(t, DiffNPCFileData(data, data))
+}
+
+func TestDiffSpawnData(t *testing.T) {
+	oldData := []NPCSpawnData{
+		{Id: 1, X: 10, Y: 10},
+		{Id: 2, X: 20, Y: 20},
+		{Id: 3, X: 30, Y: 30},
+	}
+
+	newData := []NPCSpawnData{
+		{Id: 1, X: 10, Y: 10},
+		{Id: 2, X: 25, Y: 20},
+		{Id: 3, X: 30, Y: 30},
+		{Id: 4, X: 40, Y: 40},
+	}
+
+	changes := DiffSpawnData(oldData, newData)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, DiffChangeChanged, changes[0].Type)
+	assert.Equal(t, 1, *changes[0].OldIndex)
+	assert.Equal(t, 1, *changes[0].NewIndex)
+	assert.Equal(t, byte(25), changes[0].New.X)
+
+	assert.Equal(t, DiffChangeAdded, changes[1].Type)
+	assert.Nil(t, changes[1].OldIndex)
+	assert.Equal(t, 3, *changes[1].NewIndex)
+}
+
+func TestDiffSpawnDataRemovedRow(t *testing.T) {
+	oldData := []NPCSpawnData{{Id: 1}, {Id: 2}, {Id: 3}}
+	newData := []NPCSpawnData{{Id: 1}, {Id: 3}}
+
+	changes := DiffSpawnData(oldData, newData)
+	require.Len(t, changes, 1)
+	assert.Equal(t, DiffChangeRemoved, changes[0].Type)
+	assert.Equal(t, 1, *changes[0].OldIndex)
+	assert.Equal(t, uint16(2), changes[0].Old.Id)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newText := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+
+	expected := "--- old\n+++ new\n" +
+		"@@ -2,9 +2,10 @@\n" +
+		" b\n c\n d\n-e\n+E\n f\n g\n h\n i\n j\n+k\n"
+
+	assert.Equal(t, expected, UnifiedDiff("old", "new", oldText, newText))
+}
+
+func TestUnifiedDiffSeparateHunks(t *testing.T) {
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newText := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+
+	expected := "--- old\n+++ new\n" +
+		"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+		"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n"
+
+	assert.Equal(t, expected, UnifiedDiff("old", "new", oldText, newText))
+}
+
+func TestUnifiedDiffIdentical(t *testing.T) {
+	assert.Equal(t, "", UnifiedDiff("old", "new", "same\n", "same\n"))
+}
+
+func TestUnifiedDiffFromEmpty(t *testing.T) {
+	assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+x\n+y\n", UnifiedDiff("old", "new", "", "x\ny\n"))
+}
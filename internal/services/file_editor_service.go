@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io/fs"
 	"mime"
 	"os"
@@ -25,9 +26,15 @@ const (
 )
 
 const (
-	NPCFileSize = 78
+	NPCFileSize       = 78
+	DropItemEntrySize = 12
+	MapHeaderSize     = 8
 )
 
+// MapMaxDimension is the largest width or height accepted for a map. Map
+// coordinates in spawn files are single bytes, so real maps are far smaller.
+const MapMaxDimension = 4096
+
 const (
 	DropFileExtension  = ".itm"
 	MapFileExtension   = ".map"
@@ -40,16 +47,25 @@ type FileEditorService interface {
 	GetFileAPIEndpoint(path string, fileInfo fs.FileInfo) string
 	IsFileViewable(path string, fileInfo fs.FileInfo) bool
 	ReadNPCFileData(path string) (*NPCFileData, error)
+	ReadNPCFileBytes(data []byte) (*NPCFileData, error)
 	WriteNPCFileData(path string, data *NPCFileData) error
 	WriteTextFileData(path string, content string) error
 	ReadSpawnFileData(path string) ([]NPCSpawnData, error)
+	ReadSpawnFileBytes(data []byte) ([]NPCSpawnData, error)
 	WriteSpawnFileData(path string, data []NPCSpawnData) error
+	ReadDropFileData(path string) (*DropFileData, error)
+	ReadDropFileBytes(data []byte) (*DropFileData, error)
+	WriteDropFileData(path string, data *DropFileData) error
+	ReadMapFileData(path string) (*MapFileData, error)
+	ReadMapFileBytes(data []byte) (*MapFileData, error)
+	WriteMapFileData(path string, data *MapFileData) error
 	Stat(name string) (fs.FileInfo, error)
 	ReadDir(name string) ([]fs.DirEntry, error)
 	ReadFile(name string) ([]byte, error)
 	WriteFile(name string, data []byte, perm fs.FileMode) error
 	MkdirAll(path string, perm fs.FileMode) error
 	Remove(name string) error
+	Rename(oldpath string, newpath string) error
 	RemoveAll(path string) error
 	OpenFile(name string, flag int, perm fs.FileMode) (*os.File, error)
 	Hostname() (string, error)
@@ -59,6 +75,8 @@ type FileEditorService interface {
 	ReadClientMonsterFileBytes(data []byte) ([]MonsterClientData, error)
 	ReadClientMapFileData(path string) ([]MapClientData, error)
 	ReadClientMapFileBytes(data []byte) ([]MapClientData, error)
+	ReadClientItemFileData(path string) ([]ItemClientData, error)
+	ReadClientItemFileBytes(data []byte) ([]ItemClientData, error)
 }
 
 type fileEditorService struct {
@@ -77,6 +95,10 @@ func (fes *fileEditorService) IsFileEditable(path string, fileInfo fs.FileInfo)
 		return true
 	case FileTypeSpawn:
 		return true
+	case FileTypeDrop:
+		return true
+	case FileTypeMap:
+		return true
 	default:
 		return false
 	}
@@ -113,6 +135,10 @@ func (fes *fileEditorService) IsFileViewable(path string, fileInfo fs.FileInfo)
 		return true
 	case FileTypeSpawn:
 		return true
+	case FileTypeDrop:
+		return true
+	case FileTypeMap:
+		return true
 	default:
 		return false
 	}
@@ -126,6 +152,10 @@ func (fes *fileEditorService) GetFileAPIEndpoint(path string, fileInfo fs.FileIn
 		return "/file-tree/text-file"
 	case FileTypeSpawn:
 		return "/file-tree/spawn-file"
+	case FileTypeDrop:
+		return "/file-tree/drop-file"
+	case FileTypeMap:
+		return "/file-tree/map-file"
 	default:
 		return ""
 	}
@@ -150,6 +180,19 @@ func (fes *fileEditorService) ReadNPCFileData(path string) (*NPCFileData, error)
 	return &npcData, nil
 }
 
+func (fes *fileEditorService) ReadNPCFileBytes(data []byte) (*NPCFileData, error) {
+	if len(data) < NPCFileSize {
+		return nil, errors.New("data is too small")
+	}
+
+	var npcData NPCFileData
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &npcData); err != nil {
+		return nil, err
+	}
+
+	return &npcData, nil
+}
+
 func (fes *fileEditorService) WriteNPCFileData(path string, data *NPCFileData) error {
 	file, err := os.Create(path)
 	if err != nil {
@@ -203,6 +246,19 @@ func (fes *fileEditorService) ReadSpawnFileData(path string) ([]NPCSpawnData, er
 	return spawnData, nil
 }
 
+func (fes *fileEditorService) ReadSpawnFileBytes(data []byte) ([]NPCSpawnData, error) {
+	reader := bytes.NewReader(data)
+	spawnData := make([]NPCSpawnData, len(data)/8)
+	for i := range spawnData {
+		err := binary.Read(reader, binary.LittleEndian, &spawnData[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return spawnData, nil
+}
+
 func (fes *fileEditorService) WriteSpawnFileData(path string, data []NPCSpawnData) error {
 	file, err := os.Create(path)
 	if err != nil {
@@ -222,6 +278,93 @@ func (fes *fileEditorService) WriteSpawnFileData(path string, data []NPCSpawnDat
 	return nil
 }
 
+func (fes *fileEditorService) ReadDropFileData(path string) (*DropFileData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return fes.ReadDropFileBytes(data)
+}
+
+func (fes *fileEditorService) ReadDropFileBytes(data []byte) (*DropFileData, error) {
+	if len(data) < 4 {
+		return nil, errors.New("data is too small")
+	}
+
+	entryCount := binary.LittleEndian.Uint32(data[:4])
+	entriesEnd := 4 + uint64(entryCount)*DropItemEntrySize
+	if uint64(len(data)) < entriesEnd {
+		return nil, errors.New("data is too small")
+	}
+
+	reader := bytes.NewReader(data[4:entriesEnd])
+	dropData := &DropFileData{
+		Items:   make([]DropItemData, entryCount),
+		Trailer: append([]byte{}, data[entriesEnd:]...),
+	}
+
+	for i := range dropData.Items {
+		err := binary.Read(reader, binary.LittleEndian, &dropData.Items[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dropData, nil
+}
+
+func (fes *fileEditorService) WriteDropFileData(path string, data *DropFileData) error {
+	encoded, err := EncodeDropFileData(data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, encoded, 0644)
+}
+
+func (fes *fileEditorService) ReadMapFileData(path string) (*MapFileData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return fes.ReadMapFileBytes(data)
+}
+
+func (fes *fileEditorService) ReadMapFileBytes(data []byte) (*MapFileData, error) {
+	if len(data) < MapHeaderSize {
+		return nil, errors.New("data is too small")
+	}
+
+	width := binary.LittleEndian.Uint32(data[0:4])
+	height := binary.LittleEndian.Uint32(data[4:8])
+	if width == 0 || height == 0 || width > MapMaxDimension || height > MapMaxDimension {
+		return nil, fmt.Errorf("invalid map dimensions %dx%d", width, height)
+	}
+
+	cellsEnd := MapHeaderSize + uint64(width)*uint64(height)
+	if uint64(len(data)) < cellsEnd {
+		return nil, errors.New("data is too small")
+	}
+
+	return &MapFileData{
+		Width:   width,
+		Height:  height,
+		Cells:   append([]byte{}, data[MapHeaderSize:cellsEnd]...),
+		Trailer: append([]byte{}, data[cellsEnd:]...),
+	}, nil
+}
+
+func (fes *fileEditorService) WriteMapFileData(path string, data *MapFileData) error {
+	encoded, err := EncodeMapFileData(data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, encoded, 0644)
+}
+
 func (fes *fileEditorService) Stat(name string) (fs.FileInfo, error) {
 	return os.Stat(name)
 }
@@ -246,6 +389,10 @@ func (fes *fileEditorService) Remove(name string) error {
 	return os.Remove(name)
 }
 
+func (fes *fileEditorService) Rename(oldpath string, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
 func (fes *fileEditorService) RemoveAll(path string) error {
 	return os.RemoveAll(path)
 }
@@ -322,6 +469,38 @@ func (fes *fileEditorService) ReadClientMapFileBytes(data []byte) ([]MapClientDa
 	return mapData, nil
 }
 
+func (fes *fileEditorService) ReadClientItemFileData(path string) ([]ItemClientData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return fes.ReadClientItemFileBytes(data)
+}
+
+func (fes *fileEditorService) ReadClientItemFileBytes(data []byte) ([]ItemClientData, error) {
+	const entrySize = 128
+	if len(data) < 4 {
+		return nil, errors.New("data is too small")
+	}
+
+	entryCount := binary.LittleEndian.Uint32(data[:4])
+	if uint64(len(data)) < uint64(entryCount)*entrySize+4 {
+		return nil, errors.New("data is too small")
+	}
+
+	reader := bytes.NewReader(data[4:])
+	itemData := make([]ItemClientData, entryCount)
+	for i := range itemData {
+		err := binary.Read(reader, binary.LittleEndian, &itemData[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return itemData, nil
+}
+
 type NPCFileData struct {
 	Name                [0x14]byte     `json:"name"`
 	Id                  uint16         `json:"id"`
@@ -361,6 +540,60 @@ type NPCSpawnData struct {
 	SpwanStep   byte
 }
 
+type DropFileData struct {
+	Items   []DropItemData
+	Trailer []byte
+}
+
+type DropItemData struct {
+	ItemId   uint16
+	Unknown1 uint16
+	DropRate uint32
+	Unknown2 uint32
+}
+
+func EncodeDropFileData(data *DropFileData) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := binary.Write(&buffer, binary.LittleEndian, uint32(len(data.Items))); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(&buffer, binary.LittleEndian, data.Items); err != nil {
+		return nil, err
+	}
+
+	buffer.Write(data.Trailer)
+
+	return buffer.Bytes(), nil
+}
+
+type MapFileData struct {
+	Width   uint32
+	Height  uint32
+	Cells   []byte
+	Trailer []byte
+}
+
+func EncodeMapFileData(data *MapFileData) ([]byte, error) {
+	if uint64(len(data.Cells)) != uint64(data.Width)*uint64(data.Height) {
+		return nil, errors.New("cell count does not match map dimensions")
+	}
+
+	var buffer bytes.Buffer
+	if err := binary.Write(&buffer, binary.LittleEndian, data.Width); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(&buffer, binary.LittleEndian, data.Height); err != nil {
+		return nil, err
+	}
+
+	buffer.Write(data.Cells)
+	buffer.Write(data.Trailer)
+
+	return buffer.Bytes(), nil
+}
+
 type MonsterClientData struct {
 	ID      uint32
 	Name    [0x1F]byte
@@ -376,3 +609,9 @@ type MapClientData struct {
 	Unknown5 uint32
 	Name     [0x20]byte
 }
+
+type ItemClientData struct {
+	ID      uint32
+	Name    [0x20]byte
+	Unknown [0x5C]byte
+}
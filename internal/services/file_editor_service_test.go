@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropFileRoundTripPreservesTrailer(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	original := &DropFileData{
+		Items: []DropItemData{
+			{ItemId: 101, Unknown1: 1, DropRate: 500, Unknown2: 7},
+			{ItemId: 202, Unknown1: 0, DropRate: 25, Unknown2: 0},
+		},
+		Trailer: []byte{0xDE, 0xAD, 0xBE, 0xEF},
+	}
+
+	encoded, err := EncodeDropFileData(original)
+	require.NoError(t, err)
+	assert.Len(t, encoded, 4+2*DropItemEntrySize+4)
+
+	decoded, err := fes.ReadDropFileBytes(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, original, decoded)
+}
+
+func TestReadDropFileBytesTooSmall(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	_, err := fes.ReadDropFileBytes([]byte{0x02, 0x00, 0x00, 0x00, 0x01})
+	assert.Error(t, err)
+}
+
+func TestMapFileRoundTripPreservesTrailer(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	original := &MapFileData{
+		Width:   3,
+		Height:  2,
+		Cells:   []byte{0, 1, 2, 3, 4, 5},
+		Trailer: []byte{0xAA, 0xBB},
+	}
+
+	encoded, err := EncodeMapFileData(original)
+	require.NoError(t, err)
+	assert.Len(t, encoded, MapHeaderSize+6+2)
+
+	decoded, err := fes.ReadMapFileBytes(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, original, decoded)
+}
+
+func TestReadMapFileBytesRejectsInvalidDimensions(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	for _, dimensions := range [][2]uint32{{0, 0xFFFFFFFF}, {3, 0}, {MapMaxDimension + 1, 1}} {
+		data := make([]byte, MapHeaderSize+4)
+		binary.LittleEndian.PutUint32(data[0:4], dimensions[0])
+		binary.LittleEndian.PutUint32(data[4:8], dimensions[1])
+
+		_, err := fes.ReadMapFileBytes(data)
+		assert.Error(t, err, "%dx%d", dimensions[0], dimensions[1])
+	}
+}
+
+func TestEncodeMapFileDataRejectsMismatchedCells(t *testing.T) {
+	_, err := EncodeMapFileData(&MapFileData{Width: 2, Height: 2, Cells: []byte{0, 1, 2}})
+	assert.Error(t, err)
+}
+
+func TestClientItemFileRoundTrip(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	original := []ItemClientData{{ID: 1201}, {ID: 3405}}
+	copy(original[0].Name[:], "Short Sword")
+	copy(original[1].Name[:], "Red Potion")
+	original[1].Unknown[0] = 0x7F
+	original[1].Unknown[len(original[1].Unknown)-1] = 0x01
+
+	var buffer bytes.Buffer
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, uint32(len(original))))
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, original))
+	data := buffer.Bytes()
+	require.Len(t, data, 4+2*128)
+	assert.Equal(t, uint32(3405), binary.LittleEndian.Uint32(data[4+128:]))
+	assert.Equal(t, "Red Potion", string(data[4+128+4:4+128+4+10]))
+
+	decoded, err := fes.ReadClientItemFileBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, original, decoded)
+}
+
+func TestReadClientItemFileBytesTooSmall(t *testing.T) {
+	fes := NewFileEditorService(nil)
+
+	_, err := fes.ReadClientItemFileBytes([]byte{0x01, 0x00})
+	assert.Error(t, err)
+
+	// Two entries announced, but only one and a half present.
+	data := make([]byte, 4+128+64)
+	binary.LittleEndian.PutUint32(data, 2)
+	_, err = fes.ReadClientItemFileBytes(data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+// maxTextMatchLength is the number of characters of a matching line returned
+// by FindTextMatches; longer lines are cut.
+const maxTextMatchLength = 200
+
+// FileSearchCriteria describes the files a search looks for. Empty fields are
+// not checked. NamePattern is a glob matched against the file name without
+// regard to case.
+type FileSearchCriteria struct {
+	NamePattern    string
+	FileType       FileType
+	MinSize        *int64
+	MaxSize        *int64
+	ModifiedAfter  *time.Time
+	ModifiedBefore *time.Time
+	Content        string
+	NPCID          *uint16
+	NPCName        string
+}
+
+type TextMatch struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// IsEmpty reports whether no criterion is set.
+func (c *FileSearchCriteria) IsEmpty() bool {
+	return *c == FileSearchCriteria{}
+}
+
+// SearchesNPCData reports whether decoded NPC files have to be read.
+func (c *FileSearchCriteria) SearchesNPCData() bool {
+	return c.NPCID != nil || c.NPCName != ""
+}
+
+// MatchesMetadata checks the criteria that only need the directory entry.
+// Content and NPC criteria restrict the match to text and NPC files.
+func (c *FileSearchCriteria) MatchesMetadata(name string, info fs.FileInfo, fileType FileType) bool {
+	if c.NamePattern != "" {
+		matched, err := filepath.Match(strings.ToLower(c.NamePattern), strings.ToLower(name))
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	if c.FileType != "" && fileType != c.FileType {
+		return false
+	}
+
+	if c.Content != "" && fileType != FileTypeText {
+		return false
+	}
+
+	if c.SearchesNPCData() && fileType != FileTypeNPC {
+		return false
+	}
+
+	if c.MinSize != nil && info.Size() < *c.MinSize {
+		return false
+	}
+
+	if c.MaxSize != nil && info.Size() > *c.MaxSize {
+		return false
+	}
+
+	if c.ModifiedAfter != nil && !info.ModTime().After(*c.ModifiedAfter) {
+		return false
+	}
+
+	if c.ModifiedBefore != nil && !info.ModTime().Before(*c.ModifiedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// MatchesNPC checks the NPC criteria against a decoded NPC file. The name
+// matches when it contains NPCName, without regard to case.
+func (c *FileSearchCriteria) MatchesNPC(data *NPCFileData) bool {
+	if c.NPCID != nil && data.Id != *c.NPCID {
+		return false
+	}
+
+	if c.NPCName != "" && !strings.Contains(strings.ToLower(utils.ReadStringFromBytes(data.Name[:])), strings.ToLower(c.NPCName)) {
+		return false
+	}
+
+	return true
+}
+
+// FindTextMatches returns up to limit lines of data that contain query,
+// without regard to case. Line numbers start at 1.
+func FindTextMatches(data []byte, query string, limit int) []TextMatch {
+	var matches []TextMatch
+	needle := bytes.ToLower([]byte(query))
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+
+	line := 0
+	for scanner.Scan() && len(matches) < limit {
+		line++
+		if !bytes.Contains(bytes.ToLower(scanner.Bytes()), needle) {
+			continue
+		}
+
+		text := strings.TrimSpace(scanner.Text())
+		if runes := []rune(text); len(runes) > maxTextMatchLength {
+			text = string(runes[:maxTextMatchLength])
+		}
+
+		matches = append(matches, TextMatch{Line: line, Text: text})
+	}
+
+	return matches
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSearchCriteriaMatchesMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Monster.TXT")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	small := int64(5)
+	large := int64(20)
+	past := info.ModTime().Add(-time.Hour)
+	future := info.ModTime().Add(time.Hour)
+	npcID := uint16(312)
+
+	tests := []struct {
+		name     string
+		criteria FileSearchCriteria
+		expected bool
+	}{
+		{name: "name glob ignores case", criteria: FileSearchCriteria{NamePattern: "monster.*"}, expected: true},
+		{name: "name glob mismatch", criteria: FileSearchCriteria{NamePattern: "*.n_ndt"}, expected: false},
+		{name: "file type", criteria: FileSearchCriteria{FileType: FileTypeText}, expected: true},
+		{name: "other file type", criteria: FileSearchCriteria{FileType: FileTypeNPC}, expected: false},
+		{name: "size range", criteria: FileSearchCriteria{MinSize: &small, MaxSize: &large}, expected: true},
+		{name: "too small", criteria: FileSearchCriteria{MinSize: &large}, expected: false},
+		{name: "too large", criteria: FileSearchCriteria{MaxSize: &small}, expected: false},
+		{name: "modified range", criteria: FileSearchCriteria{ModifiedAfter: &past, ModifiedBefore: &future}, expected: true},
+		{name: "modified too early", criteria: FileSearchCriteria{ModifiedAfter: &future}, expected: false},
+		{name: "content needs a text file", criteria: FileSearchCriteria{Content: "x"}, expected: true},
+		{name: "npc criteria need an NPC file", criteria: FileSearchCriteria{NPCID: &npcID}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.criteria.MatchesMetadata(info.Name(), info, FileTypeText))
+		})
+	}
+}
+
+func TestFileSearchCriteriaMatchesNPC(t *testing.T) {
+	data := &NPCFileData{Id: 312}
+	copy(data.Name[:], "Red Wolf")
+
+	id := uint16(312)
+	otherID := uint16(7)
+
+	assert.True(t, (&FileSearchCriteria{NPCID: &id}).MatchesNPC(data))
+	assert.False(t, (&FileSearchCriteria{NPCID: &otherID}).MatchesNPC(data))
+	assert.True(t, (&FileSearchCriteria{NPCName: "wolf"}).MatchesNPC(data))
+	assert.False(t, (&FileSearchCriteria{NPCID: &id, NPCName: "bear"}).MatchesNPC(data))
+}
+
+func TestFindTextMatches(t *testing.T) {
+	data := []byte("first line\n  Second MATCH line  \nthird\nmatch again\nlast match")
+
+	matches := FindTextMatches(data, "match", 2)
+	assert.Equal(t, []TextMatch{
+		{Line: 2, Text: "Second MATCH line"},
+		{Line: 4, Text: "match again"},
+	}, matches)
+
+	assert.Empty(t, FindTextMatches(data, "missing", 10))
+}
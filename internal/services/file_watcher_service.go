@@ -0,0 +1,364 @@
+package services
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	FileChangeEdit   = "edit"
+	FileChangeCreate = "create"
+	FileChangeDelete = "delete"
+)
+
+// maxWatchedFileSize is the largest file whose content the watcher stores;
+// larger files are not tracked.
+const maxWatchedFileSize = 16 * 1024 * 1024
+
+// FileChangeEvent describes a change to a watched file. PreviousData is the
+// content the watcher last saw and is nil for created files; CurrentData is
+// nil for deleted files.
+type FileChangeEvent struct {
+	Path         string
+	Operation    string
+	PreviousData []byte
+	CurrentData  []byte
+	DetectedAt   time.Time
+}
+
+// FileWatcherService watches the allowed roots for changes to files the agent
+// can view and reports every change on Events, whoever made it. The content
+// of each tracked file is kept in the revision store, retained there while it
+// is tracked, so that the state before a change is still available once the
+// file has been overwritten; in memory only its hash, size and modification
+// time are kept. Nothing is watched when no allowed roots are configured.
+type FileWatcherService interface {
+	Start() error
+	Stop() error
+	Events() <-chan FileChangeEvent
+}
+
+type fileWatcherService struct {
+	cfg           *config.EnvVars
+	logger        logger.Logger
+	fileEditor    FileEditorService
+	pathResolver  PathResolverService
+	revisionStore RevisionStoreService
+	watcher       *fsnotify.Watcher
+	events        chan FileChangeEvent
+	done          chan struct{}
+	wg            sync.WaitGroup
+	revisionsDir  string
+	// known and pending are only used by the watch loop once it is running.
+	known   map[string]watchedFile
+	pending map[string]time.Time
+}
+
+// watchedFile is what the watcher remembers of a tracked file: enough to tell
+// whether it changed, and the store object holding its content.
+type watchedFile struct {
+	hash    string
+	size    int64
+	modTime time.Time
+	object  string
+}
+
+func NewFileWatcherService(
+	cfg *config.EnvVars,
+	logger logger.Logger,
+	fileEditor FileEditorService,
+	pathResolver PathResolverService,
+	revisionStore RevisionStoreService,
+) FileWatcherService {
+	return &fileWatcherService{
+		cfg:           cfg,
+		logger:        logger,
+		fileEditor:    fileEditor,
+		pathResolver:  pathResolver,
+		revisionStore: revisionStore,
+		events:        make(chan FileChangeEvent, 256),
+		done:          make(chan struct{}),
+		known:         make(map[string]watchedFile),
+		pending:       make(map[string]time.Time),
+	}
+}
+
+func (fw *fileWatcherService) Events() <-chan FileChangeEvent {
+	return fw.events
+}
+
+func (fw *fileWatcherService) Start() error {
+	if !fw.cfg.FileWatcherEnabled {
+		return nil
+	}
+
+	if !fw.pathResolver.IsRestricted() {
+		fw.logger.Warn("file watcher disabled because ALLOWED_ROOTS is not set")
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	fw.watcher = watcher
+	fw.revisionsDir, _ = filepath.Abs(fw.cfg.RevisionsDirectory)
+
+	tracked := 0
+	for _, root := range fw.pathResolver.Roots() {
+		tracked += fw.watchTree(root, false)
+	}
+
+	fw.wg.Add(1)
+	go fw.run()
+
+	fw.logger.Info(
+		"file watcher started",
+		logger.Field{Key: "roots", Value: fw.pathResolver.Roots()},
+		logger.Field{Key: "tracked_files", Value: tracked},
+	)
+
+	return nil
+}
+
+func (fw *fileWatcherService) Stop() error {
+	if fw.watcher == nil {
+		return nil
+	}
+
+	close(fw.done)
+	fw.wg.Wait()
+	close(fw.events)
+
+	if err := fw.watcher.Close(); err != nil {
+		return fmt.Errorf("failed to close file watcher: %w", err)
+	}
+
+	fw.logger.Info("file watcher stopped")
+	return nil
+}
+
+// run collects watch events and checks each path once it has been quiet for
+// the debounce interval, so that a file written in several steps is reported
+// once.
+func (fw *fileWatcherService) run() {
+	defer fw.wg.Done()
+
+	debounce := time.Duration(fw.cfg.FileWatcherDebounceMs) * time.Millisecond
+	ticker := time.NewTicker(max(debounce/2, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fw.done:
+			return
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			fw.pending[event.Name] = time.Now()
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			fw.logger.Error("file watcher error", logger.Field{Key: "error", Value: err})
+		case now := <-ticker.C:
+			for path, lastEvent := range fw.pending {
+				if now.Sub(lastEvent) < debounce {
+					continue
+				}
+
+				delete(fw.pending, path)
+				fw.checkPath(path)
+			}
+		}
+	}
+}
+
+// checkPath compares path with what was last seen of it and reports the
+// difference.
+func (fw *fileWatcherService) checkPath(path string) {
+	info, err := fw.fileEditor.Stat(path)
+	if err != nil {
+		if !fw.fileEditor.IsNotExist(err) {
+			fw.logger.Error("failed to stat watched path", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+			return
+		}
+
+		// A removed or moved directory only reports itself, so everything
+		// known below it is gone as well.
+		prefix := path + string(filepath.Separator)
+		for knownPath, previous := range fw.known {
+			if knownPath == path || strings.HasPrefix(knownPath, prefix) {
+				delete(fw.known, knownPath)
+				if previousData, ok := fw.releaseContent(knownPath, previous); ok {
+					fw.emit(FileChangeEvent{Path: knownPath, Operation: FileChangeDelete, PreviousData: previousData})
+				}
+			}
+		}
+		return
+	}
+
+	if info.IsDir() {
+		fw.watchTree(path, true)
+		return
+	}
+
+	fw.trackFile(path, info, true)
+}
+
+// watchTree adds watches for root and the directories below it and reads the
+// tracked files in them. It returns the number of tracked files found.
+func (fw *fileWatcherService) watchTree(root string, notify bool) int {
+	tracked := 0
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			fw.logger.Warn("cannot watch path", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			if absPath, err := filepath.Abs(path); err == nil && absPath == fw.revisionsDir {
+				return filepath.SkipDir
+			}
+
+			if err := fw.watcher.Add(path); err != nil {
+				fw.logger.Warn("cannot watch directory", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+
+		if fw.trackFile(path, info, notify) {
+			tracked++
+		}
+		return nil
+	})
+	if err != nil {
+		fw.logger.Error("failed to watch directory", logger.Field{Key: "path", Value: root}, logger.Field{Key: "error", Value: err})
+	}
+
+	return tracked
+}
+
+// trackFile stores the content of path if it is a tracked file and, when
+// notify is set, reports a change if it differs from what was last seen. A
+// file whose size and modification time are unchanged is not read again. It
+// returns whether the file is tracked.
+func (fw *fileWatcherService) trackFile(path string, info fs.FileInfo, notify bool) bool {
+	previous, known := fw.known[path]
+
+	if !info.Mode().IsRegular() || info.Size() > maxWatchedFileSize || fw.fileEditor.GetFileType(path, info) == FileTypeUnknown {
+		fw.forget(path)
+		return false
+	}
+
+	// Links pointing outside the allowed roots are not followed.
+	if _, err := fw.pathResolver.Resolve(path); err != nil {
+		return false
+	}
+
+	if known && previous.size == info.Size() && previous.modTime.Equal(info.ModTime()) {
+		return true
+	}
+
+	data, err := fw.fileEditor.ReadFile(path)
+	if err != nil {
+		fw.logger.Warn("cannot read watched file", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+		return false
+	}
+
+	if data == nil {
+		data = []byte{}
+	}
+
+	hash := utils.CalculateFileHash(data)
+	if known && previous.hash == hash {
+		previous.size = info.Size()
+		previous.modTime = info.ModTime()
+		fw.known[path] = previous
+		return true
+	}
+
+	object, err := fw.revisionStore.Put(data)
+	if err != nil {
+		fw.logger.Warn("cannot store watched file", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+		return false
+	}
+
+	fw.revisionStore.Retain(object)
+	fw.known[path] = watchedFile{hash: hash, size: info.Size(), modTime: info.ModTime(), object: object}
+
+	event := FileChangeEvent{Path: path, Operation: FileChangeCreate, CurrentData: data}
+	if known {
+		previousData, ok := fw.releaseContent(path, previous)
+		if !ok {
+			return true
+		}
+
+		event.Operation = FileChangeEdit
+		event.PreviousData = previousData
+	}
+
+	if notify {
+		fw.emit(event)
+	}
+
+	return true
+}
+
+// releaseContent reads the stored content of a file that changed or is no
+// longer tracked and releases it. The change cannot be reported when the
+// content is unreadable.
+func (fw *fileWatcherService) releaseContent(path string, file watchedFile) ([]byte, bool) {
+	defer fw.revisionStore.Release(file.object)
+
+	data, err := fw.revisionStore.Get(file.object)
+	if err != nil {
+		fw.logger.Error("cannot read stored content of watched file", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (fw *fileWatcherService) forget(path string) {
+	if file, known := fw.known[path]; known {
+		delete(fw.known, path)
+		fw.revisionStore.Release(file.object)
+	}
+}
+
+func (fw *fileWatcherService) emit(event FileChangeEvent) {
+	event.DetectedAt = time.Now().UTC()
+
+	select {
+	case fw.events <- event:
+	case <-fw.done:
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWatcherReportsChanges(t *testing.T) {
+	root := t.TempDir()
+	existingPath := filepath.Join(root, "Spawn.txt")
+	require.NoError(t, os.WriteFile(existingPath, []byte("before"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "ignored.bin"), []byte{1, 2, 3}, 0644))
+
+	cfg := &config.EnvVars{
+		AllowedRoots:          []string{root},
+		RevisionsDirectory:    filepath.Join(root, ".revisions"),
+		RevisionCompression:   RevisionCompressionNone,
+		FileWatcherEnabled:    true,
+		FileWatcherDebounceMs: 50,
+	}
+
+	log := logger.NewMockLogger(t)
+	log.EXPECT().Info(mock.Anything, mock.Anything).Return().Maybe()
+	log.EXPECT().Info(mock.Anything).Return().Maybe()
+
+	pathResolver, err := NewPathResolverService(cfg, log)
+	require.NoError(t, err)
+
+	revisionStore := NewRevisionStoreService(cfg, log)
+	fw := NewFileWatcherService(cfg, log, NewFileEditorService(log), pathResolver, revisionStore)
+	require.NoError(t, fw.Start())
+	defer func() {
+		require.NoError(t, fw.Stop())
+	}()
+
+	require.NoError(t, os.WriteFile(existingPath, []byte("after"), 0644))
+	event := nextFileChangeEvent(t, fw)
+	assert.Equal(t, existingPath, event.Path)
+	assert.Equal(t, FileChangeEdit, event.Operation)
+	assert.Equal(t, []byte("before"), event.PreviousData)
+	assert.Equal(t, []byte("after"), event.CurrentData)
+
+	// The content of a tracked file is held in the revision store, not in
+	// memory, and only while the file is tracked.
+	beforePath, ok := revisionStore.Find(utils.CalculateFileHash([]byte("before")))
+	require.True(t, ok)
+	assert.False(t, revisionStore.IsRetained(beforePath))
+	afterPath, ok := revisionStore.Find(utils.CalculateFileHash([]byte("after")))
+	require.True(t, ok)
+	assert.True(t, revisionStore.IsRetained(afterPath))
+
+	subDir := filepath.Join(root, "data")
+	require.NoError(t, os.Mkdir(subDir, 0755))
+	createdPath := filepath.Join(subDir, "notes.txt")
+	require.NoError(t, os.WriteFile(createdPath, []byte("new"), 0644))
+	event = nextFileChangeEvent(t, fw)
+	assert.Equal(t, createdPath, event.Path)
+	assert.Equal(t, FileChangeCreate, event.Operation)
+	assert.Nil(t, event.PreviousData)
+
+	require.NoError(t, os.Remove(existingPath))
+	event = nextFileChangeEvent(t, fw)
+	assert.Equal(t, existingPath, event.Path)
+	assert.Equal(t, FileChangeDelete, event.Operation)
+	assert.Equal(t, []byte("after"), event.PreviousData)
+	assert.Nil(t, event.CurrentData)
+	assert.False(t, revisionStore.IsRetained(afterPath))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "ignored.bin"), []byte{4, 5, 6}, 0644))
+	select {
+	case event := <-fw.Events():
+		t.Fatalf("unexpected event for %s", event.Path)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func nextFileChangeEvent(t *testing.T, fw FileWatcherService) FileChangeEvent {
+	t.Helper()
+
+	select {
+	case event := <-fw.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no file change event")
+		return FileChangeEvent{}
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// HexBytesPerRow is the number of bytes shown on each row of a hex dump.
+const HexBytesPerRow = 16
+
+type HexRow struct {
+	Offset int64  `json:"offset"`
+	Hex    string `json:"hex"`
+	ASCII  string `json:"ascii"`
+}
+
+// FormatHexRows renders data, read from baseOffset in a file, as rows of hex
+// bytes with their printable ASCII form. Non-printable bytes are shown as '.'.
+func FormatHexRows(data []byte, baseOffset int64) []HexRow {
+	rows := make([]HexRow, 0, (len(data)+HexBytesPerRow-1)/HexBytesPerRow)
+	for start := 0; start < len(data); start += HexBytesPerRow {
+		end := min(start+HexBytesPerRow, len(data))
+		chunk := data[start:end]
+
+		hexParts := make([]string, len(chunk))
+		ascii := make([]byte, len(chunk))
+		for i, b := range chunk {
+			hexParts[i] = fmt.Sprintf("%02x", b)
+			if b >= 0x20 && b < 0x7f {
+				ascii[i] = b
+			} else {
+				ascii[i] = '.'
+			}
+		}
+
+		rows = append(rows, HexRow{
+			Offset: baseOffset + int64(start),
+			Hex:    strings.Join(hexParts, " "),
+			ASCII:  string(ascii),
+		})
+	}
+
+	return rows
+}
+
+// ParseHexBytes parses a hex string such as "de ad be ef" or "DEADBEEF".
+// Spaces between bytes are optional.
+func ParseHexBytes(value string) ([]byte, error) {
+	compact := strings.Join(strings.Fields(value), "")
+	if compact == "" {
+		return nil, fmt.Errorf("no bytes given")
+	}
+
+	data, err := hex.DecodeString(compact)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex bytes: %w", err)
+	}
+
+	return data, nil
+}
+
+// ApplyBytePatch returns a copy of data with patch written at offset. The
+// patch must fit inside data; files are never grown or truncated.
+func ApplyBytePatch(data []byte, offset int64, patch []byte) ([]byte, error) {
+	if offset < 0 || offset+int64(len(patch)) > int64(len(data)) {
+		return nil, fmt.Errorf("patch of %d bytes at offset %d does not fit in a file of %d bytes", len(patch), offset, len(data))
+	}
+
+	patched := make([]byte, len(data))
+	copy(patched, data)
+	copy(patched[offset:], patch)
+
+	return patched, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatHexRows(t *testing.T) {
+	data := append([]byte("A3 Online\x00\x01"), []byte("0123456789")...)
+
+	rows := FormatHexRows(data, 32)
+	require.Len(t, rows, 2)
+	assert.Equal(t, HexRow{Offset: 32, Hex: "41 33 20 4f 6e 6c 69 6e 65 00 01 30 31 32 33 34", ASCII: "A3 Online..01234"}, rows[0])
+	assert.Equal(t, HexRow{Offset: 48, Hex: "35 36 37 38 39", ASCII: "56789"}, rows[1])
+
+	assert.Empty(t, FormatHexRows(nil, 0))
+}
+
+func TestParseHexBytes(t *testing.T) {
+	data, err := ParseHexBytes("de ad BE EF")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, data)
+
+	data, err = ParseHexBytes("0a0B")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x0b}, data)
+
+	_, err = ParseHexBytes("  ")
+	assert.Error(t, err)
+
+	_, err = ParseHexBytes("abc")
+	assert.Error(t, err)
+
+	_, err = ParseHexBytes("zz")
+	assert.Error(t, err)
+}
+
+func TestApplyBytePatch(t *testing.T) {
+	data := []byte{0, 1, 2, 3, 4}
+
+	patched, err := ApplyBytePatch(data, 3, []byte{9, 9})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2, 9, 9}, patched)
+	assert.Equal(t, []byte{0, 1, 2, 3, 4}, data)
+
+	_, err = ApplyBytePatch(data, 4, []byte{9, 9})
+	assert.Error(t, err)
+
+	_, err = ApplyBytePatch(data, -1, []byte{9})
+	assert.Error(t, err)
+}
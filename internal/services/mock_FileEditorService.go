@@ -552,6 +552,130 @@ func (_c *MockFileEditorService_OpenFile_Call) RunAndReturn(run func(name string
 	return _c
 }
 
+// ReadClientItemFileBytes provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadClientItemFileBytes(data []byte) ([]ItemClientData, error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadClientItemFileBytes")
+	}
+
+	var r0 []ItemClientData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) ([]ItemClientData, error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) []ItemClientData); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ItemClientData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadClientItemFileBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadClientItemFileBytes'
+type MockFileEditorService_ReadClientItemFileBytes_Call struct {
+	*mock.Call
+}
+
+// ReadClientItemFileBytes is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockFileEditorService_Expecter) ReadClientItemFileBytes(data interface{}) *MockFileEditorService_ReadClientItemFileBytes_Call {
+	return &MockFileEditorService_ReadClientItemFileBytes_Call{Call: _e.mock.On("ReadClientItemFileBytes", data)}
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileBytes_Call) Run(run func(data []byte)) *MockFileEditorService_ReadClientItemFileBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileBytes_Call) Return(itemClientDatas []ItemClientData, err error) *MockFileEditorService_ReadClientItemFileBytes_Call {
+	_c.Call.Return(itemClientDatas, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileBytes_Call) RunAndReturn(run func(data []byte) ([]ItemClientData, error)) *MockFileEditorService_ReadClientItemFileBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadClientItemFileData provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadClientItemFileData(path string) ([]ItemClientData, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadClientItemFileData")
+	}
+
+	var r0 []ItemClientData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]ItemClientData, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []ItemClientData); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ItemClientData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadClientItemFileData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadClientItemFileData'
+type MockFileEditorService_ReadClientItemFileData_Call struct {
+	*mock.Call
+}
+
+// ReadClientItemFileData is a helper method to define mock.On call
+//   - path string
+func (_e *MockFileEditorService_Expecter) ReadClientItemFileData(path interface{}) *MockFileEditorService_ReadClientItemFileData_Call {
+	return &MockFileEditorService_ReadClientItemFileData_Call{Call: _e.mock.On("ReadClientItemFileData", path)}
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileData_Call) Run(run func(path string)) *MockFileEditorService_ReadClientItemFileData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileData_Call) Return(itemClientDatas []ItemClientData, err error) *MockFileEditorService_ReadClientItemFileData_Call {
+	_c.Call.Return(itemClientDatas, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadClientItemFileData_Call) RunAndReturn(run func(path string) ([]ItemClientData, error)) *MockFileEditorService_ReadClientItemFileData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadClientMapFileBytes provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) ReadClientMapFileBytes(data []byte) ([]MapClientData, error) {
 	ret := _mock.Called(data)
@@ -862,6 +986,130 @@ func (_c *MockFileEditorService_ReadDir_Call) RunAndReturn(run func(name string)
 	return _c
 }
 
+// ReadDropFileBytes provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadDropFileBytes(data []byte) (*DropFileData, error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadDropFileBytes")
+	}
+
+	var r0 *DropFileData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (*DropFileData, error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) *DropFileData); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DropFileData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadDropFileBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadDropFileBytes'
+type MockFileEditorService_ReadDropFileBytes_Call struct {
+	*mock.Call
+}
+
+// ReadDropFileBytes is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockFileEditorService_Expecter) ReadDropFileBytes(data interface{}) *MockFileEditorService_ReadDropFileBytes_Call {
+	return &MockFileEditorService_ReadDropFileBytes_Call{Call: _e.mock.On("ReadDropFileBytes", data)}
+}
+
+func (_c *MockFileEditorService_ReadDropFileBytes_Call) Run(run func(data []byte)) *MockFileEditorService_ReadDropFileBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadDropFileBytes_Call) Return(dropFileData *DropFileData, err error) *MockFileEditorService_ReadDropFileBytes_Call {
+	_c.Call.Return(dropFileData, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadDropFileBytes_Call) RunAndReturn(run func(data []byte) (*DropFileData, error)) *MockFileEditorService_ReadDropFileBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadDropFileData provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadDropFileData(path string) (*DropFileData, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadDropFileData")
+	}
+
+	var r0 *DropFileData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*DropFileData, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *DropFileData); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DropFileData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadDropFileData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadDropFileData'
+type MockFileEditorService_ReadDropFileData_Call struct {
+	*mock.Call
+}
+
+// ReadDropFileData is a helper method to define mock.On call
+//   - path string
+func (_e *MockFileEditorService_Expecter) ReadDropFileData(path interface{}) *MockFileEditorService_ReadDropFileData_Call {
+	return &MockFileEditorService_ReadDropFileData_Call{Call: _e.mock.On("ReadDropFileData", path)}
+}
+
+func (_c *MockFileEditorService_ReadDropFileData_Call) Run(run func(path string)) *MockFileEditorService_ReadDropFileData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadDropFileData_Call) Return(dropFileData *DropFileData, err error) *MockFileEditorService_ReadDropFileData_Call {
+	_c.Call.Return(dropFileData, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadDropFileData_Call) RunAndReturn(run func(path string) (*DropFileData, error)) *MockFileEditorService_ReadDropFileData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadFile provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) ReadFile(name string) ([]byte, error) {
 	ret := _mock.Called(name)
@@ -924,6 +1172,192 @@ func (_c *MockFileEditorService_ReadFile_Call) RunAndReturn(run func(name string
 	return _c
 }
 
+// ReadMapFileBytes provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadMapFileBytes(data []byte) (*MapFileData, error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMapFileBytes")
+	}
+
+	var r0 *MapFileData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (*MapFileData, error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) *MapFileData); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MapFileData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadMapFileBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadMapFileBytes'
+type MockFileEditorService_ReadMapFileBytes_Call struct {
+	*mock.Call
+}
+
+// ReadMapFileBytes is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockFileEditorService_Expecter) ReadMapFileBytes(data interface{}) *MockFileEditorService_ReadMapFileBytes_Call {
+	return &MockFileEditorService_ReadMapFileBytes_Call{Call: _e.mock.On("ReadMapFileBytes", data)}
+}
+
+func (_c *MockFileEditorService_ReadMapFileBytes_Call) Run(run func(data []byte)) *MockFileEditorService_ReadMapFileBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadMapFileBytes_Call) Return(mapFileData *MapFileData, err error) *MockFileEditorService_ReadMapFileBytes_Call {
+	_c.Call.Return(mapFileData, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadMapFileBytes_Call) RunAndReturn(run func(data []byte) (*MapFileData, error)) *MockFileEditorService_ReadMapFileBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadMapFileData provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadMapFileData(path string) (*MapFileData, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMapFileData")
+	}
+
+	var r0 *MapFileData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*MapFileData, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *MapFileData); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MapFileData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadMapFileData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadMapFileData'
+type MockFileEditorService_ReadMapFileData_Call struct {
+	*mock.Call
+}
+
+// ReadMapFileData is a helper method to define mock.On call
+//   - path string
+func (_e *MockFileEditorService_Expecter) ReadMapFileData(path interface{}) *MockFileEditorService_ReadMapFileData_Call {
+	return &MockFileEditorService_ReadMapFileData_Call{Call: _e.mock.On("ReadMapFileData", path)}
+}
+
+func (_c *MockFileEditorService_ReadMapFileData_Call) Run(run func(path string)) *MockFileEditorService_ReadMapFileData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadMapFileData_Call) Return(mapFileData *MapFileData, err error) *MockFileEditorService_ReadMapFileData_Call {
+	_c.Call.Return(mapFileData, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadMapFileData_Call) RunAndReturn(run func(path string) (*MapFileData, error)) *MockFileEditorService_ReadMapFileData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadNPCFileBytes provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadNPCFileBytes(data []byte) (*NPCFileData, error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadNPCFileBytes")
+	}
+
+	var r0 *NPCFileData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (*NPCFileData, error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) *NPCFileData); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*NPCFileData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadNPCFileBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadNPCFileBytes'
+type MockFileEditorService_ReadNPCFileBytes_Call struct {
+	*mock.Call
+}
+
+// ReadNPCFileBytes is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockFileEditorService_Expecter) ReadNPCFileBytes(data interface{}) *MockFileEditorService_ReadNPCFileBytes_Call {
+	return &MockFileEditorService_ReadNPCFileBytes_Call{Call: _e.mock.On("ReadNPCFileBytes", data)}
+}
+
+func (_c *MockFileEditorService_ReadNPCFileBytes_Call) Run(run func(data []byte)) *MockFileEditorService_ReadNPCFileBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadNPCFileBytes_Call) Return(nPCFileData *NPCFileData, err error) *MockFileEditorService_ReadNPCFileBytes_Call {
+	_c.Call.Return(nPCFileData, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadNPCFileBytes_Call) RunAndReturn(run func(data []byte) (*NPCFileData, error)) *MockFileEditorService_ReadNPCFileBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadNPCFileData provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) ReadNPCFileData(path string) (*NPCFileData, error) {
 	ret := _mock.Called(path)
@@ -986,6 +1420,68 @@ func (_c *MockFileEditorService_ReadNPCFileData_Call) RunAndReturn(run func(path
 	return _c
 }
 
+// ReadSpawnFileBytes provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) ReadSpawnFileBytes(data []byte) ([]NPCSpawnData, error) {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadSpawnFileBytes")
+	}
+
+	var r0 []NPCSpawnData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) ([]NPCSpawnData, error)); ok {
+		return returnFunc(data)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) []NPCSpawnData); ok {
+		r0 = returnFunc(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]NPCSpawnData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileEditorService_ReadSpawnFileBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadSpawnFileBytes'
+type MockFileEditorService_ReadSpawnFileBytes_Call struct {
+	*mock.Call
+}
+
+// ReadSpawnFileBytes is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockFileEditorService_Expecter) ReadSpawnFileBytes(data interface{}) *MockFileEditorService_ReadSpawnFileBytes_Call {
+	return &MockFileEditorService_ReadSpawnFileBytes_Call{Call: _e.mock.On("ReadSpawnFileBytes", data)}
+}
+
+func (_c *MockFileEditorService_ReadSpawnFileBytes_Call) Run(run func(data []byte)) *MockFileEditorService_ReadSpawnFileBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadSpawnFileBytes_Call) Return(nPCSpawnDatas []NPCSpawnData, err error) *MockFileEditorService_ReadSpawnFileBytes_Call {
+	_c.Call.Return(nPCSpawnDatas, err)
+	return _c
+}
+
+func (_c *MockFileEditorService_ReadSpawnFileBytes_Call) RunAndReturn(run func(data []byte) ([]NPCSpawnData, error)) *MockFileEditorService_ReadSpawnFileBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadSpawnFileData provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) ReadSpawnFileData(path string) ([]NPCSpawnData, error) {
 	ret := _mock.Called(path)
@@ -1150,6 +1646,63 @@ func (_c *MockFileEditorService_RemoveAll_Call) RunAndReturn(run func(path strin
 	return _c
 }
 
+// Rename provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) Rename(oldpath string, newpath string) error {
+	ret := _mock.Called(oldpath, newpath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(oldpath, newpath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileEditorService_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockFileEditorService_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - oldpath string
+//   - newpath string
+func (_e *MockFileEditorService_Expecter) Rename(oldpath interface{}, newpath interface{}) *MockFileEditorService_Rename_Call {
+	return &MockFileEditorService_Rename_Call{Call: _e.mock.On("Rename", oldpath, newpath)}
+}
+
+func (_c *MockFileEditorService_Rename_Call) Run(run func(oldpath string, newpath string)) *MockFileEditorService_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_Rename_Call) Return(err error) *MockFileEditorService_Rename_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileEditorService_Rename_Call) RunAndReturn(run func(oldpath string, newpath string) error) *MockFileEditorService_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stat provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) Stat(name string) (fs.FileInfo, error) {
 	ret := _mock.Called(name)
@@ -1212,6 +1765,63 @@ func (_c *MockFileEditorService_Stat_Call) RunAndReturn(run func(name string) (f
 	return _c
 }
 
+// WriteDropFileData provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) WriteDropFileData(path string, data *DropFileData) error {
+	ret := _mock.Called(path, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteDropFileData")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *DropFileData) error); ok {
+		r0 = returnFunc(path, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileEditorService_WriteDropFileData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteDropFileData'
+type MockFileEditorService_WriteDropFileData_Call struct {
+	*mock.Call
+}
+
+// WriteDropFileData is a helper method to define mock.On call
+//   - path string
+//   - data *DropFileData
+func (_e *MockFileEditorService_Expecter) WriteDropFileData(path interface{}, data interface{}) *MockFileEditorService_WriteDropFileData_Call {
+	return &MockFileEditorService_WriteDropFileData_Call{Call: _e.mock.On("WriteDropFileData", path, data)}
+}
+
+func (_c *MockFileEditorService_WriteDropFileData_Call) Run(run func(path string, data *DropFileData)) *MockFileEditorService_WriteDropFileData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *DropFileData
+		if args[1] != nil {
+			arg1 = args[1].(*DropFileData)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_WriteDropFileData_Call) Return(err error) *MockFileEditorService_WriteDropFileData_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileEditorService_WriteDropFileData_Call) RunAndReturn(run func(path string, data *DropFileData) error) *MockFileEditorService_WriteDropFileData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WriteFile provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) WriteFile(name string, data []byte, perm fs.FileMode) error {
 	ret := _mock.Called(name, data, perm)
@@ -1275,6 +1885,63 @@ func (_c *MockFileEditorService_WriteFile_Call) RunAndReturn(run func(name strin
 	return _c
 }
 
+// WriteMapFileData provides a mock function for the type MockFileEditorService
+func (_mock *MockFileEditorService) WriteMapFileData(path string, data *MapFileData) error {
+	ret := _mock.Called(path, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteMapFileData")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, *MapFileData) error); ok {
+		r0 = returnFunc(path, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileEditorService_WriteMapFileData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteMapFileData'
+type MockFileEditorService_WriteMapFileData_Call struct {
+	*mock.Call
+}
+
+// WriteMapFileData is a helper method to define mock.On call
+//   - path string
+//   - data *MapFileData
+func (_e *MockFileEditorService_Expecter) WriteMapFileData(path interface{}, data interface{}) *MockFileEditorService_WriteMapFileData_Call {
+	return &MockFileEditorService_WriteMapFileData_Call{Call: _e.mock.On("WriteMapFileData", path, data)}
+}
+
+func (_c *MockFileEditorService_WriteMapFileData_Call) Run(run func(path string, data *MapFileData)) *MockFileEditorService_WriteMapFileData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 *MapFileData
+		if args[1] != nil {
+			arg1 = args[1].(*MapFileData)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileEditorService_WriteMapFileData_Call) Return(err error) *MockFileEditorService_WriteMapFileData_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileEditorService_WriteMapFileData_Call) RunAndReturn(run func(path string, data *MapFileData) error) *MockFileEditorService_WriteMapFileData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WriteNPCFileData provides a mock function for the type MockFileEditorService
 func (_mock *MockFileEditorService) WriteNPCFileData(path string, data *NPCFileData) error {
 	ret := _mock.Called(path, data)
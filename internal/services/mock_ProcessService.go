@@ -37,6 +37,74 @@ func (_m *MockProcessService) EXPECT() *MockProcessService_Expecter {
 	return &MockProcessService_Expecter{mock: &_m.Mock}
 }
 
+// FindProcesses provides a mock function for the type MockProcessService
+func (_mock *MockProcessService) FindProcesses(pathOfBinary string, args []string) ([]ProcessInfo, error) {
+	ret := _mock.Called(pathOfBinary, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProcesses")
+	}
+
+	var r0 []ProcessInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, []string) ([]ProcessInfo, error)); ok {
+		return returnFunc(pathOfBinary, args)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, []string) []ProcessInfo); ok {
+		r0 = returnFunc(pathOfBinary, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ProcessInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = returnFunc(pathOfBinary, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProcessService_FindProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProcesses'
+type MockProcessService_FindProcesses_Call struct {
+	*mock.Call
+}
+
+// FindProcesses is a helper method to define mock.On call
+//   - pathOfBinary string
+//   - args []string
+func (_e *MockProcessService_Expecter) FindProcesses(pathOfBinary interface{}, args interface{}) *MockProcessService_FindProcesses_Call {
+	return &MockProcessService_FindProcesses_Call{Call: _e.mock.On("FindProcesses", pathOfBinary, args)}
+}
+
+func (_c *MockProcessService_FindProcesses_Call) Run(run func(pathOfBinary string, args []string)) *MockProcessService_FindProcesses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProcessService_FindProcesses_Call) Return(processInfos []ProcessInfo, err error) *MockProcessService_FindProcesses_Call {
+	_c.Call.Return(processInfos, err)
+	return _c
+}
+
+func (_c *MockProcessService_FindProcesses_Call) RunAndReturn(run func(pathOfBinary string, args []string) ([]ProcessInfo, error)) *MockProcessService_FindProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProcessByCommandLine provides a mock function for the type MockProcessService
 func (_mock *MockProcessService) GetProcessByCommandLine(pattern string) ([]ProcessInfo, error) {
 	ret := _mock.Called(pattern)
@@ -258,6 +326,132 @@ func (_c *MockProcessService_IsBatchFile_Call) RunAndReturn(run func(path string
 	return _c
 }
 
+// IsPIDRunning provides a mock function for the type MockProcessService
+func (_mock *MockProcessService) IsPIDRunning(pid int) (bool, error) {
+	ret := _mock.Called(pid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsPIDRunning")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return returnFunc(pid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = returnFunc(pid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(pid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProcessService_IsPIDRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsPIDRunning'
+type MockProcessService_IsPIDRunning_Call struct {
+	*mock.Call
+}
+
+// IsPIDRunning is a helper method to define mock.On call
+//   - pid int
+func (_e *MockProcessService_Expecter) IsPIDRunning(pid interface{}) *MockProcessService_IsPIDRunning_Call {
+	return &MockProcessService_IsPIDRunning_Call{Call: _e.mock.On("IsPIDRunning", pid)}
+}
+
+func (_c *MockProcessService_IsPIDRunning_Call) Run(run func(pid int)) *MockProcessService_IsPIDRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProcessService_IsPIDRunning_Call) Return(b bool, err error) *MockProcessService_IsPIDRunning_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockProcessService_IsPIDRunning_Call) RunAndReturn(run func(pid int) (bool, error)) *MockProcessService_IsPIDRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsProcessAlive provides a mock function for the type MockProcessService
+func (_mock *MockProcessService) IsProcessAlive(pid int, createTime int64) (bool, error) {
+	ret := _mock.Called(pid, createTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsProcessAlive")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int64) (bool, error)); ok {
+		return returnFunc(pid, createTime)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int64) bool); ok {
+		r0 = returnFunc(pid, createTime)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int64) error); ok {
+		r1 = returnFunc(pid, createTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProcessService_IsProcessAlive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsProcessAlive'
+type MockProcessService_IsProcessAlive_Call struct {
+	*mock.Call
+}
+
+// IsProcessAlive is a helper method to define mock.On call
+//   - pid int
+//   - createTime int64
+func (_e *MockProcessService_Expecter) IsProcessAlive(pid interface{}, createTime interface{}) *MockProcessService_IsProcessAlive_Call {
+	return &MockProcessService_IsProcessAlive_Call{Call: _e.mock.On("IsProcessAlive", pid, createTime)}
+}
+
+func (_c *MockProcessService_IsProcessAlive_Call) Run(run func(pid int, createTime int64)) *MockProcessService_IsProcessAlive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProcessService_IsProcessAlive_Call) Return(b bool, err error) *MockProcessService_IsProcessAlive_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockProcessService_IsProcessAlive_Call) RunAndReturn(run func(pid int, createTime int64) (bool, error)) *MockProcessService_IsProcessAlive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsProcessRunning provides a mock function for the type MockProcessService
 func (_mock *MockProcessService) IsProcessRunning(pathOfBinary string) (bool, error) {
 	ret := _mock.Called(pathOfBinary)
@@ -319,26 +513,31 @@ func (_c *MockProcessService_IsProcessRunning_Call) RunAndReturn(run func(pathOf
 }
 
 // StartProcess provides a mock function for the type MockProcessService
-func (_mock *MockProcessService) StartProcess(pathOfBinary string, startParams ...string) error {
-	var tmpRet mock.Arguments
-	if len(startParams) > 0 {
-		tmpRet = _mock.Called(pathOfBinary, startParams)
-	} else {
-		tmpRet = _mock.Called(pathOfBinary)
-	}
-	ret := tmpRet
+func (_mock *MockProcessService) StartProcess(pathOfBinary string, options ProcessStartOptions) (*ProcessInfo, error) {
+	ret := _mock.Called(pathOfBinary, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StartProcess")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, ...string) error); ok {
-		r0 = returnFunc(pathOfBinary, startParams...)
+	var r0 *ProcessInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, ProcessStartOptions) (*ProcessInfo, error)); ok {
+		return returnFunc(pathOfBinary, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, ProcessStartOptions) *ProcessInfo); ok {
+		r0 = returnFunc(pathOfBinary, options)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ProcessInfo)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(string, ProcessStartOptions) error); ok {
+		r1 = returnFunc(pathOfBinary, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockProcessService_StartProcess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartProcess'
@@ -348,63 +547,65 @@ type MockProcessService_StartProcess_Call struct {
 
 // StartProcess is a helper method to define mock.On call
 //   - pathOfBinary string
-//   - startParams ...string
-func (_e *MockProcessService_Expecter) StartProcess(pathOfBinary interface{}, startParams ...interface{}) *MockProcessService_StartProcess_Call {
-	return &MockProcessService_StartProcess_Call{Call: _e.mock.On("StartProcess",
-		append([]interface{}{pathOfBinary}, startParams...)...)}
+//   - options ProcessStartOptions
+func (_e *MockProcessService_Expecter) StartProcess(pathOfBinary interface{}, options interface{}) *MockProcessService_StartProcess_Call {
+	return &MockProcessService_StartProcess_Call{Call: _e.mock.On("StartProcess", pathOfBinary, options)}
 }
 
-func (_c *MockProcessService_StartProcess_Call) Run(run func(pathOfBinary string, startParams ...string)) *MockProcessService_StartProcess_Call {
+func (_c *MockProcessService_StartProcess_Call) Run(run func(pathOfBinary string, options ProcessStartOptions)) *MockProcessService_StartProcess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
-		var arg1 []string
-		var variadicArgs []string
-		if len(args) > 1 {
-			variadicArgs = args[1].([]string)
+		var arg1 ProcessStartOptions
+		if args[1] != nil {
+			arg1 = args[1].(ProcessStartOptions)
 		}
-		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1...,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockProcessService_StartProcess_Call) Return(err error) *MockProcessService_StartProcess_Call {
-	_c.Call.Return(err)
+func (_c *MockProcessService_StartProcess_Call) Return(processInfo *ProcessInfo, err error) *MockProcessService_StartProcess_Call {
+	_c.Call.Return(processInfo, err)
 	return _c
 }
 
-func (_c *MockProcessService_StartProcess_Call) RunAndReturn(run func(pathOfBinary string, startParams ...string) error) *MockProcessService_StartProcess_Call {
+func (_c *MockProcessService_StartProcess_Call) RunAndReturn(run func(pathOfBinary string, options ProcessStartOptions) (*ProcessInfo, error)) *MockProcessService_StartProcess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // StartProcessWithHealthCheck provides a mock function for the type MockProcessService
-func (_mock *MockProcessService) StartProcessWithHealthCheck(path string, port *int, timeout time.Duration, checkInterval time.Duration, startParams ...string) error {
-	var tmpRet mock.Arguments
-	if len(startParams) > 0 {
-		tmpRet = _mock.Called(path, port, timeout, checkInterval, startParams)
-	} else {
-		tmpRet = _mock.Called(path, port, timeout, checkInterval)
-	}
-	ret := tmpRet
+func (_mock *MockProcessService) StartProcessWithHealthCheck(path string, port *int, timeout time.Duration, checkInterval time.Duration, options ProcessStartOptions) (*ProcessInfo, error) {
+	ret := _mock.Called(path, port, timeout, checkInterval, options)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StartProcessWithHealthCheck")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, *int, time.Duration, time.Duration, ...string) error); ok {
-		r0 = returnFunc(path, port, timeout, checkInterval, startParams...)
+	var r0 *ProcessInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, *int, time.Duration, time.Duration, ProcessStartOptions) (*ProcessInfo, error)); ok {
+		return returnFunc(path, port, timeout, checkInterval, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, *int, time.Duration, time.Duration, ProcessStartOptions) *ProcessInfo); ok {
+		r0 = returnFunc(path, port, timeout, checkInterval, options)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ProcessInfo)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(string, *int, time.Duration, time.Duration, ProcessStartOptions) error); ok {
+		r1 = returnFunc(path, port, timeout, checkInterval, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockProcessService_StartProcessWithHealthCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartProcessWithHealthCheck'
@@ -417,13 +618,12 @@ type MockProcessService_StartProcessWithHealthCheck_Call struct {
 //   - port *int
 //   - timeout time.Duration
 //   - checkInterval time.Duration
-//   - startParams ...string
-func (_e *MockProcessService_Expecter) StartProcessWithHealthCheck(path interface{}, port interface{}, timeout interface{}, checkInterval interface{}, startParams ...interface{}) *MockProcessService_StartProcessWithHealthCheck_Call {
-	return &MockProcessService_StartProcessWithHealthCheck_Call{Call: _e.mock.On("StartProcessWithHealthCheck",
-		append([]interface{}{path, port, timeout, checkInterval}, startParams...)...)}
+//   - options ProcessStartOptions
+func (_e *MockProcessService_Expecter) StartProcessWithHealthCheck(path interface{}, port interface{}, timeout interface{}, checkInterval interface{}, options interface{}) *MockProcessService_StartProcessWithHealthCheck_Call {
+	return &MockProcessService_StartProcessWithHealthCheck_Call{Call: _e.mock.On("StartProcessWithHealthCheck", path, port, timeout, checkInterval, options)}
 }
 
-func (_c *MockProcessService_StartProcessWithHealthCheck_Call) Run(run func(path string, port *int, timeout time.Duration, checkInterval time.Duration, startParams ...string)) *MockProcessService_StartProcessWithHealthCheck_Call {
+func (_c *MockProcessService_StartProcessWithHealthCheck_Call) Run(run func(path string, port *int, timeout time.Duration, checkInterval time.Duration, options ProcessStartOptions)) *MockProcessService_StartProcessWithHealthCheck_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -441,29 +641,27 @@ func (_c *MockProcessService_StartProcessWithHealthCheck_Call) Run(run func(path
 		if args[3] != nil {
 			arg3 = args[3].(time.Duration)
 		}
-		var arg4 []string
-		var variadicArgs []string
-		if len(args) > 4 {
-			variadicArgs = args[4].([]string)
+		var arg4 ProcessStartOptions
+		if args[4] != nil {
+			arg4 = args[4].(ProcessStartOptions)
 		}
-		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4...,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockProcessService_StartProcessWithHealthCheck_Call) Return(err error) *MockProcessService_StartProcessWithHealthCheck_Call {
-	_c.Call.Return(err)
+func (_c *MockProcessService_StartProcessWithHealthCheck_Call) Return(processInfo *ProcessInfo, err error) *MockProcessService_StartProcessWithHealthCheck_Call {
+	_c.Call.Return(processInfo, err)
 	return _c
 }
 
-func (_c *MockProcessService_StartProcessWithHealthCheck_Call) RunAndReturn(run func(path string, port *int, timeout time.Duration, checkInterval time.Duration, startParams ...string) error) *MockProcessService_StartProcessWithHealthCheck_Call {
+func (_c *MockProcessService_StartProcessWithHealthCheck_Call) RunAndReturn(run func(path string, port *int, timeout time.Duration, checkInterval time.Duration, options ProcessStartOptions) (*ProcessInfo, error)) *MockProcessService_StartProcessWithHealthCheck_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -519,6 +717,63 @@ func (_c *MockProcessService_StopProcess_Call) RunAndReturn(run func(pathOfBinar
 	return _c
 }
 
+// StopProcessByPID provides a mock function for the type MockProcessService
+func (_mock *MockProcessService) StopProcessByPID(pid int, createTime int64) error {
+	ret := _mock.Called(pid, createTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopProcessByPID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, int64) error); ok {
+		r0 = returnFunc(pid, createTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProcessService_StopProcessByPID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopProcessByPID'
+type MockProcessService_StopProcessByPID_Call struct {
+	*mock.Call
+}
+
+// StopProcessByPID is a helper method to define mock.On call
+//   - pid int
+//   - createTime int64
+func (_e *MockProcessService_Expecter) StopProcessByPID(pid interface{}, createTime interface{}) *MockProcessService_StopProcessByPID_Call {
+	return &MockProcessService_StopProcessByPID_Call{Call: _e.mock.On("StopProcessByPID", pid, createTime)}
+}
+
+func (_c *MockProcessService_StopProcessByPID_Call) Run(run func(pid int, createTime int64)) *MockProcessService_StopProcessByPID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProcessService_StopProcessByPID_Call) Return(err error) *MockProcessService_StopProcessByPID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProcessService_StopProcessByPID_Call) RunAndReturn(run func(pid int, createTime int64) error) *MockProcessService_StopProcessByPID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitForPort provides a mock function for the type MockProcessService
 func (_mock *MockProcessService) WaitForPort(host string, port int, timeout time.Duration, checkInterval time.Duration) (bool, error) {
 	ret := _mock.Called(host, port, timeout, checkInterval)
@@ -97,6 +97,50 @@ func (_c *MockServerManagerService_GetProcessStatus_Call) RunAndReturn(run func(
 	return _c
 }
 
+// Start provides a mock function for the type MockServerManagerService
+func (_mock *MockServerManagerService) Start() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockServerManagerService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockServerManagerService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+func (_e *MockServerManagerService_Expecter) Start() *MockServerManagerService_Start_Call {
+	return &MockServerManagerService_Start_Call{Call: _e.mock.On("Start")}
+}
+
+func (_c *MockServerManagerService_Start_Call) Run(run func()) *MockServerManagerService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServerManagerService_Start_Call) Return(err error) *MockServerManagerService_Start_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockServerManagerService_Start_Call) RunAndReturn(run func() error) *MockServerManagerService_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartProcess provides a mock function for the type MockServerManagerService
 func (_mock *MockServerManagerService) StartProcess(id int64) error {
 	ret := _mock.Called(id)
@@ -149,20 +193,31 @@ func (_c *MockServerManagerService_StartProcess_Call) RunAndReturn(run func(id i
 }
 
 // StartServerSequence provides a mock function for the type MockServerManagerService
-func (_mock *MockServerManagerService) StartServerSequence() error {
+func (_mock *MockServerManagerService) StartServerSequence() ([]ProcessOutcome, error) {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for StartServerSequence")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
+	var r0 []ProcessOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]ProcessOutcome, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []ProcessOutcome); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ProcessOutcome)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockServerManagerService_StartServerSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartServerSequence'
@@ -182,12 +237,56 @@ func (_c *MockServerManagerService_StartServerSequence_Call) Run(run func()) *Mo
 	return _c
 }
 
-func (_c *MockServerManagerService_StartServerSequence_Call) Return(err error) *MockServerManagerService_StartServerSequence_Call {
+func (_c *MockServerManagerService_StartServerSequence_Call) Return(processOutcomes []ProcessOutcome, err error) *MockServerManagerService_StartServerSequence_Call {
+	_c.Call.Return(processOutcomes, err)
+	return _c
+}
+
+func (_c *MockServerManagerService_StartServerSequence_Call) RunAndReturn(run func() ([]ProcessOutcome, error)) *MockServerManagerService_StartServerSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockServerManagerService
+func (_mock *MockServerManagerService) Stop() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockServerManagerService_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockServerManagerService_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *MockServerManagerService_Expecter) Stop() *MockServerManagerService_Stop_Call {
+	return &MockServerManagerService_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *MockServerManagerService_Stop_Call) Run(run func()) *MockServerManagerService_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServerManagerService_Stop_Call) Return(err error) *MockServerManagerService_Stop_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockServerManagerService_StartServerSequence_Call) RunAndReturn(run func() error) *MockServerManagerService_StartServerSequence_Call {
+func (_c *MockServerManagerService_Stop_Call) RunAndReturn(run func() error) *MockServerManagerService_Stop_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -244,20 +343,31 @@ func (_c *MockServerManagerService_StopProcess_Call) RunAndReturn(run func(id in
 }
 
 // StopServerSequence provides a mock function for the type MockServerManagerService
-func (_mock *MockServerManagerService) StopServerSequence() error {
+func (_mock *MockServerManagerService) StopServerSequence() ([]ProcessOutcome, error) {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for StopServerSequence")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
+	var r0 []ProcessOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]ProcessOutcome, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []ProcessOutcome); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ProcessOutcome)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockServerManagerService_StopServerSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopServerSequence'
@@ -277,12 +387,12 @@ func (_c *MockServerManagerService_StopServerSequence_Call) Run(run func()) *Moc
 	return _c
 }
 
-func (_c *MockServerManagerService_StopServerSequence_Call) Return(err error) *MockServerManagerService_StopServerSequence_Call {
-	_c.Call.Return(err)
+func (_c *MockServerManagerService_StopServerSequence_Call) Return(processOutcomes []ProcessOutcome, err error) *MockServerManagerService_StopServerSequence_Call {
+	_c.Call.Return(processOutcomes, err)
 	return _c
 }
 
-func (_c *MockServerManagerService_StopServerSequence_Call) RunAndReturn(run func() error) *MockServerManagerService_StopServerSequence_Call {
+func (_c *MockServerManagerService_StopServerSequence_Call) RunAndReturn(run func() ([]ProcessOutcome, error)) *MockServerManagerService_StopServerSequence_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NPCFieldNames returns the names of the numeric NPC fields that can be read
+// and written by name, using the same naming as the NPC JSON API
+// (e.g. "hp", "attacks[0].damage").
+func NPCFieldNames() []string {
+	var names []string
+	collectNPCFieldNames("", reflect.TypeOf(NPCFileData{}), &names)
+	return names
+}
+
+func collectNPCFieldNames(prefix string, structType reflect.Type, names *[]string) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := prefix + strings.Split(field.Tag.Get("json"), ",")[0]
+
+		switch field.Type.Kind() {
+		case reflect.Array:
+			if field.Type.Elem().Kind() != reflect.Struct {
+				continue
+			}
+
+			for j := 0; j < field.Type.Len(); j++ {
+				collectNPCFieldNames(fmt.Sprintf("%s[%d].", name, j), field.Type.Elem(), names)
+			}
+		case reflect.Struct:
+			collectNPCFieldNames(name+".", field.Type, names)
+		default:
+			*names = append(*names, name)
+		}
+	}
+}
+
+func NPCFieldValue(data *NPCFileData, field string) (float64, error) {
+	value, err := npcField(reflect.ValueOf(data).Elem(), field)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.Uint()), nil
+}
+
+// SetNPCFieldValue rounds value to the nearest integer and stores it in the
+// named field, failing if it does not fit the field's binary size.
+func SetNPCFieldValue(data *NPCFileData, field string, value float64) error {
+	target, err := npcField(reflect.ValueOf(data).Elem(), field)
+	if err != nil {
+		return err
+	}
+
+	rounded := math.Round(value)
+	maxValue := math.Pow(2, float64(target.Type().Bits())) - 1
+	if math.IsNaN(rounded) || rounded < 0 || rounded > maxValue {
+		return fmt.Errorf("value %v is out of range for field %s", value, field)
+	}
+
+	target.SetUint(uint64(rounded))
+	return nil
+}
+
+func npcField(structValue reflect.Value, field string) (reflect.Value, error) {
+	name, rest, hasRest := strings.Cut(field, ".")
+
+	index, hasIndex := 0, false
+	if open := strings.Index(name, "["); open != -1 && strings.HasSuffix(name, "]") {
+		parsed, err := strconv.Atoi(name[open+1 : len(name)-1])
+		if err != nil || parsed < 0 {
+			return reflect.Value{}, fmt.Errorf("unknown field %s", field)
+		}
+
+		name, index, hasIndex = name[:open], parsed, true
+	}
+
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		if strings.Split(structType.Field(i).Tag.Get("json"), ",")[0] != name {
+			continue
+		}
+
+		value := structValue.Field(i)
+		if hasIndex {
+			// Only arrays of structs are addressable by index, matching
+			// NPCFieldNames; arrays such as the name are not numeric fields.
+			if value.Kind() != reflect.Array || value.Type().Elem().Kind() != reflect.Struct || index >= value.Len() {
+				return reflect.Value{}, fmt.Errorf("unknown field %s", field)
+			}
+
+			value = value.Index(index)
+		}
+
+		if hasRest {
+			if value.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("unknown field %s", field)
+			}
+
+			return npcField(value, rest)
+		}
+
+		switch value.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			return value, nil
+		default:
+			return reflect.Value{}, fmt.Errorf("field %s is not numeric", field)
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("unknown field %s", field)
+}
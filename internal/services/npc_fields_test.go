@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNPCFieldNames(t *testing.T) {
+	names := NPCFieldNames()
+
+	assert.Contains(t, names, "hp")
+	assert.Contains(t, names, "attacks[2].additional_damage")
+	assert.NotContains(t, names, "name")
+}
+
+func TestNPCFieldValue(t *testing.T) {
+	data := &NPCFileData{HP: 1500}
+	data.Attacks[1].Damage = 35
+
+	hp, err := NPCFieldValue(data, "hp")
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, hp)
+
+	damage, err := NPCFieldValue(data, "attacks[1].damage")
+	require.NoError(t, err)
+	assert.Equal(t, 35.0, damage)
+
+	_, err = NPCFieldValue(data, "name")
+	assert.Error(t, err)
+
+	_, err = NPCFieldValue(data, "attacks[3].damage")
+	assert.Error(t, err)
+
+	_, err = NPCFieldValue(data, "attacks[-2].damage")
+	assert.Error(t, err)
+
+	_, err = NPCFieldValue(data, "name[0]")
+	assert.Error(t, err)
+
+	_, err = NPCFieldValue(data, "does_not_exist")
+	assert.Error(t, err)
+}
+
+func TestSetNPCFieldValue(t *testing.T) {
+	data := &NPCFileData{}
+
+	require.NoError(t, SetNPCFieldValue(data, "hp", 1234.6))
+	assert.Equal(t, uint32(1235), data.HP)
+
+	require.NoError(t, SetNPCFieldValue(data, "attacks[0].range", 65535))
+	assert.Equal(t, uint16(65535), data.Attacks[0].Range)
+
+	assert.Error(t, SetNPCFieldValue(data, "level", 256))
+	assert.Error(t, SetNPCFieldValue(data, "level", -1))
+	assert.Equal(t, byte(0), data.Level)
+
+	assert.Error(t, SetNPCFieldValue(data, "attacks[-1].range", 1))
+	assert.Error(t, SetNPCFieldValue(data, "name[0]", 65))
+	assert.Equal(t, NPCFileData{HP: 1235, Attacks: data.Attacks}, *data)
+}
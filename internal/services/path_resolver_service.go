@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+// ErrPathNotAllowed is returned by Resolve for paths outside the allowed roots.
+var ErrPathNotAllowed = errors.New("path is outside the allowed roots")
+
+// ErrNoAllowedRoots is returned by Resolve when ALLOWED_ROOTS is not set. It
+// wraps ErrPathNotAllowed, so callers that only check for that still deny.
+var ErrNoAllowedRoots = fmt.Errorf("%w: ALLOWED_ROOTS is not set", ErrPathNotAllowed)
+
+// PathResolverService confines file access to the directories configured in
+// ALLOWED_ROOTS. Symbolic links are followed before checking containment, so
+// a link inside a root cannot be used to reach files outside it. With no roots
+// configured every path is denied, which is logged as a warning at startup.
+type PathResolverService interface {
+	Resolve(path string) (string, error)
+	IsWithin(dir string, path string) (bool, error)
+	Roots() []string
+	IsRestricted() bool
+}
+
+type allowedRoot struct {
+	path     string
+	realPath string
+}
+
+type pathResolverService struct {
+	logger logger.Logger
+	roots  []allowedRoot
+}
+
+// NewPathResolverService fails when roots are configured but none of them can
+// be loaded, rather than falling back to allowing every path.
+func NewPathResolverService(cfg *config.EnvVars, log logger.Logger) (PathResolverService, error) {
+	pr := &pathResolverService{logger: log}
+	pr.loadRoots(cfg.AllowedRoots)
+
+	if len(cfg.AllowedRoots) > 0 && len(pr.roots) == 0 {
+		return nil, fmt.Errorf("none of the allowed roots could be loaded: %s", strings.Join(cfg.AllowedRoots, ", "))
+	}
+
+	if len(pr.roots) == 0 {
+		log.Warn("ALLOWED_ROOTS is not set: file access through the API is disabled. Set ALLOWED_ROOTS to the server directories.")
+	}
+
+	return pr, nil
+}
+
+func (pr *pathResolverService) loadRoots(roots []string) {
+	for _, root := range roots {
+		absPath, err := filepath.Abs(root)
+		if err == nil {
+			var realPath string
+			realPath, err = evalExistingSymlinks(absPath)
+			if err == nil {
+				pr.roots = append(pr.roots, allowedRoot{path: absPath, realPath: realPath})
+				continue
+			}
+		}
+
+		pr.logger.Warn("ignoring invalid allowed root", logger.Field{Key: "root", Value: root}, logger.Field{Key: "error", Value: err})
+	}
+}
+
+func (pr *pathResolverService) IsRestricted() bool {
+	return len(pr.roots) > 0
+}
+
+func (pr *pathResolverService) Roots() []string {
+	roots := make([]string, len(pr.roots))
+	for i, root := range pr.roots {
+		roots[i] = root.path
+	}
+
+	return roots
+}
+
+// Resolve cleans path and checks that it is inside one of the allowed roots.
+// The returned path keeps any symbolic links, so file IDs derived from it do
+// not change; only the containment check uses the link targets.
+func (pr *pathResolverService) Resolve(path string) (string, error) {
+	if !pr.IsRestricted() {
+		return "", ErrNoAllowedRoots
+	}
+
+	cleanPath := filepath.Clean(path)
+
+	absPath, err := filepath.Abs(cleanPath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPathNotAllowed, cleanPath)
+	}
+
+	realPath, err := evalExistingSymlinks(absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", absPath, err)
+	}
+
+	for _, root := range pr.roots {
+		if isPathWithin(root.realPath, realPath) {
+			return absPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrPathNotAllowed, absPath)
+}
+
+// IsWithin reports whether path stays inside dir once the symbolic links in
+// both are followed, so that a link below dir cannot lead out of it. Unlike
+// Resolve it checks containment whether or not roots are configured.
+func (pr *pathResolverService) IsWithin(dir string, path string) (bool, error) {
+	realDir, err := evalAbsSymlinks(dir)
+	if err != nil {
+		return false, err
+	}
+
+	realPath, err := evalAbsSymlinks(path)
+	if err != nil {
+		return false, err
+	}
+
+	return isPathWithin(realDir, realPath), nil
+}
+
+func evalAbsSymlinks(path string) (string, error) {
+	absPath, err := filepath.Abs(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+
+	realPath, err := evalExistingSymlinks(absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", absPath, err)
+	}
+
+	return realPath, nil
+}
+
+// evalExistingSymlinks resolves symbolic links in the longest existing prefix
+// of path and appends the remaining, not yet created, components unchanged.
+func evalExistingSymlinks(path string) (string, error) {
+	var missing []string
+	current := path
+	for {
+		realPath, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				realPath = filepath.Join(realPath, missing[i])
+			}
+			return realPath, nil
+		}
+
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+
+		missing = append(missing, filepath.Base(current))
+		current = parent
+	}
+}
+
+func isPathWithin(root, path string) bool {
+	relative, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
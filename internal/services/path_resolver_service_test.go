@@ -0,0 +1,129 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathResolverDeniesWithoutRoots(t *testing.T) {
+	log := logger.NewMockLogger(t)
+	log.EXPECT().Warn(mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "ALLOWED_ROOTS is not set")
+	})).Return().Once()
+
+	pr, err := NewPathResolverService(&config.EnvVars{}, log)
+	require.NoError(t, err)
+
+	assert.False(t, pr.IsRestricted())
+	_, err = pr.Resolve("/etc/../etc/hosts")
+	assert.ErrorIs(t, err, ErrNoAllowedRoots)
+	assert.ErrorIs(t, err, ErrPathNotAllowed)
+}
+
+func TestPathResolverRestrictsToRoots(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "server")
+	outside := filepath.Join(base, "outside")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "NPC"), 0755))
+	require.NoError(t, os.MkdirAll(outside, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644))
+
+	linkToOutside := filepath.Join(root, "link")
+	if err := os.Symlink(outside, linkToOutside); err != nil {
+		t.Skip("symlinks are not supported: " + err.Error())
+	}
+
+	linkedRoot := filepath.Join(base, "linked-server")
+	require.NoError(t, os.Symlink(root, linkedRoot))
+
+	pr, err := NewPathResolverService(&config.EnvVars{AllowedRoots: []string{linkedRoot}}, nil)
+	require.NoError(t, err)
+	assert.True(t, pr.IsRestricted())
+	assert.Equal(t, []string{linkedRoot}, pr.Roots())
+
+	tests := []struct {
+		name    string
+		path    string
+		allowed bool
+	}{
+		{name: "root itself", path: linkedRoot, allowed: true},
+		{name: "file in root", path: filepath.Join(linkedRoot, "NPC", "1.dat"), allowed: true},
+		{name: "real path of root", path: filepath.Join(root, "NPC"), allowed: true},
+		{name: "not yet created file", path: filepath.Join(root, "new", "dir", "file.txt"), allowed: true},
+		{name: "dot dot escape", path: filepath.Join(root, "..", "outside", "secret.txt"), allowed: false},
+		{name: "outside root", path: filepath.Join(outside, "secret.txt"), allowed: false},
+		{name: "symlink escape", path: filepath.Join(linkToOutside, "secret.txt"), allowed: false},
+		{name: "sibling with root prefix", path: root + "-backup", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := pr.Resolve(tt.path)
+			if tt.allowed {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Clean(tt.path), path)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrPathNotAllowed)
+		})
+	}
+}
+
+func TestPathResolverFailsWithoutLoadableRoots(t *testing.T) {
+	log := logger.NewMockLogger(t)
+	log.EXPECT().Warn(mock.Anything, mock.Anything, mock.Anything).Return().Once()
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("not a directory"), 0644))
+
+	_, err := NewPathResolverService(&config.EnvVars{AllowedRoots: []string{filepath.Join(file, "server")}}, log)
+	assert.Error(t, err)
+}
+
+func TestPathResolverIsWithin(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "server", "Map")
+	outside := filepath.Join(base, "server", "NPC")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.MkdirAll(outside, 0755))
+
+	if err := os.Symlink(outside, filepath.Join(dir, "npc")); err != nil {
+		t.Skip("symlinks are not supported: " + err.Error())
+	}
+
+	linkedDir := filepath.Join(base, "map-link")
+	require.NoError(t, os.Symlink(dir, linkedDir))
+
+	pr, err := NewPathResolverService(&config.EnvVars{AllowedRoots: []string{base}}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		dir    string
+		path   string
+		within bool
+	}{
+		{name: "file in dir", dir: dir, path: filepath.Join(dir, "0.map"), within: true},
+		{name: "not yet created file", dir: dir, path: filepath.Join(dir, "new", "0.map"), within: true},
+		{name: "linked dir", dir: linkedDir, path: filepath.Join(dir, "0.map"), within: true},
+		{name: "symlink out of dir", dir: dir, path: filepath.Join(dir, "npc", "1.dat"), within: false},
+		{name: "sibling dir", dir: dir, path: filepath.Join(outside, "1.dat"), within: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			within, err := pr.IsWithin(tt.dir, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.within, within)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+const (
+	ProcessOutcomeStarted        = "started"
+	ProcessOutcomeAlreadyRunning = "already_running"
+	ProcessOutcomeStopped        = "stopped"
+	ProcessOutcomeFailed         = "failed"
+	ProcessOutcomeSkipped        = "skipped"
+)
+
+// ProcessOutcome is what happened to one process when starting or stopping
+// the whole server.
+type ProcessOutcome struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// processGraph is the dependency graph of the server processes. order lists
+// them so that every process comes after the processes it depends on, ties
+// broken by sequence order.
+type processGraph struct {
+	processes  map[int64]*db.ServerProcess
+	order      []int64
+	dependents map[int64][]int64
+}
+
+// newProcessGraph fails when a process depends on a process that is not in
+// processes, or when the dependencies form a cycle.
+func newProcessGraph(processes []db.ServerProcess) (*processGraph, error) {
+	g := &processGraph{
+		processes:  make(map[int64]*db.ServerProcess, len(processes)),
+		order:      make([]int64, 0, len(processes)),
+		dependents: make(map[int64][]int64, len(processes)),
+	}
+
+	for i := range processes {
+		g.processes[processes[i].ID] = &processes[i]
+	}
+
+	waiting := make(map[int64]int, len(processes))
+	for i := range processes {
+		proc := &processes[i]
+		for _, dependsOnID := range proc.DependsOn {
+			dependency, ok := g.processes[dependsOnID]
+			if !ok {
+				return nil, fmt.Errorf("%s depends on unknown process %d", proc.Name, dependsOnID)
+			}
+
+			g.dependents[dependency.ID] = append(g.dependents[dependency.ID], proc.ID)
+			waiting[proc.ID]++
+		}
+	}
+
+	placed := make(map[int64]bool, len(processes))
+	for len(g.order) < len(processes) {
+		progressed := false
+		for i := range processes {
+			id := processes[i].ID
+			if placed[id] || waiting[id] > 0 {
+				continue
+			}
+
+			placed[id] = true
+			g.order = append(g.order, id)
+			for _, dependentID := range g.dependents[id] {
+				waiting[dependentID]--
+			}
+			progressed = true
+			break
+		}
+
+		if !progressed {
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(findDependencyCycle(processes, placed), " -> "))
+		}
+	}
+
+	return g, nil
+}
+
+// findDependencyCycle returns the names along a cycle among the processes not
+// in placed, starting and ending with the same process.
+func findDependencyCycle(processes []db.ServerProcess, placed map[int64]bool) []string {
+	byID := make(map[int64]*db.ServerProcess, len(processes))
+	for i := range processes {
+		byID[processes[i].ID] = &processes[i]
+	}
+
+	var start *db.ServerProcess
+	for i := range processes {
+		if !placed[processes[i].ID] {
+			start = &processes[i]
+			break
+		}
+	}
+
+	// Every process left waits on another one left, so following the first
+	// such dependency must return to a process already visited.
+	visited := make(map[int64]int)
+	path := make([]*db.ServerProcess, 0)
+	for proc := start; ; {
+		if at, ok := visited[proc.ID]; ok {
+			names := make([]string, 0, len(path)-at+1)
+			for _, p := range path[at:] {
+				names = append(names, p.Name)
+			}
+			return append(names, proc.Name)
+		}
+
+		visited[proc.ID] = len(path)
+		path = append(path, proc)
+
+		for _, dependsOnID := range proc.DependsOn {
+			if !placed[dependsOnID] {
+				proc = byID[dependsOnID]
+				break
+			}
+		}
+	}
+}
+
+// ValidateProcessDependencies checks that the dependencies of processes refer
+// to processes in the list and do not form a cycle.
+func ValidateProcessDependencies(processes []db.ServerProcess) error {
+	_, err := newProcessGraph(processes)
+	return err
+}
+
+// dependentsOf returns every process that depends on id, directly or through
+// other processes, in start order.
+func (g *processGraph) dependentsOf(id int64) []int64 {
+	found := make(map[int64]bool)
+	queue := []int64{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, dependentID := range g.dependents[next] {
+			if !found[dependentID] {
+				found[dependentID] = true
+				queue = append(queue, dependentID)
+			}
+		}
+	}
+
+	dependents := make([]int64, 0, len(found))
+	for _, processID := range g.order {
+		if found[processID] {
+			dependents = append(dependents, processID)
+		}
+	}
+
+	return dependents
+}
+
+// walk runs step for every process once the processes it waits on are done,
+// running processes that do not wait on each other in parallel. A process
+// waiting on one that failed or was skipped is skipped. Outcomes are returned
+// in start order.
+func (g *processGraph) walk(waitsOn func(id int64) []int64, step func(proc *db.ServerProcess) (string, error)) []ProcessOutcome {
+	done := make(map[int64]chan struct{}, len(g.order))
+	outcomes := make(map[int64]*ProcessOutcome, len(g.order))
+	for _, id := range g.order {
+		done[id] = make(chan struct{})
+		outcomes[id] = &ProcessOutcome{ID: id, Name: g.processes[id].Name}
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range g.order {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			defer close(done[id])
+
+			outcome := outcomes[id]
+			for _, waitID := range waitsOn(id) {
+				<-done[waitID]
+				if waited := outcomes[waitID]; outcome.Outcome == "" && (waited.Outcome == ProcessOutcomeFailed || waited.Outcome == ProcessOutcomeSkipped) {
+					outcome.Outcome = ProcessOutcomeSkipped
+					outcome.Error = fmt.Sprintf("not attempted because %s %s", waited.Name, waited.Outcome)
+				}
+			}
+
+			if outcome.Outcome != "" {
+				return
+			}
+
+			result, err := step(g.processes[id])
+			outcome.Outcome = result
+			if err != nil {
+				outcome.Error = err.Error()
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	result := make([]ProcessOutcome, 0, len(g.order))
+	for _, id := range g.order {
+		result = append(result, *outcomes[id])
+	}
+
+	return result
+}
+
+// outcomesError names the processes in outcomes that failed or were
+// skipped, or returns nil if there are none.
+func outcomesError(outcomes []ProcessOutcome, action string) error {
+	var failed, skipped []string
+	for _, outcome := range outcomes {
+		switch outcome.Outcome {
+		case ProcessOutcomeFailed:
+			failed = append(failed, outcome.Name)
+		case ProcessOutcomeSkipped:
+			skipped = append(skipped, outcome.Name)
+		}
+	}
+
+	if len(failed) == 0 && len(skipped) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("failed to %s %s", action, strings.Join(failed, ", "))
+	if len(skipped) > 0 {
+		message += "; skipped " + strings.Join(skipped, ", ")
+	}
+
+	return errors.New(message)
+}
+
+// startGraphProcess treats a process that is already running as started, so
+// that the processes depending on it are started too.
+func (s *serverManagerService) startGraphProcess(proc *db.ServerProcess) (string, error) {
+	if err := s.checkNotRestarting(proc); err != nil {
+		return ProcessOutcomeFailed, err
+	}
+
+	s.logger.Info("starting process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID})
+
+	if err := s.startProcessInternal(proc); err != nil {
+		if errors.Is(err, errProcessAlreadyRunning) {
+			return ProcessOutcomeAlreadyRunning, nil
+		}
+
+		s.logger.Error("failed to start process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
+		return ProcessOutcomeFailed, err
+	}
+
+	return ProcessOutcomeStarted, nil
+}
+
+func (s *serverManagerService) stopGraphProcess(proc *db.ServerProcess) (string, error) {
+	if err := s.checkNotRestarting(proc); err != nil {
+		return ProcessOutcomeFailed, err
+	}
+
+	s.logger.Info("stopping process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID})
+
+	if err := s.stopProcessInternal(proc); err != nil {
+		s.logger.Error("failed to stop process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
+		return ProcessOutcomeFailed, err
+	}
+
+	return ProcessOutcomeStopped, nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testProcessGraph returns the processes in sequence order: ZoneServer depends
+// on AccountServer and DBAgent, and BattleServer on ZoneServer.
+func testProcessGraph() []db.ServerProcess {
+	return []db.ServerProcess{
+		{ID: 3, Name: "ZoneServer", Path: "zone.exe", SequenceOrder: 1, DependsOn: []int64{1, 2}},
+		{ID: 4, Name: "BattleServer", Path: "battle.exe", SequenceOrder: 2, DependsOn: []int64{3}},
+		{ID: 1, Name: "AccountServer", Path: "account.exe", SequenceOrder: 3},
+		{ID: 2, Name: "DBAgent", Path: "dbagent.exe", SequenceOrder: 4},
+	}
+}
+
+func TestNewProcessGraphOrdersByDependencies(t *testing.T) {
+	graph, err := newProcessGraph(testProcessGraph())
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{1, 2, 3, 4}, graph.order)
+	assert.Equal(t, []int64{3, 4}, graph.dependentsOf(1))
+	assert.Empty(t, graph.dependentsOf(4))
+}
+
+func TestValidateProcessDependencies(t *testing.T) {
+	processes := testProcessGraph()
+	processes[2].DependsOn = []int64{4}
+
+	err := ValidateProcessDependencies(processes)
+	assert.EqualError(t, err, "dependency cycle: ZoneServer -> AccountServer -> BattleServer -> ZoneServer")
+
+	processes = testProcessGraph()
+	processes[1].DependsOn = []int64{9}
+
+	err = ValidateProcessDependencies(processes)
+	assert.EqualError(t, err, "BattleServer depends on unknown process 9")
+
+	assert.NoError(t, ValidateProcessDependencies(testProcessGraph()))
+}
+
+func TestStartServerSequenceSkipsDependentsOfFailedProcess(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	processes := testProcessGraph()
+	processes[3].PID = intPtr(200)
+	processes[3].PIDCreateTime = int64Ptr(2000)
+
+	internalDB.EXPECT().GetServerProcesses().Return(processes, nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("account.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("port 9000 not ready")).Once()
+	processService.EXPECT().IsProcessAlive(200, int64(2000)).Return(true, nil).Once()
+
+	outcomes, err := s.StartServerSequence()
+	assert.EqualError(t, err, "failed to start AccountServer; skipped ZoneServer, BattleServer")
+	assert.Equal(t, []ProcessOutcome{
+		{ID: 1, Name: "AccountServer", Outcome: ProcessOutcomeFailed, Error: "failed to start process: port 9000 not ready"},
+		{ID: 2, Name: "DBAgent", Outcome: ProcessOutcomeAlreadyRunning},
+		{ID: 3, Name: "ZoneServer", Outcome: ProcessOutcomeSkipped, Error: "not attempted because AccountServer failed"},
+		{ID: 4, Name: "BattleServer", Outcome: ProcessOutcomeSkipped, Error: "not attempted because ZoneServer skipped"},
+	}, outcomes)
+}
+
+func TestStartServerSequenceStartsIndependentProcessesInParallel(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	processes := testProcessGraph()
+	internalDB.EXPECT().GetServerProcesses().Return(processes, nil).Once()
+
+	// AccountServer and DBAgent each wait until the other one is starting,
+	// which only completes when they are started at the same time.
+	var starting sync.WaitGroup
+	starting.Add(2)
+	waitForOther := func(string, *int, time.Duration, time.Duration, ProcessStartOptions) {
+		starting.Done()
+		waited := make(chan struct{})
+		go func() {
+			starting.Wait()
+			close(waited)
+		}()
+
+		select {
+		case <-waited:
+		case <-time.After(5 * time.Second):
+			t.Error("independent processes were not started in parallel")
+		}
+	}
+
+	now := time.Now()
+	for id, path := range map[int64]string{1: "account.exe", 2: "dbagent.exe", 3: "zone.exe", 4: "battle.exe"} {
+		call := processService.EXPECT().StartProcessWithHealthCheck(path, (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(&ProcessInfo{PID: int(id) * 100, StartTime: now}, nil)
+		if id <= 2 {
+			call.Run(waitForOther)
+		}
+		call.Once()
+		internalDB.EXPECT().UpdateProcessPID(id, intPtr(int(id)*100), int64Ptr(now.UnixMilli())).Return(nil).Once()
+		internalDB.EXPECT().UpdateProcessStartTime(id, mock.Anything).Return(nil).Once()
+	}
+
+	outcomes, err := s.StartServerSequence()
+	require.NoError(t, err)
+	for _, outcome := range outcomes {
+		assert.Equal(t, ProcessOutcomeStarted, outcome.Outcome, outcome.Name)
+	}
+}
+
+func TestStopServerSequenceStopsDependentsFirst(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	internalDB.EXPECT().GetServerProcesses().Return(testProcessGraph(), nil).Once()
+	processService.EXPECT().FindProcesses(mock.Anything, []string(nil)).Return(nil, nil).Times(4)
+
+	var mu sync.Mutex
+	var stopped []int64
+	internalDB.EXPECT().UpdateProcessEndTime(mock.Anything, mock.Anything).
+		Run(func(id int64, endTime time.Time) {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, id)
+		}).
+		Return(nil).Times(4)
+
+	outcomes, err := s.StopServerSequence()
+	require.NoError(t, err)
+	require.Len(t, outcomes, 4)
+	for _, outcome := range outcomes {
+		assert.Equal(t, ProcessOutcomeStopped, outcome.Outcome, outcome.Name)
+	}
+
+	require.Len(t, stopped, 4)
+	assert.Equal(t, []int64{4, 3}, stopped[:2])
+	assert.ElementsMatch(t, []int64{1, 2}, stopped[2:])
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+const (
+	ProcessLogStdout = "stdout"
+	ProcessLogStderr = "stderr"
+)
+
+// maxProcessLogLineLength is the longest line kept; longer output without a
+// line break is split.
+const maxProcessLogLineLength = 64 * 1024
+
+// ProcessLogLine is one line of process output. Offsets count the lines a
+// process has written since the agent started, so they identify a line and
+// let readers continue where they stopped.
+type ProcessLogLine struct {
+	Offset int64     `json:"offset"`
+	Stream string    `json:"stream"`
+	Text   string    `json:"text"`
+	Time   time.Time `json:"time"`
+}
+
+type ProcessLogPage struct {
+	Lines []ProcessLogLine `json:"lines"`
+	// FirstOffset is the oldest line still held in memory and NextOffset the
+	// offset the next line will get.
+	FirstOffset int64 `json:"first_offset"`
+	NextOffset  int64 `json:"next_offset"`
+	// Truncated is set when requested lines are no longer held in memory.
+	// They can still be found in the log files.
+	Truncated bool `json:"truncated"`
+}
+
+// ProcessLogService captures the console output of server processes. Every
+// line is appended to a log file per process, rotated by size, and the most
+// recent lines are kept in memory for reading and following.
+type ProcessLogService interface {
+	Start() error
+	Stop() error
+	// Output returns the writers to connect to a process's stdout and
+	// stderr.
+	Output(processID int64) (io.Writer, io.Writer)
+	// Read returns up to limit lines starting at offset. A negative offset
+	// reads the last limit lines.
+	Read(processID int64, offset int64, limit int) ProcessLogPage
+	// Follow reads like Read and also returns the lines written after them.
+	// The returned function stops following and must be called.
+	Follow(processID int64, offset int64, limit int) (ProcessLogPage, <-chan ProcessLogLine, func())
+	LogFilePath(processID int64) string
+}
+
+type processLogService struct {
+	cfg    *config.EnvVars
+	logger logger.Logger
+	mu     sync.Mutex
+	logs   map[int64]*processLog
+}
+
+type processLog struct {
+	mu sync.Mutex
+	// ring holds the last len(ring) lines; the line with offset o is at
+	// ring[o%len(ring)].
+	ring        []ProcessLogLine
+	count       int
+	next        int64
+	path        string
+	file        *os.File
+	size        int64
+	partial     map[string][]byte
+	subscribers map[chan ProcessLogLine]struct{}
+}
+
+func NewProcessLogService(cfg *config.EnvVars, logger logger.Logger) ProcessLogService {
+	return &processLogService{
+		cfg:    cfg,
+		logger: logger,
+		logs:   make(map[int64]*processLog),
+	}
+}
+
+func (pls *processLogService) Start() error {
+	if err := os.MkdirAll(pls.cfg.ProcessLogDirectory, 0755); err != nil {
+		return fmt.Errorf("failed to create process log directory: %w", err)
+	}
+
+	return nil
+}
+
+func (pls *processLogService) Stop() error {
+	pls.mu.Lock()
+	defer pls.mu.Unlock()
+
+	for _, pl := range pls.logs {
+		pl.mu.Lock()
+		if pl.file != nil {
+			_ = pl.file.Close()
+			pl.file = nil
+		}
+		pl.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (pls *processLogService) LogFilePath(processID int64) string {
+	return filepath.Join(pls.cfg.ProcessLogDirectory, fmt.Sprintf("process_%d.log", processID))
+}
+
+func (pls *processLogService) getLog(processID int64) *processLog {
+	pls.mu.Lock()
+	defer pls.mu.Unlock()
+
+	pl, ok := pls.logs[processID]
+	if !ok {
+		pl = &processLog{
+			ring:        make([]ProcessLogLine, max(pls.cfg.ProcessLogBufferLines, 1)),
+			path:        pls.LogFilePath(processID),
+			partial:     make(map[string][]byte),
+			subscribers: make(map[chan ProcessLogLine]struct{}),
+		}
+		pls.logs[processID] = pl
+	}
+
+	return pl
+}
+
+func (pls *processLogService) Output(processID int64) (io.Writer, io.Writer) {
+	pl := pls.getLog(processID)
+	return &processLogWriter{service: pls, log: pl, stream: ProcessLogStdout},
+		&processLogWriter{service: pls, log: pl, stream: ProcessLogStderr}
+}
+
+func (pls *processLogService) Read(processID int64, offset int64, limit int) ProcessLogPage {
+	pl := pls.getLog(processID)
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	return pl.read(offset, limit)
+}
+
+func (pls *processLogService) Follow(processID int64, offset int64, limit int) (ProcessLogPage, <-chan ProcessLogLine, func()) {
+	pl := pls.getLog(processID)
+	ch := make(chan ProcessLogLine, 256)
+
+	pl.mu.Lock()
+	page := pl.read(offset, limit)
+	pl.subscribers[ch] = struct{}{}
+	pl.mu.Unlock()
+
+	return page, ch, func() {
+		pl.mu.Lock()
+		delete(pl.subscribers, ch)
+		pl.mu.Unlock()
+	}
+}
+
+func (pl *processLog) read(offset int64, limit int) ProcessLogPage {
+	first := pl.next - int64(pl.count)
+	page := ProcessLogPage{
+		Lines:       []ProcessLogLine{},
+		FirstOffset: first,
+		NextOffset:  pl.next,
+	}
+
+	if limit <= 0 {
+		return page
+	}
+
+	start := offset
+	if offset < 0 {
+		start = max(pl.next-int64(limit), first)
+	} else if start < first {
+		start = first
+		page.Truncated = true
+	}
+
+	for o := start; o < pl.next && len(page.Lines) < limit; o++ {
+		page.Lines = append(page.Lines, pl.ring[o%int64(len(pl.ring))])
+	}
+
+	return page
+}
+
+type processLogWriter struct {
+	service *processLogService
+	log     *processLog
+	stream  string
+}
+
+// Write splits p into lines. An unfinished last line is kept until the rest
+// of it is written.
+func (w *processLogWriter) Write(p []byte) (int, error) {
+	pl := w.log
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	data := append(pl.partial[w.stream], p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.appendLine(data[:i])
+		data = data[i+1:]
+	}
+
+	for len(data) > maxProcessLogLineLength {
+		w.appendLine(data[:maxProcessLogLineLength])
+		data = data[maxProcessLogLineLength:]
+	}
+
+	pl.partial[w.stream] = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+func (w *processLogWriter) appendLine(raw []byte) {
+	pl := w.log
+	line := ProcessLogLine{
+		Offset: pl.next,
+		Stream: w.stream,
+		Text:   strings.ToValidUTF8(strings.TrimRight(string(raw), "\r"), "�"),
+		Time:   time.Now().UTC(),
+	}
+
+	pl.ring[pl.next%int64(len(pl.ring))] = line
+	pl.next++
+	pl.count = min(pl.count+1, len(pl.ring))
+
+	w.service.writeLogFile(pl, line)
+
+	for ch := range pl.subscribers {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// writeLogFile appends line to the process's log file. When the file would
+// grow beyond ProcessLogMaxSizeMb it is renamed to .1, older files move up
+// by one and the oldest beyond ProcessLogMaxFiles is removed.
+func (pls *processLogService) writeLogFile(pl *processLog, line ProcessLogLine) {
+	entry := fmt.Sprintf("%s [%s] %s\n", line.Time.Format(time.RFC3339), line.Stream, line.Text)
+	maxSize := int64(pls.cfg.ProcessLogMaxSizeMb) * 1024 * 1024
+
+	if pl.file != nil && maxSize > 0 && pl.size+int64(len(entry)) > maxSize {
+		_ = pl.file.Close()
+		pl.file = nil
+		pls.rotateLogFiles(pl.path)
+	}
+
+	if pl.file == nil {
+		file, err := os.OpenFile(pl.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			pls.logger.Error("failed to open process log file", logger.Field{Key: "path", Value: pl.path}, logger.Field{Key: "error", Value: err})
+			return
+		}
+
+		info, err := file.Stat()
+		if err == nil {
+			pl.size = info.Size()
+		}
+		pl.file = file
+	}
+
+	n, err := pl.file.WriteString(entry)
+	pl.size += int64(n)
+	if err != nil {
+		pls.logger.Error("failed to write process log file", logger.Field{Key: "path", Value: pl.path}, logger.Field{Key: "error", Value: err})
+	}
+}
+
+func (pls *processLogService) rotateLogFiles(path string) {
+	keep := max(pls.cfg.ProcessLogMaxFiles, 1)
+	_ = os.Remove(fmt.Sprintf("%s.%d", path, keep))
+
+	for i := keep - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		pls.logger.Error("failed to rotate process log file", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProcessLogService(t *testing.T, bufferLines int) (ProcessLogService, *config.EnvVars) {
+	cfg := &config.EnvVars{
+		ProcessLogDirectory:   t.TempDir(),
+		ProcessLogMaxSizeMb:   1,
+		ProcessLogMaxFiles:    2,
+		ProcessLogBufferLines: bufferLines,
+	}
+
+	pls := NewProcessLogService(cfg, logger.NewMockLogger(t))
+	require.NoError(t, pls.Start())
+	t.Cleanup(func() {
+		require.NoError(t, pls.Stop())
+	})
+
+	return pls, cfg
+}
+
+func lineTexts(lines []ProcessLogLine) []string {
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+	}
+
+	return texts
+}
+
+func TestProcessLogSplitsOutputIntoLines(t *testing.T) {
+	pls, _ := newTestProcessLogService(t, 10)
+	stdout, stderr := pls.Output(1)
+
+	_, _ = stdout.Write([]byte("Zone server star"))
+	_, _ = stderr.Write([]byte("warning: map 7 missing\r\n"))
+	_, _ = stdout.Write([]byte("ting\nlistening on 9000\n"))
+
+	page := pls.Read(1, -1, 10)
+	assert.Equal(t, []string{"warning: map 7 missing", "Zone server starting", "listening on 9000"}, lineTexts(page.Lines))
+	assert.Equal(t, ProcessLogStderr, page.Lines[0].Stream)
+	assert.Equal(t, ProcessLogStdout, page.Lines[1].Stream)
+	assert.Equal(t, int64(3), page.NextOffset)
+
+	data, err := os.ReadFile(pls.LogFilePath(1))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[stderr] warning: map 7 missing\n")
+	assert.Contains(t, string(data), "[stdout] listening on 9000\n")
+
+	assert.Empty(t, pls.Read(2, -1, 10).Lines)
+}
+
+func TestProcessLogRingBuffer(t *testing.T) {
+	pls, _ := newTestProcessLogService(t, 3)
+	stdout, _ := pls.Output(1)
+
+	for i := 0; i < 5; i++ {
+		_, _ = fmt.Fprintf(stdout, "line %d\n", i)
+	}
+
+	tail := pls.Read(1, -1, 2)
+	assert.Equal(t, []string{"line 3", "line 4"}, lineTexts(tail.Lines))
+	assert.Equal(t, int64(2), tail.FirstOffset)
+	assert.Equal(t, int64(5), tail.NextOffset)
+	assert.False(t, tail.Truncated)
+
+	fromOffset := pls.Read(1, 0, 10)
+	assert.Equal(t, []string{"line 2", "line 3", "line 4"}, lineTexts(fromOffset.Lines))
+	assert.True(t, fromOffset.Truncated)
+
+	page := pls.Read(1, 3, 1)
+	assert.Equal(t, []string{"line 3"}, lineTexts(page.Lines))
+	assert.Equal(t, int64(3), page.Lines[0].Offset)
+}
+
+func TestProcessLogFollow(t *testing.T) {
+	pls, _ := newTestProcessLogService(t, 10)
+	stdout, _ := pls.Output(1)
+
+	_, _ = stdout.Write([]byte("booting\n"))
+
+	page, lines, unfollow := pls.Follow(1, -1, 10)
+	assert.Equal(t, []string{"booting"}, lineTexts(page.Lines))
+
+	_, _ = stdout.Write([]byte("ready\n"))
+	line := <-lines
+	assert.Equal(t, "ready", line.Text)
+	assert.Equal(t, int64(1), line.Offset)
+
+	unfollow()
+	_, _ = stdout.Write([]byte("ignored\n"))
+	assert.Empty(t, lines)
+}
+
+func TestProcessLogRotatesFiles(t *testing.T) {
+	pls, _ := newTestProcessLogService(t, 10)
+	stdout, _ := pls.Output(1)
+
+	text := strings.Repeat("x", 1000) + "\n"
+	for i := 0; i < 3500; i++ {
+		_, _ = stdout.Write([]byte(text))
+	}
+
+	path := pls.LogFilePath(1)
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(1024*1024))
+	}
+
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -25,17 +27,33 @@ type ProcessInfo struct {
 	StartTime   time.Time `json:"start_time"`
 }
 
+// ProcessStartOptions configures how a process is started. Dir defaults to
+// the directory of the binary and Env, in "KEY=value" form, is added to the
+// agent's environment. Output written to stdout or stderr is copied to Stdout
+// and Stderr when they are set and discarded otherwise.
+type ProcessStartOptions struct {
+	Args   []string
+	Env    []string
+	Dir    string
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
 type ProcessService interface {
 	GetProcessList() ([]ProcessInfo, error)
 	GetProcessCount() (int, error)
 	IsProcessRunning(pathOfBinary string) (bool, error)
-	StartProcess(pathOfBinary string, startParams ...string) error
+	IsPIDRunning(pid int) (bool, error)
+	StartProcess(pathOfBinary string, options ProcessStartOptions) (*ProcessInfo, error)
 	StopProcess(pathOfBinary string) error
+	FindProcesses(pathOfBinary string, args []string) ([]ProcessInfo, error)
+	IsProcessAlive(pid int, createTime int64) (bool, error)
+	StopProcessByPID(pid int, createTime int64) error
 	IsBatchFile(path string) bool
 	GetProcessByCommandLine(pattern string) ([]ProcessInfo, error)
 	WaitForPort(host string, port int, timeout, checkInterval time.Duration) (bool, error)
 	WaitForProcess(path string, timeout, checkInterval time.Duration) (bool, error)
-	StartProcessWithHealthCheck(path string, port *int, timeout, checkInterval time.Duration, startParams ...string) error
+	StartProcessWithHealthCheck(path string, port *int, timeout, checkInterval time.Duration, options ProcessStartOptions) (*ProcessInfo, error)
 }
 
 type processService struct {
@@ -110,6 +128,16 @@ func (ps *processService) GetProcessCount() (int, error) {
 	return len(list), nil
 }
 
+func (ps *processService) IsPIDRunning(pid int) (bool, error) {
+	exists, err := process.PidExists(int32(pid))
+	if err != nil {
+		ps.logger.Error("failed to check process", logger.Field{Key: "pid", Value: pid}, logger.Field{Key: "error", Value: err})
+		return false, fmt.Errorf("failed to check process %d: %w", pid, err)
+	}
+
+	return exists, nil
+}
+
 func (ps *processService) IsProcessRunning(pathOfBinary string) (bool, error) {
 	normalizedPath, err := ps.normalizePath(pathOfBinary)
 	if err != nil {
@@ -167,6 +195,103 @@ func (ps *processService) isBatchFileRunning(normalizedPath string) (bool, error
 	return false, nil
 }
 
+// FindProcesses returns the processes running the binary at pathOfBinary with
+// args, found by comparing paths and command lines of all processes. Several
+// instances of one binary can run with different arguments, so without
+// arguments every instance matches, and with arguments only those whose
+// command line contains them.
+func (ps *processService) FindProcesses(pathOfBinary string, args []string) ([]ProcessInfo, error) {
+	normalizedPath, err := ps.normalizePath(pathOfBinary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize path: %w", err)
+	}
+
+	processes, err := ps.GetProcessList()
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedArgs := ps.normalizeCommandLine(strings.Join(args, " "))
+	isBatchFile := ps.IsBatchFile(pathOfBinary)
+	var matches []ProcessInfo
+	for _, proc := range processes {
+		normalizedCmdLine := ps.normalizeCommandLine(proc.CommandLine)
+		if isBatchFile {
+			if proc.Name != "cmd.exe" || proc.CommandLine == "" || !strings.Contains(normalizedCmdLine, normalizedPath) {
+				continue
+			}
+		} else {
+			if proc.Path == "" {
+				continue
+			}
+
+			procPath, err := ps.normalizePath(proc.Path)
+			if err != nil || procPath != normalizedPath {
+				continue
+			}
+		}
+
+		if len(args) == 0 || strings.Contains(normalizedCmdLine, normalizedArgs) {
+			matches = append(matches, proc)
+		}
+	}
+
+	return matches, nil
+}
+
+// IsProcessAlive reports whether the process with pid is the one that was
+// created at createTime, in milliseconds since the epoch, and has not exited.
+// Comparing the create time guards against the PID having been reused.
+func (ps *processService) IsProcessAlive(pid int, createTime int64) (bool, error) {
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to check process %d: %w", pid, err)
+	}
+
+	procCreateTime, err := proc.CreateTime()
+	if err != nil {
+		return false, nil
+	}
+
+	if procCreateTime != createTime {
+		return false, nil
+	}
+
+	// Processes started by the agent are not waited for, so one that exited
+	// remains as a zombie until the agent stops it.
+	status, err := proc.Status()
+	if err == nil && slices.Contains(status, process.Zombie) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// StopProcessByPID stops the process with pid if it is still the one created
+// at createTime.
+func (ps *processService) StopProcessByPID(pid int, createTime int64) error {
+	isAlive, err := ps.IsProcessAlive(pid, createTime)
+	if err != nil {
+		return err
+	}
+
+	if !isAlive {
+		return nil
+	}
+
+	if err := ps.terminateProcess(pid); err != nil {
+		ps.logger.Error("failed to terminate process", logger.Field{Key: "pid", Value: pid}, logger.Field{Key: "error", Value: err})
+		return fmt.Errorf("failed to stop process %d: %w", pid, err)
+	}
+
+	ps.logger.Info("process terminated", logger.Field{Key: "pid", Value: pid})
+	return nil
+}
+
 func (ps *processService) normalizeCommandLine(cmdLine string) string {
 	normalized := strings.ToLower(cmdLine)
 	normalized = strings.ReplaceAll(normalized, "/", "\\")
@@ -197,28 +322,31 @@ func (ps *processService) normalizePath(path string) (string, error) {
 	return normalized, nil
 }
 
-func (ps *processService) StartProcess(pathOfBinary string, startParams ...string) error {
+// StartProcess starts the binary at pathOfBinary and returns the started
+// process. Its PID and StartTime identify it for IsProcessAlive and
+// StopProcessByPID.
+func (ps *processService) StartProcess(pathOfBinary string, options ProcessStartOptions) (*ProcessInfo, error) {
 	normalizedPath, err := ps.normalizePath(pathOfBinary)
 	if err != nil {
-		return fmt.Errorf("failed to normalize path: %w", err)
+		return nil, fmt.Errorf("failed to normalize path: %w", err)
 	}
 
 	absPath, err := filepath.Abs(normalizedPath)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return fmt.Errorf("file not found: %s", absPath)
+		return nil, fmt.Errorf("file not found: %s", absPath)
 	}
 
-	isRunning, err := ps.IsProcessRunning(absPath)
+	running, err := ps.FindProcesses(absPath, options.Args)
 	if err != nil {
 		ps.logger.Warn("failed to check if process is running", logger.Field{Key: "error", Value: err})
 	}
 
-	if isRunning {
-		return fmt.Errorf("process is already running: %s", absPath)
+	if len(running) > 0 {
+		return nil, fmt.Errorf("process is already running: %s", absPath)
 	}
 
 	var cmd *exec.Cmd
@@ -228,30 +356,118 @@ func (ps *processService) StartProcess(pathOfBinary string, startParams ...strin
 		} else {
 			cmd = exec.Command("sh", absPath)
 		}
-		cmd.Args = append(cmd.Args, startParams...)
+		cmd.Args = append(cmd.Args, options.Args...)
 	} else {
 		if runtime.GOOS == "windows" {
 			normalizedPath = strings.TrimSuffix(normalizedPath, ".exe")
 			normalizedPath += ".exe"
 			absPath, err = filepath.Abs(normalizedPath)
 			if err != nil {
-				return fmt.Errorf("failed to get absolute path: %w", err)
+				return nil, fmt.Errorf("failed to get absolute path: %w", err)
 			}
 		}
-		cmd = exec.Command(absPath, startParams...)
+		cmd = exec.Command(absPath, options.Args...)
 	}
 
 	dir := filepath.Dir(absPath)
+	if options.Dir != "" {
+		dir = options.Dir
+	}
 	cmd.Dir = dir
 
-	if err := cmd.Start(); err != nil {
+	if len(options.Env) > 0 {
+		cmd.Env = append(os.Environ(), options.Env...)
+	}
+
+	outputs, err := ps.connectOutput(cmd, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	for _, output := range outputs {
+		// The child holds its own copy of the write end; closing ours lets
+		// the copy end when the process exits.
+		_ = output.writer.Close()
+		if err != nil {
+			_ = output.reader.Close()
+			continue
+		}
+
+		go ps.copyOutput(output.reader, output.dst)
+	}
+
+	if err != nil {
 		ps.logger.Error("failed to start process", logger.Field{Key: "path", Value: absPath}, logger.Field{Key: "error", Value: err})
-		return fmt.Errorf("failed to start process: %w", err)
+		return nil, fmt.Errorf("failed to start process: %w", err)
 	}
 
-	ps.logger.Info("process started", logger.Field{Key: "path", Value: absPath}, logger.Field{Key: "pid", Value: cmd.Process.Pid})
+	pid := cmd.Process.Pid
+	ps.logger.Info("process started", logger.Field{Key: "path", Value: absPath}, logger.Field{Key: "pid", Value: pid})
 
-	return nil
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return nil, fmt.Errorf("process %d exited right after starting: %w", pid, err)
+	}
+
+	createTime, err := proc.CreateTime()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get create time of process %d: %w", pid, err)
+	}
+
+	return &ProcessInfo{
+		PID:         pid,
+		Name:        filepath.Base(cmd.Path),
+		Path:        absPath,
+		CommandLine: strings.Join(cmd.Args, " "),
+		StartTime:   time.UnixMilli(createTime),
+	}, nil
+}
+
+type processOutput struct {
+	reader *os.File
+	writer *os.File
+	dst    io.Writer
+}
+
+// connectOutput gives the process a pipe for each requested output. The
+// pipes are read by copyOutput rather than by exec.Cmd, which would need
+// cmd.Wait and conflict with terminating the process by PID.
+func (ps *processService) connectOutput(cmd *exec.Cmd, options ProcessStartOptions) ([]processOutput, error) {
+	var outputs []processOutput
+	for _, target := range []struct {
+		dst io.Writer
+		set func(*os.File)
+	}{
+		{options.Stdout, func(f *os.File) { cmd.Stdout = f }},
+		{options.Stderr, func(f *os.File) { cmd.Stderr = f }},
+	} {
+		if target.dst == nil {
+			continue
+		}
+
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			for _, output := range outputs {
+				_ = output.reader.Close()
+				_ = output.writer.Close()
+			}
+			return nil, fmt.Errorf("failed to create output pipe: %w", err)
+		}
+
+		target.set(writer)
+		outputs = append(outputs, processOutput{reader: reader, writer: writer, dst: target.dst})
+	}
+
+	return outputs, nil
+}
+
+func (ps *processService) copyOutput(reader *os.File, dst io.Writer) {
+	defer reader.Close()
+
+	if _, err := io.Copy(dst, reader); err != nil {
+		ps.logger.Warn("failed to copy process output", logger.Field{Key: "error", Value: err})
+	}
 }
 
 func (ps *processService) StopProcess(pathOfBinary string) error {
@@ -453,34 +669,40 @@ func (ps *processService) WaitForProcess(path string, timeout, checkInterval tim
 	}
 }
 
-func (ps *processService) StartProcessWithHealthCheck(path string, port *int, timeout, checkInterval time.Duration, startParams ...string) error {
-	if err := ps.StartProcess(path, startParams...); err != nil {
-		return err
+// StartProcessWithHealthCheck starts the process like StartProcess and waits
+// until port accepts connections, or without a port, until the process has
+// kept running for checkInterval.
+func (ps *processService) StartProcessWithHealthCheck(path string, port *int, timeout, checkInterval time.Duration, options ProcessStartOptions) (*ProcessInfo, error) {
+	info, err := ps.StartProcess(path, options)
+	if err != nil {
+		return nil, err
 	}
 
 	if port != nil {
 		isReady, err := ps.WaitForPort("127.0.0.1", *port, timeout, checkInterval)
 		if err != nil {
-			return fmt.Errorf("process started but port check failed: %w", err)
+			return info, fmt.Errorf("process started but port check failed: %w", err)
 		}
 
 		if !isReady {
-			return fmt.Errorf("process started but port %d did not become available within timeout", *port)
+			return info, fmt.Errorf("process started but port %d did not become available within timeout", *port)
 		}
 
 		ps.logger.Info("process started and port is ready", logger.Field{Key: "path", Value: path}, logger.Field{Key: "port", Value: *port})
 	} else {
-		isReady, err := ps.WaitForProcess(path, timeout, checkInterval)
+		time.Sleep(min(checkInterval, timeout))
+
+		isAlive, err := ps.IsProcessAlive(info.PID, info.StartTime.UnixMilli())
 		if err != nil {
-			return fmt.Errorf("process started but health check failed: %w", err)
+			return info, fmt.Errorf("process started but health check failed: %w", err)
 		}
 
-		if !isReady {
-			return fmt.Errorf("process started but did not become available within timeout")
+		if !isAlive {
+			return info, fmt.Errorf("process exited right after starting")
 		}
 
-		ps.logger.Info("process started and is ready", logger.Field{Key: "path", Value: path})
+		ps.logger.Info("process started and is ready", logger.Field{Key: "path", Value: path}, logger.Field{Key: "pid", Value: info.PID})
 	}
 
-	return nil
+	return info, nil
 }
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+const (
+	ProcessEventCrash         = "crash"
+	ProcessEventRestart       = "restart"
+	ProcessEventRestartFailed = "restart_failed"
+	ProcessEventGaveUp        = "gave_up"
+)
+
+// supervisedProcess is the supervisor's state for a process that should be
+// running.
+type supervisedProcess struct {
+	// attempts counts the restarts since the process last ran for
+	// SupervisorResetAfterSeconds.
+	attempts    int
+	restartedAt time.Time
+	// crashedAt is set while the process is down and waiting to be restarted.
+	crashedAt     *time.Time
+	nextAttemptAt time.Time
+	// dependents were stopped for a restart that has not succeeded yet and
+	// are started again once it does, in dependency order.
+	dependents []int64
+}
+
+func (s *serverManagerService) Start() error {
+	s.adoptRunningProcesses()
+
+	if !s.cfg.SupervisorEnabled {
+		return nil
+	}
+
+	s.done = make(chan struct{})
+	s.wg.Add(1)
+	go s.supervise()
+
+	s.logger.Info(
+		"process supervisor started",
+		logger.Field{Key: "check_interval_seconds", Value: s.cfg.SupervisorCheckIntervalSeconds},
+		logger.Field{Key: "max_restart_attempts", Value: s.cfg.SupervisorMaxRestartAttempts},
+	)
+
+	return nil
+}
+
+func (s *serverManagerService) Stop() error {
+	if s.done == nil {
+		return nil
+	}
+
+	close(s.done)
+	s.wg.Wait()
+	s.done = nil
+
+	s.logger.Info("process supervisor stopped")
+	return nil
+}
+
+func (s *serverManagerService) supervise() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(s.cfg.SupervisorCheckIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.superviseProcesses(time.Now())
+		}
+	}
+}
+
+// isProcessExpectedRunning reports whether proc was started through the agent
+// and has not been stopped since. Only these processes are supervised.
+func isProcessExpectedRunning(proc *db.ServerProcess) bool {
+	return proc.StartTime != nil && (proc.EndTime == nil || proc.EndTime.Before(*proc.StartTime))
+}
+
+// restartBackoff returns how long to wait before restart attempt number
+// attempts+1: the configured backoff, doubled for every earlier attempt, up
+// to the configured maximum.
+func (s *serverManagerService) restartBackoff(attempts int) time.Duration {
+	backoff := time.Duration(s.cfg.SupervisorRestartBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(s.cfg.SupervisorMaxBackoffSeconds) * time.Second
+
+	for i := 0; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// superviseProcesses checks every process that should be running. A process
+// found not running is recorded as crashed and restarted once its backoff
+// has passed; after SupervisorMaxRestartAttempts failed restarts the
+// supervisor gives up and marks it stopped. The processes depending on it,
+// directly or through other processes, are stopped before the restart and
+// started again after it, in dependency order.
+//
+// The restart is decided under mu but carried out without it, so that its
+// health checks do not hold up requests; requests to start or stop the
+// processes it involves fail until it is done.
+func (s *serverManagerService) superviseProcesses(now time.Time) {
+	graph, proc, state := s.findProcessToRestart(now)
+	if proc == nil {
+		return
+	}
+
+	s.restartCrashedProcess(graph, proc, state)
+}
+
+// findProcessToRestart updates the supervision state of every process and
+// returns the first crashed process due for a restart, marking it and its
+// dependents as restarting. It returns a nil process when there is none.
+func (s *serverManagerService) findProcessToRestart(now time.Time) (*processGraph, *db.ServerProcess, *supervisedProcess) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	processes, err := s.db.GetServerProcesses()
+	if err != nil {
+		s.logger.Error("failed to get server processes for supervision", logger.Field{Key: "error", Value: err})
+		return nil, nil, nil
+	}
+
+	graph, err := newProcessGraph(processes)
+	if err != nil {
+		s.logger.Error("invalid process dependencies for supervision", logger.Field{Key: "error", Value: err})
+		return nil, nil, nil
+	}
+
+	expected := make(map[int64]bool, len(processes))
+	for i := range processes {
+		proc := &processes[i]
+		if !isProcessExpectedRunning(proc) {
+			continue
+		}
+
+		expected[proc.ID] = true
+		state, ok := s.supervised[proc.ID]
+		if !ok {
+			state = &supervisedProcess{}
+			s.supervised[proc.ID] = state
+		}
+
+		isRunning, err := s.isProcessRunning(proc)
+		if err != nil {
+			s.logger.Warn("failed to check supervised process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
+			continue
+		}
+
+		if isRunning {
+			state.crashedAt = nil
+			state.dependents = nil
+			if state.attempts > 0 && now.Sub(state.restartedAt) >= time.Duration(s.cfg.SupervisorResetAfterSeconds)*time.Second {
+				state.attempts = 0
+			}
+			continue
+		}
+
+		if state.crashedAt == nil {
+			state.crashedAt = &now
+			state.nextAttemptAt = now.Add(s.restartBackoff(state.attempts))
+			s.logger.Error("supervised process is not running", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID})
+			s.recordProcessEvent(proc.ID, ProcessEventCrash, state.attempts, "process exited unexpectedly")
+		}
+
+		if now.Before(state.nextAttemptAt) {
+			continue
+		}
+
+		if state.attempts >= s.cfg.SupervisorMaxRestartAttempts {
+			s.logger.Error("giving up restarting process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "attempts", Value: state.attempts})
+			s.recordProcessEvent(proc.ID, ProcessEventGaveUp, state.attempts, fmt.Sprintf("not restarted after %d attempts", state.attempts))
+
+			if err := s.db.UpdateProcessEndTime(proc.ID, now); err != nil {
+				s.logger.Warn("failed to update process end time", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+			}
+			delete(s.supervised, proc.ID)
+			delete(expected, proc.ID)
+			continue
+		}
+
+		// Only one process is restarted per pass; the processes depending
+		// on it are dealt with by the restart, and the list no longer
+		// reflects their state.
+		state.attempts++
+		s.restarting[proc.ID] = true
+		for _, id := range graph.dependentsOf(proc.ID) {
+			s.restarting[id] = true
+		}
+
+		return graph, proc, state
+	}
+
+	for id := range s.supervised {
+		if !expected[id] {
+			delete(s.supervised, id)
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// restartCrashedProcess runs without mu; findProcessToRestart marked the
+// processes it involves as restarting, which keeps requests away from them.
+func (s *serverManagerService) restartCrashedProcess(graph *processGraph, proc *db.ServerProcess, state *supervisedProcess) {
+	dependents := graph.dependentsOf(proc.ID)
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.restarting, proc.ID)
+		for _, id := range dependents {
+			delete(s.restarting, id)
+		}
+	}()
+
+	stopped := make(map[int64]bool, len(dependents))
+	for i := len(dependents) - 1; i >= 0; i-- {
+		dependent := graph.processes[dependents[i]]
+		if !isProcessExpectedRunning(dependent) {
+			continue
+		}
+
+		if err := s.stopProcessInternal(dependent); err != nil {
+			s.logger.Error("failed to stop dependent process", logger.Field{Key: "name", Value: dependent.Name}, logger.Field{Key: "error", Value: err})
+			continue
+		}
+
+		stopped[dependent.ID] = true
+	}
+
+	for _, id := range dependents {
+		if stopped[id] {
+			state.dependents = append(state.dependents, id)
+		}
+	}
+
+	s.logger.Info("restarting crashed process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "attempt", Value: state.attempts})
+
+	if err := s.startProcessInternal(proc); err != nil {
+		s.logger.Error("failed to restart process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
+		s.recordProcessEvent(proc.ID, ProcessEventRestartFailed, state.attempts, err.Error())
+		state.nextAttemptAt = time.Now().Add(s.restartBackoff(state.attempts))
+		return
+	}
+
+	s.recordProcessEvent(proc.ID, ProcessEventRestart, state.attempts, "")
+	state.crashedAt = nil
+	state.restartedAt = time.Now()
+
+	stoppedDependents := state.dependents
+	state.dependents = nil
+	s.startDependents(proc, stoppedDependents)
+}
+
+// checkNotRestarting returns an error for a process that the supervisor is
+// restarting, or that is stopped and started again by such a restart. It must
+// be called with mu held.
+func (s *serverManagerService) checkNotRestarting(proc *db.ServerProcess) error {
+	if s.restarting[proc.ID] {
+		return fmt.Errorf("%s %w", proc.Name, errProcessRestarting)
+	}
+
+	return nil
+}
+
+// startDependents starts the processes in ids, which are in dependency
+// order, after proc has been restarted.
+func (s *serverManagerService) startDependents(proc *db.ServerProcess, ids []int64) {
+	for _, id := range ids {
+		dependent, err := s.db.GetServerProcess(id)
+		if err != nil {
+			s.logger.Error("failed to get dependent process", logger.Field{Key: "id", Value: id}, logger.Field{Key: "error", Value: err})
+			continue
+		}
+
+		message := "restarted after " + proc.Name
+		if err := s.startProcessInternal(dependent); err != nil {
+			s.logger.Error("failed to restart dependent process", logger.Field{Key: "name", Value: dependent.Name}, logger.Field{Key: "error", Value: err})
+			s.recordProcessEvent(dependent.ID, ProcessEventRestartFailed, 0, message+": "+err.Error())
+			continue
+		}
+
+		s.recordProcessEvent(dependent.ID, ProcessEventRestart, 0, message)
+	}
+}
+
+func (s *serverManagerService) recordProcessEvent(processID int64, eventType string, attempt int, message string) {
+	if err := s.db.CreateServerProcessEvent(processID, eventType, attempt, message); err != nil {
+		s.logger.Warn("failed to record process event", logger.Field{Key: "id", Value: processID}, logger.Field{Key: "event_type", Value: eventType}, logger.Field{Key: "error", Value: err})
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSupervisorTestManager(t *testing.T, cfg *config.EnvVars) (*serverManagerService, *db.MockInternalDB, *MockProcessService) {
+	log := logger.NewMockLogger(t)
+	log.EXPECT().Info(mock.Anything, mock.Anything).Return().Maybe()
+	log.EXPECT().Warn(mock.Anything, mock.Anything).Return().Maybe()
+	log.EXPECT().Error(mock.Anything, mock.Anything).Return().Maybe()
+
+	internalDB := db.NewMockInternalDB(t)
+	processService := NewMockProcessService(t)
+
+	processLogs := NewProcessLogService(&config.EnvVars{ProcessLogDirectory: t.TempDir(), ProcessLogBufferLines: 10}, log)
+
+	return NewServerManagerService(cfg, internalDB, processService, processLogs, log).(*serverManagerService), internalDB, processService
+}
+
+func TestRestartBackoff(t *testing.T) {
+	s, _, _ := newSupervisorTestManager(t, &config.EnvVars{
+		SupervisorRestartBackoffSeconds: 5,
+		SupervisorMaxBackoffSeconds:     60,
+	})
+
+	assert.Equal(t, 5*time.Second, s.restartBackoff(0))
+	assert.Equal(t, 10*time.Second, s.restartBackoff(1))
+	assert.Equal(t, 40*time.Second, s.restartBackoff(3))
+	assert.Equal(t, 60*time.Second, s.restartBackoff(4))
+	assert.Equal(t, 60*time.Second, s.restartBackoff(50))
+}
+
+func TestSuperviseProcessesRestartsCrashedProcessAndDependents(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{
+		SupervisorMaxBackoffSeconds:  60,
+		SupervisorMaxRestartAttempts: 3,
+	})
+
+	now := time.Now()
+	startedAt := now.Add(-time.Hour)
+	accountServer := db.ServerProcess{ID: 1, Name: "AccountServer", Path: "account.exe", SequenceOrder: 1, StartTime: &startedAt, PID: intPtr(100), PIDCreateTime: int64Ptr(1000)}
+	zoneServer := db.ServerProcess{ID: 2, Name: "ZoneServer", Path: "zone.exe", SequenceOrder: 2, DependsOn: []int64{1}, StartTime: &startedAt, PID: intPtr(200), PIDCreateTime: int64Ptr(2000)}
+	stoppedServer := db.ServerProcess{ID: 3, Name: "BattleServer", Path: "battle.exe", SequenceOrder: 3, DependsOn: []int64{2}}
+	independentServer := db.ServerProcess{ID: 4, Name: "DBAgent", Path: "dbagent.exe", SequenceOrder: 4, StartTime: &startedAt, PID: intPtr(400), PIDCreateTime: int64Ptr(4000)}
+
+	internalDB.EXPECT().GetServerProcesses().Return([]db.ServerProcess{accountServer, zoneServer, stoppedServer, independentServer}, nil).Once()
+	processService.EXPECT().IsProcessAlive(100, int64(1000)).Return(false, nil).Twice()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(1), ProcessEventCrash, 0, "process exited unexpectedly").Return(nil).Once()
+
+	processService.EXPECT().IsProcessAlive(200, int64(2000)).Return(true, nil).Once()
+	processService.EXPECT().StopProcessByPID(200, int64(2000)).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(2), (*int)(nil), (*int64)(nil)).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessEndTime(int64(2), mock.Anything).Return(nil).Once()
+
+	processService.EXPECT().StartProcessWithHealthCheck("account.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(&ProcessInfo{PID: 101, StartTime: now}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(1), intPtr(101), int64Ptr(now.UnixMilli())).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(1), mock.Anything).Return(nil).Once()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(1), ProcessEventRestart, 1, "").Return(nil).Once()
+
+	stoppedZoneServer := zoneServer
+	stoppedZoneServer.PID = nil
+	stoppedZoneServer.PIDCreateTime = nil
+	internalDB.EXPECT().GetServerProcess(int64(2)).Return(&stoppedZoneServer, nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("zone.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(&ProcessInfo{PID: 201, StartTime: now}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(2), intPtr(201), int64Ptr(now.UnixMilli())).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(2), mock.Anything).Return(nil).Once()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventRestart, 0, "restarted after AccountServer").Return(nil).Once()
+
+	s.superviseProcesses(now)
+
+	assert.Equal(t, 1, s.supervised[1].attempts)
+	assert.Nil(t, s.supervised[1].crashedAt)
+}
+
+func TestSuperviseProcessesGivesUpAfterMaxAttempts(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{
+		SupervisorMaxBackoffSeconds:  60,
+		SupervisorMaxRestartAttempts: 1,
+	})
+
+	now := time.Now()
+	startedAt := now.Add(-time.Hour)
+	zoneServer := db.ServerProcess{ID: 2, Name: "ZoneServer", Path: "zone.exe", SequenceOrder: 1, StartTime: &startedAt, PID: intPtr(200), PIDCreateTime: int64Ptr(2000)}
+
+	internalDB.EXPECT().GetServerProcesses().Return([]db.ServerProcess{zoneServer}, nil).Twice()
+	processService.EXPECT().IsProcessAlive(200, int64(2000)).Return(false, nil).Times(3)
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventCrash, 0, "process exited unexpectedly").Return(nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("zone.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("port 9000 not ready")).Once()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventRestartFailed, 1, "failed to start process: port 9000 not ready").Return(nil).Once()
+
+	s.superviseProcesses(now)
+
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventGaveUp, 1, "not restarted after 1 attempts").Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessEndTime(int64(2), mock.Anything).Return(nil).Once()
+
+	s.superviseProcesses(now.Add(time.Second))
+
+	assert.NotContains(t, s.supervised, int64(2))
+}
+
+func TestSuperviseProcessesRestartsWithoutBlockingRequests(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{
+		SupervisorMaxBackoffSeconds:  60,
+		SupervisorMaxRestartAttempts: 3,
+	})
+
+	now := time.Now()
+	startedAt := now.Add(-time.Hour)
+	zoneServer := db.ServerProcess{ID: 2, Name: "ZoneServer", Path: "zone.exe", SequenceOrder: 1, StartTime: &startedAt, PID: intPtr(200), PIDCreateTime: int64Ptr(2000)}
+	dbAgent := db.ServerProcess{ID: 4, Name: "DBAgent", Path: "dbagent.exe", SequenceOrder: 2}
+
+	internalDB.EXPECT().GetServerProcesses().Return([]db.ServerProcess{zoneServer, dbAgent}, nil).Once()
+	processService.EXPECT().IsProcessAlive(200, int64(2000)).Return(false, nil).Twice()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventCrash, 0, "process exited unexpectedly").Return(nil).Once()
+
+	// The restart waits in its health check until the requests below are
+	// done, which only happens if they do not wait for the restart.
+	healthCheck := make(chan struct{})
+	restarted := make(chan struct{})
+	processService.EXPECT().StartProcessWithHealthCheck("zone.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).
+		Run(func(string, *int, time.Duration, time.Duration, ProcessStartOptions) { <-healthCheck }).
+		Return(nil, errors.New("port 9000 not ready")).Once()
+	internalDB.EXPECT().CreateServerProcessEvent(int64(2), ProcessEventRestartFailed, 1, "failed to start process: port 9000 not ready").Return(nil).Once()
+
+	go func() {
+		defer close(restarted)
+		s.superviseProcesses(now)
+	}()
+
+	internalDB.EXPECT().GetServerProcess(int64(2)).Return(&zoneServer, nil).Once()
+	internalDB.EXPECT().GetServerProcess(int64(4)).Return(&dbAgent, nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("dbagent.exe", (*int)(nil), mock.Anything, mock.Anything, mock.Anything).Return(&ProcessInfo{PID: 400, StartTime: now}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(4), intPtr(400), int64Ptr(now.UnixMilli())).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(4), mock.Anything).Return(nil).Once()
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.restarting[2]
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.ErrorIs(t, s.StopProcess(2), errProcessRestarting)
+	assert.NoError(t, s.StartProcess(4))
+
+	close(healthCheck)
+	<-restarted
+
+	assert.Empty(t, s.restarting)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+)
+
+// isProcessRunning reports whether the OS process recorded for proc is still
+// running. It only looks at that one process, so a process without a
+// recorded PID is reported as not running.
+func (s *serverManagerService) isProcessRunning(proc *db.ServerProcess) (bool, error) {
+	if proc.PID == nil || proc.PIDCreateTime == nil {
+		return false, nil
+	}
+
+	return s.processService.IsProcessAlive(*proc.PID, *proc.PIDCreateTime)
+}
+
+func (s *serverManagerService) recordProcessPID(proc *db.ServerProcess, info *ProcessInfo) {
+	createTime := info.StartTime.UnixMilli()
+	proc.PID = &info.PID
+	proc.PIDCreateTime = &createTime
+
+	if err := s.db.UpdateProcessPID(proc.ID, proc.PID, proc.PIDCreateTime); err != nil {
+		s.logger.Warn("failed to update process pid", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+	}
+}
+
+// adoptProcess looks for a process started outside the agent by comparing
+// the path and arguments of all running processes with proc's, skipping
+// processes already recorded for another server process. A match is recorded
+// as proc's process, with its start time, and true is returned.
+func (s *serverManagerService) adoptProcess(proc *db.ServerProcess) bool {
+	s.adoptMu.Lock()
+	defer s.adoptMu.Unlock()
+
+	candidates, err := s.processService.FindProcesses(proc.Path, proc.Args)
+	if err != nil {
+		s.logger.Warn("failed to look for process to adopt", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+		return false
+	}
+
+	if len(candidates) == 0 {
+		return false
+	}
+
+	processes, err := s.db.GetServerProcesses()
+	if err != nil {
+		s.logger.Warn("failed to get server processes", logger.Field{Key: "error", Value: err})
+		return false
+	}
+
+	owned := make(map[int]bool, len(processes))
+	for _, other := range processes {
+		if other.ID != proc.ID && other.PID != nil {
+			owned[*other.PID] = true
+		}
+	}
+
+	for i := range candidates {
+		info := &candidates[i]
+		if owned[info.PID] {
+			continue
+		}
+
+		s.recordProcessPID(proc, info)
+		proc.StartTime = &info.StartTime
+		if err := s.db.UpdateProcessStartTime(proc.ID, info.StartTime); err != nil {
+			s.logger.Warn("failed to update process start time", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+		}
+
+		s.logger.Info("adopted running process", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "pid", Value: info.PID})
+		return true
+	}
+
+	return false
+}
+
+// adoptRunningProcesses brings the recorded PIDs up to date when the agent
+// starts: PIDs of processes that have exited are cleared, and processes
+// without a running PID are adopted if they were started outside the agent
+// or before it tracked PIDs.
+func (s *serverManagerService) adoptRunningProcesses() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	processes, err := s.db.GetServerProcesses()
+	if err != nil {
+		s.logger.Error("failed to get server processes to adopt", logger.Field{Key: "error", Value: err})
+		return
+	}
+
+	for i := range processes {
+		proc := &processes[i]
+		isRunning, err := s.isProcessRunning(proc)
+		if err != nil {
+			s.logger.Warn("failed to check if process is running", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+			continue
+		}
+
+		if isRunning {
+			continue
+		}
+
+		if proc.PID != nil {
+			proc.PID = nil
+			proc.PIDCreateTime = nil
+			if err := s.db.UpdateProcessPID(proc.ID, nil, nil); err != nil {
+				s.logger.Warn("failed to clear process pid", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+			}
+		}
+
+		s.adoptProcess(proc)
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdoptRunningProcesses(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	createdAt := time.UnixMilli(time.Now().Add(-time.Hour).UnixMilli())
+	zone1 := db.ServerProcess{ID: 1, Name: "Zone 1", Path: "zone.exe", PID: intPtr(300), PIDCreateTime: int64Ptr(3000)}
+	zone1.Args = db.ProcessArgs{"-zone", "1"}
+	zone2 := db.ServerProcess{ID: 2, Name: "Zone 2", Path: "zone.exe", PID: intPtr(400), PIDCreateTime: int64Ptr(4000)}
+	zone2.Args = db.ProcessArgs{"-zone", "2"}
+	account := db.ServerProcess{ID: 3, Name: "AccountServer", Path: "account.exe"}
+
+	internalDB.EXPECT().GetServerProcesses().Return([]db.ServerProcess{zone1, zone2, account}, nil)
+
+	processService.EXPECT().IsProcessAlive(300, int64(3000)).Return(false, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(1), (*int)(nil), (*int64)(nil)).Return(nil).Once()
+	processService.EXPECT().FindProcesses("zone.exe", []string{"-zone", "1"}).Return([]ProcessInfo{
+		{PID: 400, StartTime: createdAt},
+		{PID: 401, StartTime: createdAt},
+	}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(1), intPtr(401), int64Ptr(createdAt.UnixMilli())).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(1), createdAt).Return(nil).Once()
+
+	processService.EXPECT().IsProcessAlive(400, int64(4000)).Return(true, nil).Once()
+
+	processService.EXPECT().FindProcesses("account.exe", []string(nil)).Return(nil, nil).Once()
+
+	s.adoptRunningProcesses()
+}
+
+func TestGetProcessStatusChecksRecordedPID(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	startedAt := time.Now().Add(-time.Minute)
+	zone := &db.ServerProcess{ID: 2, Name: "ZoneServer", Path: "zone.exe", StartTime: &startedAt, PID: intPtr(200), PIDCreateTime: int64Ptr(2000)}
+
+	internalDB.EXPECT().GetServerProcess(int64(2)).Return(zone, nil).Once()
+	processService.EXPECT().IsProcessAlive(200, int64(2000)).Return(true, nil).Once()
+
+	status, err := s.GetProcessStatus(2)
+	assert.NoError(t, err)
+	assert.True(t, status.Running)
+	assert.Equal(t, intPtr(200), status.PID)
+	assert.NotNil(t, status.CurrentUptimeSeconds)
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	RevisionProblemMissingCopy   = "missing_copy"
+	RevisionProblemUnreadable    = "unreadable_copy"
+	RevisionProblemCorruptedCopy = "corrupted_copy"
+	RevisionProblemHashMismatch  = "hash_mismatch"
+	RevisionProblemOrphanedDraft = "orphaned_draft"
+
+	RevisionActionMarkedCorrupted = "marked_corrupted"
+	RevisionActionMarkedOrphaned  = "marked_orphaned"
+	RevisionActionRepaired        = "repaired"
+)
+
+// ErrRevisionVerificationRunning is returned by Verify while another
+// verification is in progress.
+var ErrRevisionVerificationRunning = errors.New("revision verification is already running")
+
+type RevisionIntegrityService interface {
+	Start() error
+	Stop() error
+	Verify() (*RevisionIntegrityReport, error)
+	LastReport() *RevisionIntegrityReport
+}
+
+type RevisionIntegrityReport struct {
+	StartedAt          time.Time                `json:"started_at"`
+	FinishedAt         time.Time                `json:"finished_at"`
+	CheckedRevisions   int                      `json:"checked_revisions"`
+	HealthyRevisions   int                      `json:"healthy_revisions"`
+	Issues             []RevisionIntegrityIssue `json:"issues"`
+	RemovedOrphanFiles int                      `json:"removed_orphan_files"`
+	RemovedOrphanDirs  int                      `json:"removed_orphan_directories"`
+}
+
+type RevisionIntegrityIssue struct {
+	RevisionID   int64  `json:"revision_id"`
+	FileID       string `json:"file_id"`
+	OriginalPath string `json:"original_path"`
+	RevisionPath string `json:"revision_path,omitempty"`
+	Problem      string `json:"problem"`
+	Detail       string `json:"detail,omitempty"`
+	Action       string `json:"action"`
+}
+
+type revisionIntegrityService struct {
+	cfg           *config.EnvVars
+	logger        logger.Logger
+	internalDB    db.InternalDB
+	revisionStore RevisionStoreService
+	cron          *cron.Cron
+	runningMu     sync.Mutex
+	reportMu      sync.RWMutex
+	lastReport    *RevisionIntegrityReport
+}
+
+func NewRevisionIntegrityService(
+	cfg *config.EnvVars,
+	logger logger.Logger,
+	internalDB db.InternalDB,
+	revisionStore RevisionStoreService,
+) RevisionIntegrityService {
+	return &revisionIntegrityService{
+		cfg:           cfg,
+		logger:        logger,
+		internalDB:    internalDB,
+		revisionStore: revisionStore,
+	}
+}
+
+func (ri *revisionIntegrityService) Start() error {
+	ri.cron = cron.New(cron.WithSeconds())
+	_, err := ri.cron.AddFunc(ri.cfg.RevisionIntegritySchedule, func() {
+		if _, err := ri.Verify(); err != nil {
+			ri.logger.Error("failed to verify revisions", logger.Field{Key: "error", Value: err})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule revision verification: %w", err)
+	}
+
+	ri.cron.Start()
+
+	ri.logger.Info(
+		"revision integrity service started",
+		logger.Field{Key: "schedule", Value: ri.cfg.RevisionIntegritySchedule},
+	)
+
+	return nil
+}
+
+func (ri *revisionIntegrityService) Stop() error {
+	if ri.cron != nil {
+		<-ri.cron.Stop().Done()
+	}
+
+	ri.logger.Info("revision integrity service stopped")
+	return nil
+}
+
+func (ri *revisionIntegrityService) LastReport() *RevisionIntegrityReport {
+	ri.reportMu.RLock()
+	defer ri.reportMu.RUnlock()
+
+	return ri.lastReport
+}
+
+// Verify checks every stored revision copy against the hash recorded for it,
+// marks broken and orphaned revisions, and removes files and directories in
+// the revisions directory that no revision refers to.
+func (ri *revisionIntegrityService) Verify() (*RevisionIntegrityReport, error) {
+	if !ri.runningMu.TryLock() {
+		return nil, ErrRevisionVerificationRunning
+	}
+	defer ri.runningMu.Unlock()
+
+	report := &RevisionIntegrityReport{
+		StartedAt: time.Now().UTC(),
+		Issues:    []RevisionIntegrityIssue{},
+	}
+
+	revisions, err := ri.internalDB.GetFileRevisionsByStatus([]string{"draft", "completed", "reverted"})
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects are shared by revisions with the same content, so each one is
+	// only read once.
+	checked := make(map[string]copyCheck)
+	for _, revision := range revisions {
+		report.CheckedRevisions++
+
+		issue, err := ri.verifyRevision(revision, checked)
+		if err != nil {
+			return nil, err
+		}
+
+		if issue == nil {
+			report.HealthyRevisions++
+			continue
+		}
+
+		report.Issues = append(report.Issues, *issue)
+	}
+
+	referenced, err := ri.referencedRevisionPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	report.RemovedOrphanFiles, report.RemovedOrphanDirs, err = ri.removeOrphans(referenced)
+	if err != nil {
+		return nil, err
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	ri.reportMu.Lock()
+	ri.lastReport = report
+	ri.reportMu.Unlock()
+
+	ri.logger.Info(
+		"verified revisions",
+		logger.Field{Key: "checked_revisions", Value: report.CheckedRevisions},
+		logger.Field{Key: "issues", Value: len(report.Issues)},
+		logger.Field{Key: "removed_orphan_files", Value: report.RemovedOrphanFiles},
+		logger.Field{Key: "removed_orphan_directories", Value: report.RemovedOrphanDirs},
+	)
+
+	return report, nil
+}
+
+type copyCheck struct {
+	problem string
+	detail  string
+}
+
+func (ri *revisionIntegrityService) verifyRevision(revision db.FileRevision, checked map[string]copyCheck) (*RevisionIntegrityIssue, error) {
+	issue := &RevisionIntegrityIssue{
+		RevisionID:   revision.ID,
+		FileID:       revision.FileID,
+		OriginalPath: revision.OriginalPath,
+		RevisionPath: revision.RevisionPath,
+	}
+
+	// Drafts are only written inside the transaction that completes them, so
+	// any draft that is visible was left behind by a failed save.
+	if revision.Status == "draft" {
+		issue.Problem = RevisionProblemOrphanedDraft
+		issue.Action = RevisionActionMarkedOrphaned
+		if err := ri.internalDB.SetFileRevisionStatus(revision.ID, "orphaned"); err != nil {
+			return nil, err
+		}
+
+		return issue, nil
+	}
+
+	key := revision.RevisionPath + "\x00" + revision.PreviousHash
+	check, ok := checked[key]
+	if !ok {
+		check.problem, check.detail = VerifyRevisionCopy(ri.revisionStore, revision.RevisionPath, revision.PreviousHash)
+		checked[key] = check
+	}
+
+	if check.problem == "" {
+		return nil, nil
+	}
+
+	issue.Problem = check.problem
+	issue.Detail = check.detail
+
+	// A copy with the expected content may still be in the store, for example
+	// when the same content was saved again by a later revision.
+	if objectPath, found := ri.revisionStore.Find(revision.PreviousHash); found && objectPath != revision.RevisionPath {
+		if problem, _ := VerifyRevisionCopy(ri.revisionStore, objectPath, revision.PreviousHash); problem == "" {
+			if err := ri.internalDB.SetFileRevisionPath(revision.ID, objectPath); err != nil {
+				return nil, err
+			}
+
+			issue.Action = RevisionActionRepaired
+			return issue, nil
+		}
+	}
+
+	if err := ri.internalDB.SetFileRevisionStatus(revision.ID, "corrupted"); err != nil {
+		return nil, err
+	}
+
+	issue.Action = RevisionActionMarkedCorrupted
+	return issue, nil
+}
+
+// VerifyRevisionCopy reads the copy at path and returns the problem found with
+// it, or an empty string if its content matches expectedHash.
+func VerifyRevisionCopy(store RevisionStoreService, path, expectedHash string) (string, string) {
+	if path == "" {
+		return RevisionProblemMissingCopy, "revision has no stored copy"
+	}
+
+	data, err := store.Get(path)
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return RevisionProblemMissingCopy, "stored copy does not exist"
+		case errors.Is(err, ErrRevisionCorrupted):
+			return RevisionProblemCorruptedCopy, err.Error()
+		default:
+			return RevisionProblemUnreadable, err.Error()
+		}
+	}
+
+	if hash := utils.CalculateFileHash(data); hash != expectedHash {
+		return RevisionProblemHashMismatch, fmt.Sprintf("expected hash %s, got %s", expectedHash, hash)
+	}
+
+	return "", ""
+}
+
+func (ri *revisionIntegrityService) referencedRevisionPaths() (map[string]bool, error) {
+	revisions, err := ri.internalDB.GetStoredFileRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(revisions))
+	for _, revision := range revisions {
+		referenced[filepath.Clean(revision.RevisionPath)] = true
+	}
+
+	return referenced, nil
+}
+
+// removeOrphans deletes legacy revision copies and interrupted object writes
+// that no revision refers to, then any directories left empty. Unreferenced
+// objects are left to retention, which applies the same grace period.
+func (ri *revisionIntegrityService) removeOrphans(referenced map[string]bool) (int, int, error) {
+	root := filepath.Clean(ri.cfg.RevisionsDirectory)
+	locksDir := filepath.Join(root, "locks")
+	objectsDir := filepath.Clean(ri.revisionStore.ObjectsDirectory())
+
+	removedFiles := 0
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() {
+			if path == locksDir {
+				return filepath.SkipDir
+			}
+
+			if path != root && path != objectsDir {
+				dirs = append(dirs, path)
+			}
+
+			return nil
+		}
+
+		if referenced[filepath.Clean(path)] {
+			return nil
+		}
+
+		inObjects := strings.HasPrefix(path, objectsDir+string(filepath.Separator))
+		if inObjects && !strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < unreferencedObjectGracePeriod {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			ri.logger.Error("failed to remove orphaned revision file", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+			return nil
+		}
+
+		removedFiles++
+		return nil
+	})
+	if err != nil {
+		return removedFiles, 0, fmt.Errorf("failed to scan revisions directory: %w", err)
+	}
+
+	// Deepest directories first, so that parents emptied by removing their
+	// children are removed too.
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+
+	removedDirs := 0
+	for _, dir := range dirs {
+		if os.Remove(dir) == nil {
+			removedDirs++
+		}
+	}
+
+	return removedFiles, removedDirs, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRevisionCopy(t *testing.T) {
+	rs := NewRevisionStoreService(&config.EnvVars{RevisionsDirectory: t.TempDir(), RevisionCompression: RevisionCompressionNone}, nil)
+
+	path, err := rs.Put([]byte("original"))
+	require.NoError(t, err)
+
+	problem, _ := VerifyRevisionCopy(rs, path, utils.CalculateFileHash([]byte("original")))
+	assert.Empty(t, problem)
+
+	problem, _ = VerifyRevisionCopy(rs, path, utils.CalculateFileHash([]byte("other")))
+	assert.Equal(t, RevisionProblemHashMismatch, problem)
+
+	problem, _ = VerifyRevisionCopy(rs, "", "")
+	assert.Equal(t, RevisionProblemMissingCopy, problem)
+
+	problem, _ = VerifyRevisionCopy(rs, filepath.Join(rs.ObjectsDirectory(), "ab", "abcdef"), "abcdef")
+	assert.Equal(t, RevisionProblemMissingCopy, problem)
+
+	require.NoError(t, os.WriteFile(path, []byte("tampered"), 0644))
+	problem, _ = VerifyRevisionCopy(rs, path, utils.CalculateFileHash([]byte("original")))
+	assert.Equal(t, RevisionProblemCorruptedCopy, problem)
+}
+
+func TestRevisionIntegrityVerify(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.EnvVars{RevisionsDirectory: dir, RevisionCompression: RevisionCompressionZstd}
+	rs := NewRevisionStoreService(cfg, nil)
+
+	healthyPath, err := rs.Put([]byte("healthy"))
+	require.NoError(t, err)
+
+	movedPath, err := rs.Put([]byte("moved"))
+	require.NoError(t, err)
+	missingLegacyPath := filepath.Join(dir, "file-b", "2", "1700000000_b.txt")
+
+	brokenPath := filepath.Join(dir, "file-c", "3", "1700000000_c.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(brokenPath), 0755))
+	require.NoError(t, os.WriteFile(brokenPath, []byte("changed"), 0644))
+
+	orphanPath := filepath.Join(dir, "file-e", "9", "1700000000_e.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(orphanPath), 0755))
+	require.NoError(t, os.WriteFile(orphanPath, []byte("orphan"), 0644))
+	old := time.Now().Add(-2 * unreferencedObjectGracePeriod)
+	require.NoError(t, os.Chtimes(orphanPath, old, old))
+
+	revisions := []db.FileRevision{
+		{ID: 1, FileID: "file-a", RevisionPath: healthyPath, PreviousHash: utils.CalculateFileHash([]byte("healthy")), Status: "completed"},
+		{ID: 2, FileID: "file-b", RevisionPath: missingLegacyPath, PreviousHash: utils.CalculateFileHash([]byte("moved")), Status: "reverted"},
+		{ID: 3, FileID: "file-c", RevisionPath: brokenPath, PreviousHash: utils.CalculateFileHash([]byte("original")), Status: "completed"},
+		{ID: 4, FileID: "file-d", Status: "draft"},
+	}
+
+	internalDB := db.NewMockInternalDB(t)
+	internalDB.EXPECT().GetFileRevisionsByStatus([]string{"draft", "completed", "reverted"}).Return(revisions, nil)
+	internalDB.EXPECT().SetFileRevisionPath(int64(2), movedPath).Return(nil)
+	internalDB.EXPECT().SetFileRevisionStatus(int64(3), "corrupted").Return(nil)
+	internalDB.EXPECT().SetFileRevisionStatus(int64(4), "orphaned").Return(nil)
+	internalDB.EXPECT().GetStoredFileRevisions().Return([]db.FileRevision{
+		{ID: 1, RevisionPath: healthyPath},
+		{ID: 2, RevisionPath: movedPath},
+		{ID: 3, RevisionPath: brokenPath},
+	}, nil)
+
+	log := logger.NewMockLogger(t)
+	log.EXPECT().Info("verified revisions", mock.Anything).Return()
+
+	ri := NewRevisionIntegrityService(cfg, log, internalDB, rs)
+	report, err := ri.Verify()
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.CheckedRevisions)
+	assert.Equal(t, 1, report.HealthyRevisions)
+	require.Len(t, report.Issues, 3)
+	assert.Equal(t, RevisionActionRepaired, report.Issues[0].Action)
+	assert.Equal(t, RevisionProblemMissingCopy, report.Issues[0].Problem)
+	assert.Equal(t, RevisionActionMarkedCorrupted, report.Issues[1].Action)
+	assert.Equal(t, RevisionProblemHashMismatch, report.Issues[1].Problem)
+	assert.Equal(t, RevisionActionMarkedOrphaned, report.Issues[2].Action)
+	assert.Equal(t, 1, report.RemovedOrphanFiles)
+	assert.Same(t, report, ri.LastReport())
+
+	assert.NoFileExists(t, orphanPath)
+	assert.NoDirExists(t, filepath.Join(dir, "file-e"))
+	assert.FileExists(t, brokenPath)
+	assert.FileExists(t, healthyPath)
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/robfig/cron/v3"
+)
+
+// unreferencedObjectGracePeriod protects objects written by saves whose
+// revision has not been committed yet from being collected.
+const unreferencedObjectGracePeriod = time.Hour
+
+type RevisionRetentionService interface {
+	Start() error
+	Stop() error
+	MigrateLegacyRevisions() error
+	ApplyRetention() error
+}
+
+type revisionRetentionService struct {
+	cfg           *config.EnvVars
+	logger        logger.Logger
+	internalDB    db.InternalDB
+	revisionStore RevisionStoreService
+	cron          *cron.Cron
+}
+
+func NewRevisionRetentionService(
+	cfg *config.EnvVars,
+	logger logger.Logger,
+	internalDB db.InternalDB,
+	revisionStore RevisionStoreService,
+) RevisionRetentionService {
+	return &revisionRetentionService{
+		cfg:           cfg,
+		logger:        logger,
+		internalDB:    internalDB,
+		revisionStore: revisionStore,
+	}
+}
+
+func (rr *revisionRetentionService) Start() error {
+	if err := rr.MigrateLegacyRevisions(); err != nil {
+		rr.logger.Error("failed to migrate legacy revisions", logger.Field{Key: "error", Value: err})
+	}
+
+	rr.cron = cron.New(cron.WithSeconds())
+	_, err := rr.cron.AddFunc(rr.cfg.RevisionRetentionSchedule, func() {
+		if err := rr.ApplyRetention(); err != nil {
+			rr.logger.Error("failed to apply revision retention", logger.Field{Key: "error", Value: err})
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule revision retention: %w", err)
+	}
+
+	rr.cron.Start()
+
+	rr.logger.Info(
+		"revision retention service started",
+		logger.Field{Key: "schedule", Value: rr.cfg.RevisionRetentionSchedule},
+		logger.Field{Key: "retention_count", Value: rr.cfg.RevisionRetentionCount},
+		logger.Field{Key: "retention_days", Value: rr.cfg.RevisionRetentionDays},
+	)
+
+	return nil
+}
+
+func (rr *revisionRetentionService) Stop() error {
+	if rr.cron != nil {
+		<-rr.cron.Stop().Done()
+	}
+
+	rr.logger.Info("revision retention service stopped")
+	return nil
+}
+
+// MigrateLegacyRevisions moves revision copies written before the
+// content-addressed store into it, removing the old per-revision directories.
+// Revisions already in the store are skipped, so it is safe to run on every
+// start.
+func (rr *revisionRetentionService) MigrateLegacyRevisions() error {
+	revisions, err := rr.internalDB.GetStoredFileRevisions()
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, revision := range revisions {
+		if rr.revisionStore.IsObjectPath(revision.RevisionPath) {
+			continue
+		}
+
+		data, err := os.ReadFile(revision.RevisionPath)
+		if err != nil {
+			// Missing copies are left alone so that reading the revision still
+			// reports it as corrupted.
+			if !os.IsNotExist(err) {
+				rr.logger.Error("failed to read legacy revision copy", logger.Field{Key: "revision_id", Value: revision.ID}, logger.Field{Key: "error", Value: err})
+			}
+			continue
+		}
+
+		objectPath, err := rr.revisionStore.Put(data)
+		if err != nil {
+			return err
+		}
+
+		if err := rr.internalDB.SetFileRevisionPath(revision.ID, objectPath); err != nil {
+			return err
+		}
+
+		rr.removeLegacyRevisionCopy(revision.RevisionPath)
+		migrated++
+	}
+
+	if migrated > 0 {
+		rr.logger.Info("migrated legacy revisions into the revision store", logger.Field{Key: "count", Value: migrated})
+	}
+
+	return nil
+}
+
+// removeLegacyRevisionCopy removes a legacy copy along with its
+// <fileID>/<revisionID> directories once they are empty.
+func (rr *revisionRetentionService) removeLegacyRevisionCopy(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		rr.logger.Error("failed to remove legacy revision copy", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+		return
+	}
+
+	root := filepath.Clean(rr.cfg.RevisionsDirectory)
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// ApplyRetention prunes revisions outside the retention policy and deletes
+// stored objects that no revision refers to any more.
+func (rr *revisionRetentionService) ApplyRetention() error {
+	revisions, err := rr.internalDB.GetFileRevisionsForRetention()
+	if err != nil {
+		return err
+	}
+
+	pruned := SelectRevisionsToPrune(revisions, rr.cfg.RevisionRetentionCount, rr.cfg.RevisionRetentionDays, time.Now())
+	revisionIDs := make([]int64, len(pruned))
+	for i, revision := range pruned {
+		revisionIDs[i] = revision.ID
+	}
+
+	if err := rr.internalDB.PruneFileRevisions(revisionIDs); err != nil {
+		return err
+	}
+
+	removed, err := rr.removeUnreferencedObjects()
+	if err != nil {
+		return err
+	}
+
+	rr.logger.Info(
+		"applied revision retention",
+		logger.Field{Key: "pruned_revisions", Value: len(revisionIDs)},
+		logger.Field{Key: "removed_objects", Value: removed},
+	)
+
+	return nil
+}
+
+func (rr *revisionRetentionService) removeUnreferencedObjects() (int, error) {
+	revisions, err := rr.internalDB.GetStoredFileRevisions()
+	if err != nil {
+		return 0, err
+	}
+
+	referenced := make(map[string]bool, len(revisions))
+	for _, revision := range revisions {
+		referenced[filepath.Clean(revision.RevisionPath)] = true
+	}
+
+	removed := 0
+	err = filepath.WalkDir(rr.revisionStore.ObjectsDirectory(), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() || referenced[filepath.Clean(path)] || rr.revisionStore.IsRetained(path) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < unreferencedObjectGracePeriod {
+			return nil
+		}
+
+		if err := rr.revisionStore.Delete(path); err == nil {
+			removed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("failed to scan revision objects: %w", err)
+	}
+
+	return removed, nil
+}
+
+// SelectRevisionsToPrune returns the revisions that fall outside the
+// retention policy. For each file a revision is kept while it is one of the
+// newest keepCount revisions or is younger than keepDays days; a zero limit
+// disables that rule, and with both disabled nothing is pruned.
+func SelectRevisionsToPrune(revisions []db.FileRevision, keepCount, keepDays int, now time.Time) []db.FileRevision {
+	if keepCount <= 0 && keepDays <= 0 {
+		return nil
+	}
+
+	sorted := make([]db.FileRevision, len(revisions))
+	copy(sorted, revisions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].FileID != sorted[j].FileID {
+			return sorted[i].FileID < sorted[j].FileID
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	maxAge := time.Duration(keepDays) * 24 * time.Hour
+	var pruned []db.FileRevision
+	rank := 0
+	for i, revision := range sorted {
+		if i == 0 || revision.FileID != sorted[i-1].FileID {
+			rank = 0
+		}
+
+		keepByCount := keepCount > 0 && rank < keepCount
+		keepByAge := keepDays > 0 && now.Sub(revision.CreatedAt) < maxAge
+		if !keepByCount && !keepByAge {
+			pruned = append(pruned, revision)
+		}
+
+		rank++
+	}
+
+	return pruned
+}
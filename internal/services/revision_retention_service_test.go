@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectRevisionsToPrune(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+
+	revisions := []db.FileRevision{
+		{ID: 1, FileID: "a", CreatedAt: daysAgo(40)},
+		{ID: 2, FileID: "a", CreatedAt: daysAgo(20)},
+		{ID: 3, FileID: "a", CreatedAt: daysAgo(5)},
+		{ID: 4, FileID: "a", CreatedAt: daysAgo(1)},
+		{ID: 5, FileID: "b", CreatedAt: daysAgo(90)},
+	}
+
+	prunedIDs := func(pruned []db.FileRevision) []int64 {
+		ids := []int64{}
+		for _, revision := range pruned {
+			ids = append(ids, revision.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name      string
+		keepCount int
+		keepDays  int
+		expected  []int64
+	}{
+		{name: "disabled", keepCount: 0, keepDays: 0, expected: []int64{}},
+		{name: "count only", keepCount: 2, keepDays: 0, expected: []int64{2, 1}},
+		{name: "days only", keepCount: 0, keepDays: 10, expected: []int64{2, 1, 5}},
+		{name: "count or days", keepCount: 1, keepDays: 30, expected: []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, prunedIDs(SelectRevisionsToPrune(revisions, tt.keepCount, tt.keepDays, now)))
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/logger"
+	"github.com/omnihance/omnihance-a3-agent/internal/utils"
+)
+
+const (
+	RevisionCompressionNone = "none"
+	RevisionCompressionGzip = "gzip"
+	RevisionCompressionZstd = "zstd"
+)
+
+// ErrRevisionCorrupted is returned by Get when a stored object cannot be
+// decompressed or its content does not match its hash.
+var ErrRevisionCorrupted = errors.New("revision object is corrupted")
+
+var revisionObjectExtensions = map[string]string{
+	RevisionCompressionNone: "",
+	RevisionCompressionGzip: ".gz",
+	RevisionCompressionZstd: ".zst",
+}
+
+// RevisionStoreService keeps revision copies in a content-addressed store.
+// Each distinct content is stored once under its CalculateFileHash, so saving
+// the same content for many revisions does not use more space. Objects that
+// no revision refers to yet can be retained by whoever stored them, which
+// keeps retention from collecting them.
+type RevisionStoreService interface {
+	Put(data []byte) (string, error)
+	Get(path string) ([]byte, error)
+	Find(hash string) (string, bool)
+	Delete(path string) error
+	IsObjectPath(path string) bool
+	ObjectsDirectory() string
+	Retain(path string)
+	Release(path string)
+	IsRetained(path string) bool
+}
+
+type revisionStoreService struct {
+	cfg    *config.EnvVars
+	logger logger.Logger
+	// retained counts the holders of each retained object.
+	retainedMu sync.Mutex
+	retained   map[string]int
+}
+
+func NewRevisionStoreService(cfg *config.EnvVars, logger logger.Logger) RevisionStoreService {
+	return &revisionStoreService{cfg: cfg, logger: logger, retained: make(map[string]int)}
+}
+
+func (rs *revisionStoreService) ObjectsDirectory() string {
+	return filepath.Join(rs.cfg.RevisionsDirectory, "objects")
+}
+
+// Put stores data and returns the path of its object. If the content is
+// already stored, with any compression, the existing object is returned.
+func (rs *revisionStoreService) Put(data []byte) (string, error) {
+	hash := utils.CalculateFileHash(data)
+	objectDir := filepath.Join(rs.ObjectsDirectory(), hash[:2])
+
+	for _, extension := range revisionObjectExtensions {
+		existingPath := filepath.Join(objectDir, hash+extension)
+		if _, err := os.Stat(existingPath); err == nil {
+			// Refreshing the modification time keeps a reused object from being
+			// collected as unreferenced before the new revision is committed.
+			now := time.Now()
+			if err := os.Chtimes(existingPath, now, now); err != nil {
+				rs.logger.Error("failed to touch revision object", logger.Field{Key: "path", Value: existingPath}, logger.Field{Key: "error", Value: err})
+			}
+
+			return existingPath, nil
+		}
+	}
+
+	extension, ok := revisionObjectExtensions[rs.cfg.RevisionCompression]
+	if !ok {
+		return "", fmt.Errorf("unsupported revision compression %s", rs.cfg.RevisionCompression)
+	}
+
+	encoded, err := compressRevisionData(rs.cfg.RevisionCompression, data)
+	if err != nil {
+		rs.logger.Error("failed to compress revision data", logger.Field{Key: "error", Value: err})
+		return "", fmt.Errorf("failed to compress revision data: %w", err)
+	}
+
+	if err := os.MkdirAll(objectDir, 0755); err != nil {
+		rs.logger.Error("failed to create revision object directory", logger.Field{Key: "error", Value: err})
+		return "", fmt.Errorf("failed to create revision object directory: %w", err)
+	}
+
+	// Objects are written under a temporary name and renamed so that a crash
+	// never leaves a truncated object under its final name.
+	objectPath := filepath.Join(objectDir, hash+extension)
+	tempPath := objectPath + fmt.Sprintf(".%d.tmp", time.Now().UnixNano())
+	if err := os.WriteFile(tempPath, encoded, 0644); err != nil {
+		rs.logger.Error("failed to write revision object", logger.Field{Key: "error", Value: err})
+		return "", fmt.Errorf("failed to write revision object: %w", err)
+	}
+
+	if err := os.Rename(tempPath, objectPath); err != nil {
+		_ = os.Remove(tempPath)
+		rs.logger.Error("failed to write revision object", logger.Field{Key: "error", Value: err})
+		return "", fmt.Errorf("failed to write revision object: %w", err)
+	}
+
+	return objectPath, nil
+}
+
+// Find returns the path of the object holding content with the given hash.
+func (rs *revisionStoreService) Find(hash string) (string, bool) {
+	if len(hash) < 2 {
+		return "", false
+	}
+
+	for _, extension := range revisionObjectExtensions {
+		path := filepath.Join(rs.ObjectsDirectory(), hash[:2], hash+extension)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// Get returns the content stored at path. Objects are decompressed and checked
+// against their hash; paths outside the store are read as plain copies.
+func (rs *revisionStoreService) Get(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.IsObjectPath(path) {
+		return data, nil
+	}
+
+	name := filepath.Base(path)
+	extension := filepath.Ext(name)
+	compression := RevisionCompressionNone
+	for candidate, candidateExtension := range revisionObjectExtensions {
+		if candidateExtension != "" && candidateExtension == extension {
+			compression = candidate
+		}
+	}
+
+	decoded, err := decompressRevisionData(compression, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrRevisionCorrupted, name, err)
+	}
+
+	if hash := strings.TrimSuffix(name, revisionObjectExtensions[compression]); utils.CalculateFileHash(decoded) != hash {
+		return nil, fmt.Errorf("%w: %s: content hash does not match", ErrRevisionCorrupted, name)
+	}
+
+	return decoded, nil
+}
+
+func (rs *revisionStoreService) Delete(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		rs.logger.Error("failed to delete revision object", logger.Field{Key: "path", Value: path}, logger.Field{Key: "error", Value: err})
+		return fmt.Errorf("failed to delete revision object: %w", err)
+	}
+
+	// The fan-out directory is removed once it is empty; os.Remove fails
+	// harmlessly while other objects remain.
+	_ = os.Remove(filepath.Dir(path))
+	return nil
+}
+
+// Retain keeps the object at path from being collected until it is released
+// as many times as it was retained.
+func (rs *revisionStoreService) Retain(path string) {
+	rs.retainedMu.Lock()
+	defer rs.retainedMu.Unlock()
+
+	rs.retained[filepath.Clean(path)]++
+}
+
+func (rs *revisionStoreService) Release(path string) {
+	rs.retainedMu.Lock()
+	defer rs.retainedMu.Unlock()
+
+	path = filepath.Clean(path)
+	if rs.retained[path] <= 1 {
+		delete(rs.retained, path)
+		return
+	}
+
+	rs.retained[path]--
+}
+
+func (rs *revisionStoreService) IsRetained(path string) bool {
+	rs.retainedMu.Lock()
+	defer rs.retainedMu.Unlock()
+
+	return rs.retained[filepath.Clean(path)] > 0
+}
+
+func (rs *revisionStoreService) IsObjectPath(path string) bool {
+	relative, err := filepath.Rel(rs.ObjectsDirectory(), path)
+	return err == nil && relative != "." && !strings.HasPrefix(relative, "..")
+}
+
+func compressRevisionData(compression string, data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	switch compression {
+	case RevisionCompressionNone:
+		return data, nil
+	case RevisionCompressionGzip:
+		writer, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	case RevisionCompressionZstd:
+		writer, err := zstd.NewWriter(&buffer, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported revision compression %s", compression)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func decompressRevisionData(compression string, data []byte) ([]byte, error) {
+	switch compression {
+	case RevisionCompressionNone:
+		return data, nil
+	case RevisionCompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	case RevisionCompressionZstd:
+		reader, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	default:
+		return nil, fmt.Errorf("unsupported revision compression %s", compression)
+	}
+}
+
+// NewDataFileInfo describes in-memory content, so that file type detection
+// can be used on revision copies whose stored size differs from the original.
+func NewDataFileInfo(name string, data []byte) fs.FileInfo {
+	return dataFileInfo{name: filepath.Base(name), size: int64(len(data))}
+}
+
+type dataFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi dataFileInfo) Name() string       { return fi.name }
+func (fi dataFileInfo) Size() int64        { return fi.size }
+func (fi dataFileInfo) Mode() fs.FileMode  { return 0644 }
+func (fi dataFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi dataFileInfo) IsDir() bool        { return false }
+func (fi dataFileInfo) Sys() interface{}   { return nil }
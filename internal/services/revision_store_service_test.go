@@ -0,0 +1,88 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionStoreRoundTrip(t *testing.T) {
+	data := []byte("[server]\nname=A3\nport=10000\n")
+
+	for _, compression := range []string{RevisionCompressionNone, RevisionCompressionGzip, RevisionCompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			rs := NewRevisionStoreService(&config.EnvVars{RevisionsDirectory: t.TempDir(), RevisionCompression: compression}, nil)
+
+			path, err := rs.Put(data)
+			require.NoError(t, err)
+			assert.True(t, rs.IsObjectPath(path))
+
+			stored, err := rs.Get(path)
+			require.NoError(t, err)
+			assert.Equal(t, data, stored)
+		})
+	}
+}
+
+func TestRevisionStoreDeduplicatesAcrossCompressions(t *testing.T) {
+	cfg := &config.EnvVars{RevisionsDirectory: t.TempDir(), RevisionCompression: RevisionCompressionGzip}
+	rs := NewRevisionStoreService(cfg, nil)
+
+	first, err := rs.Put([]byte("same content"))
+	require.NoError(t, err)
+
+	cfg.RevisionCompression = RevisionCompressionZstd
+	second, err := rs.Put([]byte("same content"))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	third, err := rs.Put([]byte("other content"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third)
+	assert.Equal(t, ".zst", filepath.Ext(third))
+}
+
+func TestRevisionStoreDetectsCorruption(t *testing.T) {
+	rs := NewRevisionStoreService(&config.EnvVars{RevisionsDirectory: t.TempDir(), RevisionCompression: RevisionCompressionNone}, nil)
+
+	path, err := rs.Put([]byte("original"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("tampered"), 0644))
+
+	_, err = rs.Get(path)
+	assert.ErrorIs(t, err, ErrRevisionCorrupted)
+}
+
+func TestRevisionStoreReadsLegacyCopies(t *testing.T) {
+	dir := t.TempDir()
+	rs := NewRevisionStoreService(&config.EnvVars{RevisionsDirectory: dir, RevisionCompression: RevisionCompressionZstd}, nil)
+
+	legacyPath := filepath.Join(dir, "abc", "1", "1700000000_file.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacyPath), 0755))
+	require.NoError(t, os.WriteFile(legacyPath, []byte("legacy"), 0644))
+
+	assert.False(t, rs.IsObjectPath(legacyPath))
+	data, err := rs.Get(legacyPath)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("legacy"), data)
+}
+
+func TestRevisionStoreCountsRetains(t *testing.T) {
+	rs := NewRevisionStoreService(&config.EnvVars{RevisionsDirectory: t.TempDir(), RevisionCompression: RevisionCompressionNone}, nil)
+
+	path, err := rs.Put([]byte("watched"))
+	require.NoError(t, err)
+	assert.False(t, rs.IsRetained(path))
+
+	rs.Retain(path)
+	rs.Retain(filepath.Join(filepath.Dir(path), ".", filepath.Base(path)))
+	rs.Release(path)
+	assert.True(t, rs.IsRetained(path))
+
+	rs.Release(path)
+	assert.False(t, rs.IsRetained(path))
+}
@@ -1,113 +1,194 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
 	"github.com/omnihance/omnihance-a3-agent/internal/db"
 	"github.com/omnihance/omnihance-a3-agent/internal/logger"
 	"github.com/omnihance/omnihance-a3-agent/internal/utils"
 )
 
+var (
+	errProcessAlreadyRunning = errors.New("process is already running")
+	errProcessRestarting     = errors.New("is being restarted by the supervisor")
+)
+
+// ServerManagerService starts and stops the configured server processes. Once
+// started it also supervises them: a process that exits while it should be
+// running is restarted, see superviseProcesses. Each process is tracked by the
+// PID and create time of the OS process started for it, see
+// isProcessRunning.
 type ServerManagerService interface {
-	StartServerSequence() error
-	StopServerSequence() error
+	Start() error
+	Stop() error
+	StartServerSequence() ([]ProcessOutcome, error)
+	StopServerSequence() ([]ProcessOutcome, error)
 	StartProcess(id int64) error
 	StopProcess(id int64) error
 	GetProcessStatus(id int64) (*ProcessStatus, error)
 }
 
 type serverManagerService struct {
+	cfg            *config.EnvVars
 	db             db.InternalDB
 	processService ProcessService
+	processLogs    ProcessLogService
 	logger         logger.Logger
+	// mu serializes starting and stopping processes, whether requested or
+	// done by the supervisor.
+	mu sync.Mutex
+	// restarting holds the processes involved in a supervisor restart, which
+	// runs without mu. Requests leave them alone until it is done.
+	restarting map[int64]bool
+	// adoptMu serializes adopting processes, which may happen for several
+	// processes at once while stopping the server.
+	adoptMu    sync.Mutex
+	supervised map[int64]*supervisedProcess
+	done       chan struct{}
+	wg         sync.WaitGroup
 }
 
-func NewServerManagerService(internalDB db.InternalDB, processService ProcessService, log logger.Logger) ServerManagerService {
+func NewServerManagerService(cfg *config.EnvVars, internalDB db.InternalDB, processService ProcessService, processLogs ProcessLogService, log logger.Logger) ServerManagerService {
 	return &serverManagerService{
+		cfg:            cfg,
 		db:             internalDB,
 		processService: processService,
+		processLogs:    processLogs,
 		logger:         log,
+		supervised:     make(map[int64]*supervisedProcess),
+		restarting:     make(map[int64]bool),
 	}
 }
 
-func (s *serverManagerService) StartServerSequence() error {
-	processes, err := s.db.GetServerProcesses()
+// StartServerSequence starts every process after the processes it depends
+// on, starting processes that do not depend on each other in parallel. The
+// processes depending on one that failed to start are skipped. The returned
+// error names them when any process failed or was skipped.
+func (s *serverManagerService) StartServerSequence() ([]ProcessOutcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	graph, err := s.loadProcessGraph()
 	if err != nil {
-		return fmt.Errorf("failed to get server processes: %w", err)
+		return nil, err
 	}
 
-	if len(processes) == 0 {
-		return fmt.Errorf("no processes configured")
-	}
+	outcomes := graph.walk(func(id int64) []int64 {
+		return graph.processes[id].DependsOn
+	}, s.startGraphProcess)
 
-	for i, proc := range processes {
-		s.logger.Info("starting process in sequence", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "order", Value: i + 1})
+	return outcomes, outcomesError(outcomes, "start")
+}
 
-		if err := s.startProcessInternal(&proc); err != nil {
-			s.logger.Error("failed to start process in sequence", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
-			return fmt.Errorf("failed to start process %s: %w", proc.Name, err)
-		}
+// StopServerSequence stops every process after the processes depending on
+// it, the reverse of StartServerSequence. A process is not stopped while a
+// process depending on it failed to stop.
+func (s *serverManagerService) StopServerSequence() ([]ProcessOutcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		s.logger.Info("process started successfully", logger.Field{Key: "name", Value: proc.Name})
+	graph, err := s.loadProcessGraph()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	outcomes := graph.walk(func(id int64) []int64 {
+		return graph.dependents[id]
+	}, s.stopGraphProcess)
+
+	return outcomes, outcomesError(outcomes, "stop")
 }
 
-func (s *serverManagerService) StopServerSequence() error {
+func (s *serverManagerService) loadProcessGraph() (*processGraph, error) {
 	processes, err := s.db.GetServerProcesses()
 	if err != nil {
-		return fmt.Errorf("failed to get server processes: %w", err)
+		return nil, fmt.Errorf("failed to get server processes: %w", err)
 	}
 
 	if len(processes) == 0 {
-		return fmt.Errorf("no processes configured")
+		return nil, fmt.Errorf("no processes configured")
 	}
 
-	for i := len(processes) - 1; i >= 0; i-- {
-		proc := processes[i]
-		s.logger.Info("stopping process in sequence", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "order", Value: len(processes) - i})
-
-		if err := s.stopProcessInternal(&proc); err != nil {
-			s.logger.Error("failed to stop process in sequence", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "error", Value: err})
-			return fmt.Errorf("failed to stop process %s: %w", proc.Name, err)
-		}
-
-		s.logger.Info("process stopped successfully", logger.Field{Key: "name", Value: proc.Name})
+	graph, err := newProcessGraph(processes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process dependencies: %w", err)
 	}
 
-	return nil
+	return graph, nil
 }
 
 func (s *serverManagerService) StartProcess(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	proc, err := s.db.GetServerProcess(id)
 	if err != nil {
 		return fmt.Errorf("failed to get server process: %w", err)
 	}
 
+	if err := s.checkNotRestarting(proc); err != nil {
+		return err
+	}
+
 	return s.startProcessInternal(proc)
 }
 
 func (s *serverManagerService) StopProcess(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	proc, err := s.db.GetServerProcess(id)
 	if err != nil {
 		return fmt.Errorf("failed to get server process: %w", err)
 	}
 
+	if err := s.checkNotRestarting(proc); err != nil {
+		return err
+	}
+
 	return s.stopProcessInternal(proc)
 }
 
 func (s *serverManagerService) startProcessInternal(proc *db.ServerProcess) error {
-	timeout := 60 * time.Second
-	checkInterval := 2 * time.Second
+	isRunning, err := s.isProcessRunning(proc)
+	if err != nil {
+		s.logger.Warn("failed to check if process is running", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+	}
+
+	if isRunning {
+		return fmt.Errorf("%w with pid %d", errProcessAlreadyRunning, *proc.PID)
+	}
 
 	var port *int
 	if proc.Port != nil {
 		port = proc.Port
 	}
 
-	if err := s.processService.StartProcessWithHealthCheck(proc.Path, port, timeout, checkInterval); err != nil {
+	stdout, stderr := s.processLogs.Output(proc.ID)
+	options := ProcessStartOptions{
+		Args:   proc.Args,
+		Env:    processEnvList(proc.Env),
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	if proc.WorkingDir != nil {
+		options.Dir = *proc.WorkingDir
+	}
+
+	info, err := s.processService.StartProcessWithHealthCheck(proc.Path, port, proc.StartTimeout(), proc.CheckInterval(), options)
+	if info != nil {
+		// Recorded even when the health check failed, so that a process
+		// left running can still be stopped.
+		s.recordProcessPID(proc, info)
+	}
+
+	if err != nil {
 		return fmt.Errorf("failed to start process: %w", err)
 	}
 
@@ -116,14 +197,38 @@ func (s *serverManagerService) startProcessInternal(proc *db.ServerProcess) erro
 		s.logger.Warn("failed to update process start time", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
 	}
 
-	s.logger.Info("process started and health check passed", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID})
+	s.logger.Info("process started and health check passed", logger.Field{Key: "name", Value: proc.Name}, logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "pid", Value: info.PID})
 
 	return nil
 }
 
+// stopProcessInternal records the end time even when the process is no longer
+// running, so that stopping a crashed process ends its supervision. A process
+// without a running PID may have been started outside the agent and is looked
+// up by path first.
 func (s *serverManagerService) stopProcessInternal(proc *db.ServerProcess) error {
-	if err := s.processService.StopProcess(proc.Path); err != nil {
-		return fmt.Errorf("failed to stop process: %w", err)
+	isRunning, err := s.isProcessRunning(proc)
+	if err != nil {
+		s.logger.Warn("failed to check if process is running", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+		isRunning = proc.PID != nil && proc.PIDCreateTime != nil
+	}
+
+	if !isRunning && err == nil {
+		isRunning = s.adoptProcess(proc)
+	}
+
+	if isRunning {
+		if err := s.processService.StopProcessByPID(*proc.PID, *proc.PIDCreateTime); err != nil {
+			return fmt.Errorf("failed to stop process: %w", err)
+		}
+	}
+
+	if proc.PID != nil {
+		proc.PID = nil
+		proc.PIDCreateTime = nil
+		if err := s.db.UpdateProcessPID(proc.ID, nil, nil); err != nil {
+			s.logger.Warn("failed to clear process pid", logger.Field{Key: "id", Value: proc.ID}, logger.Field{Key: "error", Value: err})
+		}
 	}
 
 	now := time.Now()
@@ -142,7 +247,7 @@ func (s *serverManagerService) GetProcessStatus(id int64) (*ProcessStatus, error
 		return nil, fmt.Errorf("failed to get server process: %w", err)
 	}
 
-	isRunning, err := s.processService.IsProcessRunning(proc.Path)
+	isRunning, err := s.isProcessRunning(proc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if process is running: %w", err)
 	}
@@ -151,6 +256,10 @@ func (s *serverManagerService) GetProcessStatus(id int64) (*ProcessStatus, error
 		Running: isRunning,
 	}
 
+	if isRunning {
+		status.PID = proc.PID
+	}
+
 	if proc.Port != nil {
 		portOpen, err := utils.IsPortOpen("127.0.0.1", *proc.Port, 2*time.Second)
 		if err == nil {
@@ -178,9 +287,27 @@ func (s *serverManagerService) GetProcessStatus(id int64) (*ProcessStatus, error
 
 type ProcessStatus struct {
 	Running              bool    `json:"running"`
+	PID                  *int    `json:"pid,omitempty"`
 	PortOpen             *bool   `json:"port_open,omitempty"`
 	StartTime            *string `json:"start_time,omitempty"`
 	EndTime              *string `json:"end_time,omitempty"`
 	CurrentUptimeSeconds *int64  `json:"current_uptime_seconds,omitempty"`
 	LastUptimeSeconds    *int64  `json:"last_uptime_seconds,omitempty"`
 }
+
+// processEnvList returns env in the "KEY=value" form used by exec.Cmd, sorted
+// by key.
+func processEnvList(env db.ProcessEnv) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, key+"="+env[key])
+	}
+
+	return list
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omnihance/omnihance-a3-agent/internal/config"
+	"github.com/omnihance/omnihance-a3-agent/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartProcessUsesLaunchConfig(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	workingDir := "/srv/a3/zone2"
+	timeout := 90
+	interval := 5
+	zoneServer := &db.ServerProcess{
+		ID:   2,
+		Name: "ZoneServer 2",
+		Path: "zone.exe",
+		ServerProcessLaunch: db.ServerProcessLaunch{
+			Args:                 db.ProcessArgs{"-zone", "2"},
+			Env:                  db.ProcessEnv{"ZONE_PORT": "9002", "ZONE_ID": "2"},
+			WorkingDir:           &workingDir,
+			StartTimeoutSeconds:  &timeout,
+			CheckIntervalSeconds: &interval,
+		},
+	}
+
+	var options ProcessStartOptions
+	internalDB.EXPECT().GetServerProcess(int64(2)).Return(zoneServer, nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("zone.exe", (*int)(nil), 90*time.Second, 5*time.Second, mock.Anything).
+		Run(func(path string, port *int, timeout time.Duration, checkInterval time.Duration, opts ProcessStartOptions) {
+			options = opts
+		}).
+		Return(&ProcessInfo{PID: 4242}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(2), intPtr(4242), mock.Anything).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(2), mock.Anything).Return(nil).Once()
+
+	require.NoError(t, s.StartProcess(2))
+
+	assert.Equal(t, []string{"-zone", "2"}, options.Args)
+	assert.Equal(t, []string{"ZONE_ID=2", "ZONE_PORT=9002"}, options.Env)
+	assert.Equal(t, workingDir, options.Dir)
+	assert.NotNil(t, options.Stdout)
+	assert.NotNil(t, options.Stderr)
+}
+
+func TestStartProcessUsesDefaultLaunchConfig(t *testing.T) {
+	s, internalDB, processService := newSupervisorTestManager(t, &config.EnvVars{})
+
+	accountServer := &db.ServerProcess{ID: 1, Name: "AccountServer", Path: "account.exe"}
+
+	var options ProcessStartOptions
+	internalDB.EXPECT().GetServerProcess(int64(1)).Return(accountServer, nil).Once()
+	processService.EXPECT().StartProcessWithHealthCheck("account.exe", (*int)(nil), 60*time.Second, 2*time.Second, mock.Anything).
+		Run(func(path string, port *int, timeout time.Duration, checkInterval time.Duration, opts ProcessStartOptions) {
+			options = opts
+		}).
+		Return(&ProcessInfo{PID: 4241}, nil).Once()
+	internalDB.EXPECT().UpdateProcessPID(int64(1), intPtr(4241), mock.Anything).Return(nil).Once()
+	internalDB.EXPECT().UpdateProcessStartTime(int64(1), mock.Anything).Return(nil).Once()
+
+	require.NoError(t, s.StartProcess(1))
+
+	assert.Empty(t, options.Args)
+	assert.Empty(t, options.Env)
+	assert.Empty(t, options.Dir)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	SpawnProblemOutOfBounds = "out_of_bounds"
+	SpawnProblemNotWalkable = "not_walkable"
+	SpawnProblemDuplicate   = "duplicate_coordinates"
+)
+
+// SpawnIssue describes a problem with the spawn at Index. Duplicate
+// coordinates are reported as warnings; the other problems make the spawn
+// file invalid.
+type SpawnIssue struct {
+	Index   int    `json:"index"`
+	ID      uint16 `json:"id"`
+	X       byte   `json:"x"`
+	Y       byte   `json:"y"`
+	Problem string `json:"problem"`
+	Detail  string `json:"detail"`
+}
+
+// IsWarning reports whether the issue should not prevent saving.
+func (issue SpawnIssue) IsWarning() bool {
+	return issue.Problem == SpawnProblemDuplicate
+}
+
+// IsMapCellWalkable reports whether the cell at x, y can be walked on. Cells
+// with an attribute of zero are open ground; any other attribute blocks
+// movement. Cells outside the map are not walkable.
+func IsMapCellWalkable(mapData *MapFileData, x, y uint32) bool {
+	if x >= mapData.Width || y >= mapData.Height {
+		return false
+	}
+
+	return mapData.Cells[uint64(y)*uint64(mapData.Width)+uint64(x)] == 0
+}
+
+// ValidateSpawnPlacement checks spawn coordinates against the map grid and
+// against each other. Without a map only duplicates are reported. A spawn
+// sharing its coordinates with an earlier one is reported once, pointing at
+// the first spawn on that cell.
+func ValidateSpawnPlacement(spawns []NPCSpawnData, mapData *MapFileData) []SpawnIssue {
+	issues := []SpawnIssue{}
+	firstAt := make(map[[2]byte]int, len(spawns))
+
+	for i, spawn := range spawns {
+		issue := SpawnIssue{Index: i, ID: spawn.Id, X: spawn.X, Y: spawn.Y}
+
+		if mapData != nil {
+			x, y := uint32(spawn.X), uint32(spawn.Y)
+			switch {
+			case x >= mapData.Width || y >= mapData.Height:
+				issue.Problem = SpawnProblemOutOfBounds
+				issue.Detail = fmt.Sprintf("(%d, %d) is outside the %dx%d map", x, y, mapData.Width, mapData.Height)
+				issues = append(issues, issue)
+			case !IsMapCellWalkable(mapData, x, y):
+				issue.Problem = SpawnProblemNotWalkable
+				issue.Detail = fmt.Sprintf("(%d, %d) is a blocked cell", x, y)
+				issues = append(issues, issue)
+			}
+		}
+
+		position := [2]byte{spawn.X, spawn.Y}
+		if first, ok := firstAt[position]; ok {
+			issue.Problem = SpawnProblemDuplicate
+			issue.Detail = fmt.Sprintf("same coordinates as spawn %d", first)
+			issues = append(issues, issue)
+			continue
+		}
+
+		firstAt[position] = i
+	}
+
+	return issues
+}
+
+// UntouchedSpawns reports for each spawn in spawns whether previous holds an
+// identical spawn, so that an edit can tell the spawns it left alone from the
+// ones it added or changed. Each spawn in previous matches at most one spawn.
+func UntouchedSpawns(previous, spawns []NPCSpawnData) []bool {
+	remaining := make(map[NPCSpawnData]int, len(previous))
+	for _, spawn := range previous {
+		remaining[spawn]++
+	}
+
+	untouched := make([]bool, len(spawns))
+	for i, spawn := range spawns {
+		if remaining[spawn] > 0 {
+			remaining[spawn]--
+			untouched[i] = true
+		}
+	}
+
+	return untouched
+}
+
+type SpawnGroup struct {
+	MonsterID uint16 `json:"monster_id"`
+	Count     int    `json:"count"`
+	// Indexes are the positions of the group's spawns in the file.
+	Indexes []int `json:"indexes"`
+}
+
+// GroupSpawnsByMonster counts spawns per monster ID, most frequent first and
+// by ID for equal counts.
+func GroupSpawnsByMonster(spawns []NPCSpawnData) []SpawnGroup {
+	groupIndex := make(map[uint16]int)
+	groups := []SpawnGroup{}
+
+	for i, spawn := range spawns {
+		index, ok := groupIndex[spawn.Id]
+		if !ok {
+			index = len(groups)
+			groupIndex[spawn.Id] = index
+			groups = append(groups, SpawnGroup{MonsterID: spawn.Id, Indexes: []int{}})
+		}
+
+		groups[index].Count++
+		groups[index].Indexes = append(groups[index].Indexes, i)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+
+		return groups[i].MonsterID < groups[j].MonsterID
+	})
+
+	return groups
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpawnPlacement(t *testing.T) {
+	mapData := &MapFileData{
+		Width:  3,
+		Height: 2,
+		Cells: []byte{
+			0, 0, 1,
+			0, 4, 0,
+		},
+	}
+
+	spawns := []NPCSpawnData{
+		{Id: 1, X: 0, Y: 0},
+		{Id: 2, X: 2, Y: 0},
+		{Id: 3, X: 3, Y: 1},
+		{Id: 4, X: 0, Y: 0},
+		{Id: 5, X: 1, Y: 1},
+		{Id: 6, X: 2, Y: 1},
+	}
+
+	issues := ValidateSpawnPlacement(spawns, mapData)
+	assert.Equal(t, []SpawnIssue{
+		{Index: 1, ID: 2, X: 2, Y: 0, Problem: SpawnProblemNotWalkable, Detail: "(2, 0) is a blocked cell"},
+		{Index: 2, ID: 3, X: 3, Y: 1, Problem: SpawnProblemOutOfBounds, Detail: "(3, 1) is outside the 3x2 map"},
+		{Index: 3, ID: 4, X: 0, Y: 0, Problem: SpawnProblemDuplicate, Detail: "same coordinates as spawn 0"},
+		{Index: 4, ID: 5, X: 1, Y: 1, Problem: SpawnProblemNotWalkable, Detail: "(1, 1) is a blocked cell"},
+	}, issues)
+	assert.True(t, issues[2].IsWarning())
+	assert.False(t, issues[0].IsWarning())
+
+	issues = ValidateSpawnPlacement(spawns, nil)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, SpawnProblemDuplicate, issues[0].Problem)
+}
+
+func TestUntouchedSpawns(t *testing.T) {
+	previous := []NPCSpawnData{{Id: 1, X: 5}, {Id: 2, X: 6}, {Id: 1, X: 5}}
+	spawns := []NPCSpawnData{{Id: 2, X: 6}, {Id: 1, X: 5}, {Id: 1, X: 5}, {Id: 1, X: 5}, {Id: 2, X: 7}}
+
+	assert.Equal(t, []bool{true, true, true, false, false}, UntouchedSpawns(previous, spawns))
+	assert.Equal(t, []bool{false}, UntouchedSpawns(nil, spawns[:1]))
+}
+
+func TestGroupSpawnsByMonster(t *testing.T) {
+	spawns := []NPCSpawnData{{Id: 7}, {Id: 3}, {Id: 7}, {Id: 5}, {Id: 3}, {Id: 7}}
+
+	assert.Equal(t, []SpawnGroup{
+		{MonsterID: 7, Count: 3, Indexes: []int{0, 2, 5}},
+		{MonsterID: 3, Count: 2, Indexes: []int{1, 4}},
+		{MonsterID: 5, Count: 1, Indexes: []int{3}},
+	}, GroupSpawnsByMonster(spawns))
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"io"
 	"strings"
 )
 
@@ -15,3 +16,14 @@ func CalculateFileHash(data []byte) string {
 	hash := md5.Sum(data)
 	return hex.EncodeToString(hash[:])
 }
+
+// CalculateReaderHash returns the same hash as CalculateFileHash without
+// holding the whole content in memory.
+func CalculateReaderHash(reader io.Reader) (string, error) {
+	hash := md5.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}